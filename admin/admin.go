@@ -0,0 +1,549 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package admin provides a HTTP admin API to inspect and manage a sq_cache LRUCache of a running process.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// Stats represents the response of the stats endpoint.
+type Stats struct {
+	Status    string           `json:"status"`
+	Len       int64            `json:"len"`
+	MaxItems  int64            `json:"maxItems"`
+	MaxShards int64            `json:"maxShards"`
+	Cleanup   bool             `json:"cleanupRunning"`
+	Telemetry map[string]int64 `json:"telemetry,omitempty"`
+}
+
+// Entries represents the response of the entries endpoint.
+type Entries struct {
+	Entries    []Entry `json:"entries"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// Entry represents a listed item of the entries endpoint.
+type Entry struct {
+	Key     string `json:"key"`
+	Size    int    `json:"size"`
+	TTL     string `json:"ttl,omitempty"`
+	Version uint64 `json:"version"`
+	Cost    int64  `json:"cost"`
+	Hits    int64  `json:"hits"`
+}
+
+// defaultEntriesLimit is the page size of the entries endpoint, if no limit is specified.
+const defaultEntriesLimit = 100
+
+// defaultHotLimit is the number of hot keys of the hot endpoint, if no limit is specified.
+const defaultHotLimit = 1000
+
+// Handler is a http.Handler serving the admin API of a LRUCache:
+//
+//	GET    /stats        returns the Stats as JSON
+//	GET    /entries      returns a page of the items without their values as Entries, paged by the "cursor" and
+//	                     "limit" query parameters (see LRUCache.ListEntries)
+//	GET    /keys/{key}   returns the value of the key
+//	PUT    /keys/{key}   sets the value of the key to the request body, the optional "ttl" query parameter is parsed
+//	                     by time.ParseDuration
+//	DELETE /keys/{key}   removes the key
+//	POST   /purge        removes all items
+//	POST   /cleanup      removes all expired items
+//	GET    /export       streams a snapshot of the items, the optional "limit" query parameter limits the snapshot to
+//	                     the most recently accessed items (see WarmFromPeer)
+//	GET    /hot          streams a snapshot of the items of the most frequently accessed keys, limited by the "limit"
+//	                     query parameter (see ReplicateHot)
+type Handler struct {
+	cache  *sq_cache.LRUCache[string, []byte]
+	mux    *http.ServeMux
+	limits *Limits
+}
+
+// NewHandler creates a Handler, which serves the admin API of the specified cache. The handler doesn't authenticate
+// the requests, it must only be exposed on a trusted (e.g. pod-local) listener, or be protected by Authorized or
+// served by NewServer.
+//
+// Parameters:
+//   - cache: The cache to manage.
+//
+// Returns:
+//   - handler: The created Handler object.
+//
+// Example Usage:
+//
+//	go http.ListenAndServe("127.0.0.1:6061", admin.NewHandler(cache))
+func NewHandler(cache *sq_cache.LRUCache[string, []byte]) (handler *Handler) {
+	handler = &Handler{
+		cache: cache,
+		mux:   http.NewServeMux(),
+	}
+
+	handler.mux.HandleFunc("GET /stats", handler.stats)
+	handler.mux.HandleFunc("GET /entries", handler.entries)
+	handler.mux.HandleFunc("GET /keys/{key}", handler.get)
+	handler.mux.HandleFunc("PUT /keys/{key}", handler.set)
+	handler.mux.HandleFunc("DELETE /keys/{key}", handler.remove)
+	handler.mux.HandleFunc("POST /purge", handler.purge)
+	handler.mux.HandleFunc("POST /cleanup", handler.cleanup)
+	handler.mux.HandleFunc("GET /export", handler.export)
+	handler.mux.HandleFunc("GET /hot", handler.hot)
+
+	return handler
+}
+
+// ServeHTTP dispatches the request to the endpoint of the admin API.
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.mux.ServeHTTP(w, r)
+}
+
+// stats writes the Stats of the cache as JSON.
+func (handler *Handler) stats(w http.ResponseWriter, r *http.Request) {
+	stats := Stats{
+		Status:    statusName(handler.cache.Status()),
+		Len:       handler.cache.Len(),
+		MaxItems:  handler.cache.MaxItems(),
+		MaxShards: handler.cache.MaxShards(),
+		Cleanup:   handler.cache.IsCleanupRunning(),
+	}
+
+	if telemetry, err := handler.cache.Telemetry(); err == nil {
+		stats.Telemetry = map[string]int64{
+			"add":               telemetry.GetAddCounter(),
+			"update":            telemetry.GetUpdateCounter(),
+			"hit":               telemetry.GetHitCounter(),
+			"miss":              telemetry.GetMissCounter(),
+			"evict":             telemetry.GetEvictCounter(),
+			"loadSuccess":       telemetry.GetLoadSuccessCounter(),
+			"loadFailure":       telemetry.GetLoadFailureCounter(),
+			"loadTimeout":       telemetry.GetLoadTimeoutCounter(),
+			"oversized":         telemetry.GetOversizedCounter(),
+			"rejected":          telemetry.GetRejectedCounter(),
+			"lock":              telemetry.GetLockCounter(),
+			"lockContention":    telemetry.GetLockContentionCounter(),
+			"lockWait":          telemetry.GetLockWaitCounter(),
+			"recommendedShards": telemetry.GetRecommendedShardsCounter(),
+			"snapshotTime":      telemetry.GetSnapshotTimeCounter(),
+			"snapshotBytes":     telemetry.GetSnapshotBytesCounter(),
+			"snapshotItems":     telemetry.GetSnapshotItemsCounter(),
+			"auditDropped":      telemetry.GetAuditDroppedCounter(),
+			"walDropped":        telemetry.GetWALDroppedCounter(),
+			"setFill":           telemetry.GetSetFillCounter(),
+			"loaderFill":        telemetry.GetLoaderFillCounter(),
+			"peerFill":          telemetry.GetPeerFillCounter(),
+			"promotionFill":     telemetry.GetPromotionFillCounter(),
+			"restoreFill":       telemetry.GetRestoreFillCounter(),
+			"loadCoalesced":     telemetry.GetLoadCoalescedCounter(),
+			"victimHit":         telemetry.GetVictimHitCounter(),
+			"ghostHit":          telemetry.GetGhostHitCounter(),
+		}
+		for step, saved := range telemetry.GetMissesSaved() {
+			stats.Telemetry["missesSaved"+strconv.Itoa((step+1)*10)] = saved
+		}
+	}
+
+	if handler.limits != nil {
+		if stats.Telemetry == nil {
+			stats.Telemetry = make(map[string]int64, 2)
+		}
+		stats.Telemetry["rejectedConnections"] = handler.limits.RejectedConnections()
+		stats.Telemetry["rejectedRequests"] = handler.limits.RejectedRequests()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// entries writes a page of the items, starting at the "cursor" query parameter.
+func (handler *Handler) entries(w http.ResponseWriter, r *http.Request) {
+	limit := defaultEntriesLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	infos, next, err := handler.cache.ListEntries(r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if handler.cache.Status() == sq_cache.Started {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	entries := Entries{Entries: make([]Entry, 0, len(infos)), NextCursor: next}
+	for _, info := range infos {
+		entry := Entry{Key: info.Key, Size: info.Size, Version: info.Version, Cost: info.Cost, Hits: info.Hits}
+		if info.TTL != sq_cache.NoExpiry {
+			entry.TTL = info.TTL.String()
+		}
+		entries.Entries = append(entries.Entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+// get writes the value of the key.
+func (handler *Handler) get(w http.ResponseWriter, r *http.Request) {
+	value, err := handler.cache.Peek(r.PathValue("key"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if value == nil {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(value)
+}
+
+// set sets the value of the key to the request body.
+func (handler *Handler) set(w http.ResponseWriter, r *http.Request) {
+	var ttl time.Duration
+	if param := r.URL.Query().Get("ttl"); param != "" {
+		var err error
+		if ttl, err = time.ParseDuration(param); err != nil {
+			http.Error(w, "invalid ttl: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	value, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ttl <= 0 {
+		ttl = sq_cache.NoExpiry
+	}
+	if _, err = handler.cache.SetContext(auditContext(r), r.PathValue("key"), value, ttl); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// remove removes the key.
+func (handler *Handler) remove(w http.ResponseWriter, r *http.Request) {
+	removed, err := handler.cache.RemoveContext(auditContext(r), r.PathValue("key"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if !removed {
+		http.Error(w, "key not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// purge removes all items. A started cache is stopped for the duration of the purge.
+func (handler *Handler) purge(w http.ResponseWriter, r *http.Request) {
+	if handler.cache.Status() == sq_cache.Started {
+		handler.cache.Stop()
+		defer handler.cache.Start()
+	}
+
+	if _, err := handler.cache.PurgeContext(auditContext(r)); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// auditContext returns the context of the request for the audit log of the cache: the operations are recorded as
+// AdminOrigin, and attributed to the common name of the verified client certificate, or else to the remote address.
+func auditContext(r *http.Request) (ctx context.Context) {
+	actor := r.RemoteAddr
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		actor = r.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+
+	return sq_cache.WithActor(sq_cache.WithOrigin(r.Context(), sq_cache.AdminOrigin), actor)
+}
+
+// cleanup removes all expired items.
+func (handler *Handler) cleanup(w http.ResponseWriter, r *http.Request) {
+	if err := handler.cache.Cleanup(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// export streams a snapshot of the items, limited to the most recently accessed items by the "limit" query parameter.
+func (handler *Handler) export(w http.ResponseWriter, r *http.Request) {
+	var limit int64
+	if param := r.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.ParseInt(param, 10, 64); err != nil || limit < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if status := handler.cache.Status(); status != sq_cache.Started {
+		http.Error(w, "cache is "+statusName(status), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if limit > 0 {
+		_, _ = handler.cache.WriteNewestSnapshot(r.Context(), w, limit)
+	} else {
+		_, _ = handler.cache.WriteSnapshot(r.Context(), w)
+	}
+}
+
+// hot streams a snapshot of the items of the hottest keys.
+func (handler *Handler) hot(w http.ResponseWriter, r *http.Request) {
+	limit := defaultHotLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if status := handler.cache.Status(); status != sq_cache.Started {
+		http.Error(w, "cache is "+statusName(status), http.StatusServiceUnavailable)
+		return
+	}
+
+	if _, err := handler.cache.TopKeys(0); err != nil {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = handler.cache.WriteHotSnapshot(r.Context(), w, limit)
+}
+
+// WarmFromPeer warms the cache with the most recently accessed items of a peer, which serves the admin API, e.g. to
+// avoid a storm of misses after a deploy. It should be called after the cache was created and before traffic is
+// served. The snapshot of the peer is validated by its checksum before any item is restored, so a broken transfer
+// leaves the cache cold, but consistent.
+//
+// Parameters:
+//   - ctx: The context of the transfer, e.g. with a timeout to bound the startup delay.
+//   - cache: The cache to warm.
+//   - client: The client to request the peer by, http.DefaultClient is used if nil.
+//   - peerURL: The URL of the admin API of the peer, e.g. "http://10.0.0.12:6061".
+//   - limit: The maximum number of items to transfer, zero transfers all items.
+//
+// Returns:
+//   - count: The number of restored items.
+//   - err: An error if the peer can't be requested, if it responds with an error, if the snapshot is invalid, or if
+//     any other issue occurs.
+//
+// Example Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+//	defer cancel()
+//	count, err := admin.WarmFromPeer(ctx, cache, nil, "http://10.0.0.12:6061", 50000)
+//	if err != nil {
+//	    log.Printf("cold start: %v", err)
+//	}
+func WarmFromPeer(
+	ctx context.Context, cache *sq_cache.LRUCache[string, []byte], client *http.Client, peerURL string, limit int64,
+) (count int64, err error) {
+	path := "/export"
+	if limit > 0 {
+		path += "?limit=" + strconv.FormatInt(limit, 10)
+	}
+
+	return readPeerSnapshot(ctx, client, peerURL, path, func(r io.Reader) (int64, error) {
+		return cache.ReadSnapshot(sq_cache.WithOrigin(ctx, sq_cache.PeerOrigin), r)
+	})
+}
+
+// ReplicateHot replicates the hot items of the peers to the instance: it pulls the items of the most frequently
+// accessed keys from the "/hot" endpoint of every other peer of the ring and restores the items of the keys, which the
+// instance is one of the replicas of (see sq_cache.PeerRing.Replicas). So a hot key is served by replicas peers
+// instead of only by its owner, which spreads the load of a skewed (zipfian) traffic. It should be called periodically
+// to exchange the hot sets, the hot key tracking of the peers must be enabled by HotKeysCapacity.
+//
+// Parameters:
+//   - ctx: The context of the transfers.
+//   - cache: The cache to replicate to.
+//   - client: The client to request the peers by, http.DefaultClient is used if nil.
+//   - ring: The ring of the URLs of the admin APIs of the peers, e.g. updated by sq_cache.WatchPeers.
+//   - self: The URL of the admin API of the instance on the ring.
+//   - replicas: The number of peers serving a hot key, including its owner.
+//   - limit: The number of hot keys requested from every peer.
+//
+// Returns:
+//   - count: The number of restored items.
+//   - err: The joined errors of the peers, which couldn't be replicated, or nil.
+//
+// Example Usage:
+//
+//	ticker := time.NewTicker(time.Second * 30)
+//	for range ticker.C {
+//	    _, err := admin.ReplicateHot(ctx, cache, nil, ring, "http://10.0.0.11:6061", 3, 1000)
+//	    if err != nil {
+//	        log.Printf("hot replication: %v", err)
+//	    }
+//	}
+func ReplicateHot(
+	ctx context.Context, cache *sq_cache.LRUCache[string, []byte], client *http.Client, ring *sq_cache.PeerRing,
+	self string, replicas, limit int,
+) (count int64, err error) {
+	keep := func(entry sq_cache.Entry[string, []byte]) bool {
+		return slices.Contains(ring.Replicas(entry.Key, replicas), self)
+	}
+
+	var errs []error
+	for _, peer := range ring.Peers() {
+		if peer == self {
+			continue
+		}
+
+		path := "/hot?limit=" + strconv.Itoa(limit)
+		replicated, err := readPeerSnapshot(ctx, client, peer, path, func(r io.Reader) (int64, error) {
+			return cache.ReadSnapshotFunc(sq_cache.WithOrigin(ctx, sq_cache.PeerOrigin), r, keep)
+		})
+		count += replicated
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// readPeerSnapshot requests the snapshot of the specified path of the admin API of a peer and reads it by read.
+func readPeerSnapshot(
+	ctx context.Context, client *http.Client, peerURL, path string, read func(r io.Reader) (int64, error),
+) (count int64, err error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(peerURL, "/")+path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return 0, fmt.Errorf("admin: peer %s responded %s: %s", peerURL, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	return read(response.Body)
+}
+
+// WarmFromPeers warms the cache by WarmFromPeer from the first discovered peer, which responds, so that the peers of a
+// deploy don't have to be configured by hand. The peers are tried in their discovered order.
+//
+// Parameters:
+//   - ctx: The context of the discovery and the transfer.
+//   - cache: The cache to warm.
+//   - client: The client to request the peers by, http.DefaultClient is used if nil.
+//   - discovery: The discovery of the URLs of the admin APIs of the peers, should exclude the instance itself.
+//   - limit: The maximum number of items to transfer, zero transfers all items.
+//
+// Returns:
+//   - count: The number of restored items.
+//   - err: An error if the peers can't be discovered, if no peer was discovered, the error of the last peer if no peer
+//     responds, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	discovery := sq_cache.DNSPeers{Host: "cache.default.svc.cluster.local", Port: "6061"}
+//	count, err := admin.WarmFromPeers(ctx, cache, nil, discovery, 50000)
+func WarmFromPeers(
+	ctx context.Context, cache *sq_cache.LRUCache[string, []byte], client *http.Client, discovery sq_cache.PeerDiscovery,
+	limit int64,
+) (count int64, err error) {
+	peers, err := discovery.Peers(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(peers) == 0 {
+		return 0, errors.New("admin: no peer discovered")
+	}
+
+	for _, peer := range peers {
+		if count, err = WarmFromPeer(ctx, cache, client, peer, limit); err == nil {
+			return count, nil
+		}
+	}
+
+	return 0, err
+}
+
+// statusName returns the name of the cache status.
+func statusName(status sq_cache.CacheStatus) (name string) {
+	switch status {
+	case sq_cache.Opened:
+		return "opened"
+	case sq_cache.Started:
+		return "started"
+	case sq_cache.Stopped:
+		return "stopped"
+	case sq_cache.Closed:
+		return "closed"
+	default:
+		return strconv.Itoa(int(status))
+	}
+}
@@ -0,0 +1,298 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package admin
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// ErrUnauthorized is returned by an Authorizer, if the request doesn't carry valid credentials. It is answered with
+// the status 401, any other error of an Authorizer with the status 403.
+var ErrUnauthorized = errors.New("admin: unauthorized")
+
+// Authorizer authorizes a request to the admin API, e.g. by the verified client certificate of the TLS connection
+// (r.TLS.VerifiedChains) or by a token of the request. A nil error grants the request.
+type Authorizer func(r *http.Request) (err error)
+
+// ServerOptions configures the server of the admin API created by NewServer.
+type ServerOptions struct {
+	// Addr is the TCP address to listen on, e.g. ":6061".
+	Addr string
+	// CertFile and KeyFile are the PEM files of the certificate and the private key of the server. Both are required,
+	// unless Insecure is set.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the PEM file of the certificate authorities of the client certificates. If set, every client
+	// must present a certificate verified by them (mutual TLS).
+	ClientCAFile string
+	// Authorize authorizes every request, after the client certificate was verified, if a ClientCAFile is set. Nil
+	// grants every request of a client verified by the ClientCAFile, it is required without a ClientCAFile.
+	Authorize Authorizer
+	// Insecure serves plain HTTP without TLS, e.g. on a pod-local listener. An Authorizer is still required.
+	Insecure bool
+	// Limits bounds the connections and the requests of the server, nil doesn't limit them.
+	Limits *Limits
+}
+
+// ClientOptions configures the client created by NewClient, e.g. to request the admin API of the peers.
+type ClientOptions struct {
+	// CertFile and KeyFile are the PEM files of the client certificate and its private key, presented to a server
+	// requiring mutual TLS.
+	CertFile string
+	KeyFile  string
+	// CAFile is the PEM file of the certificate authorities of the server certificates, the system pool if empty.
+	CAFile string
+	// Token is sent as bearer token in the Authorization header of every request, if set.
+	Token string
+}
+
+// NewServer creates a http.Server, which serves the admin API of the specified cache by TLS, verifies the client
+// certificates and authorizes every request. It is started by ListenAndServeTLS with empty file names, or by
+// ListenAndServe if the options are insecure.
+//
+// Parameters:
+//   - cache: The cache to manage.
+//   - options: The options of the server.
+//
+// Returns:
+//   - server: The created server.
+//   - err: An error if neither a ClientCAFile (for a secure server) nor an Authorizer is configured, so that the server
+//     would be unauthenticated, if the certificates can't be loaded, if no certificate is configured for a secure
+//     server, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	server, err := admin.NewServer(cache, admin.ServerOptions{
+//	    Addr:         ":6061",
+//	    CertFile:     "/etc/cache/tls.crt",
+//	    KeyFile:      "/etc/cache/tls.key",
+//	    ClientCAFile: "/etc/cache/ca.crt",
+//	    Authorize:    admin.AllowCommonNames("cache-0", "cache-1", "operator"),
+//	})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	go server.ListenAndServeTLS("", "")
+func NewServer(cache *sq_cache.LRUCache[string, []byte], options ServerOptions) (server *http.Server, err error) {
+	if options.Authorize == nil && (options.Insecure || options.ClientCAFile == "") {
+		return nil, errors.New("admin: a client CA or an authorizer is required, the server must not be unauthenticated")
+	}
+
+	handler := NewHandler(cache)
+	handler.limits = options.Limits
+	server = &http.Server{
+		Addr:    options.Addr,
+		Handler: Authorized(handler, options.Authorize),
+	}
+	if options.Limits != nil {
+		options.Limits.Apply(server)
+	}
+
+	if options.Insecure {
+		return server, nil
+	}
+
+	if options.CertFile == "" || options.KeyFile == "" {
+		return nil, errors.New("admin: a server certificate and key are required, unless the server is insecure")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("admin: loading the server certificate: %w", err)
+	}
+
+	server.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if options.ClientCAFile != "" {
+		if server.TLSConfig.ClientCAs, err = loadCertPool(options.ClientCAFile); err != nil {
+			return nil, err
+		}
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return server, nil
+}
+
+// NewClient creates a http.Client, which presents the client certificate, verifies the server certificates and sends
+// the token of the options, e.g. for WarmFromPeer and ReplicateHot against the peers served by NewServer.
+//
+// Parameters:
+//   - options: The options of the client.
+//
+// Returns:
+//   - client: The created client.
+//   - err: An error if the certificates can't be loaded, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	client, err := admin.NewClient(admin.ClientOptions{
+//	    CertFile: "/etc/cache/tls.crt",
+//	    KeyFile:  "/etc/cache/tls.key",
+//	    CAFile:   "/etc/cache/ca.crt",
+//	})
+//	count, err := admin.WarmFromPeer(ctx, cache, client, "https://10.0.0.12:6061", 50000)
+func NewClient(options ClientOptions) (client *http.Client, err error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if options.CertFile != "" || options.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("admin: loading the client certificate: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if options.CAFile != "" {
+		if transport.TLSClientConfig.RootCAs, err = loadCertPool(options.CAFile); err != nil {
+			return nil, err
+		}
+	}
+
+	client = &http.Client{Transport: transport}
+	if options.Token != "" {
+		client.Transport = &tokenTransport{token: options.Token, next: transport}
+	}
+
+	return client, nil
+}
+
+// Authorized wraps the specified handler, so that every request is authorized by the Authorizer before it is served.
+// A nil Authorizer returns the handler itself.
+//
+// Parameters:
+//   - handler: The handler to protect, e.g. created by NewHandler.
+//   - authorize: The Authorizer of the requests.
+//
+// Returns:
+//   - authorized: The protecting handler.
+//
+// Example Usage:
+//
+//	handler := admin.Authorized(admin.NewHandler(cache), admin.AllowTokens(os.Getenv("CACHE_ADMIN_TOKEN")))
+//	go http.ListenAndServe("127.0.0.1:6061", handler)
+func Authorized(handler http.Handler, authorize Authorizer) (authorized http.Handler) {
+	if authorize == nil {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := authorize(r); errors.Is(err, ErrUnauthorized) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// AllowCommonNames returns an Authorizer, which grants the requests of the clients, whose verified certificate has one
+// of the specified common names. It requires a server verifying the client certificates (ClientCAFile).
+func AllowCommonNames(commonNames ...string) (authorize Authorizer) {
+	return func(r *http.Request) (err error) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			return ErrUnauthorized
+		}
+
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		if !slices.Contains(commonNames, commonName) {
+			return fmt.Errorf("admin: client %q is not allowed", commonName)
+		}
+
+		return nil
+	}
+}
+
+// AllowTokens returns an Authorizer, which grants the requests carrying one of the specified bearer tokens in their
+// Authorization header. The tokens are compared in constant time, empty tokens are ignored.
+func AllowTokens(tokens ...string) (authorize Authorizer) {
+	return func(r *http.Request) (err error) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			return ErrUnauthorized
+		}
+
+		for _, allowed := range tokens {
+			if allowed != "" && subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+				return nil
+			}
+		}
+
+		return ErrUnauthorized
+	}
+}
+
+// loadCertPool loads the certificate authorities of the specified PEM file.
+func loadCertPool(path string) (pool *x509.CertPool, err error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("admin: loading the certificate authorities: %w", err)
+	}
+
+	pool = x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("admin: no certificate found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// tokenTransport sends a bearer token with every request.
+type tokenTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+// RoundTrip sets the Authorization header of a copy of the request and sends it by the next round tripper.
+func (transport *tokenTransport) RoundTrip(r *http.Request) (response *http.Response, err error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+transport.token)
+
+	return transport.next.RoundTrip(r)
+}
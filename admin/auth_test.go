@@ -0,0 +1,74 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rommarius/sq_cache"
+)
+
+func TestNewServerRejectsUnauthenticated(t *testing.T) {
+	cache, err := sq_cache.NewLRUCache[string, []byte](context.Background(), &sq_cache.Config[string, []byte]{
+		MaxShards:       4,
+		MaxItems:        100,
+		LoggingDisabled: true,
+	})
+	if err != nil {
+		t.Fatalf("NewLRUCache() error = %v", err)
+	}
+	t.Cleanup(cache.Close)
+
+	allow := func(r *http.Request) error {
+		return nil
+	}
+
+	tests := map[string]struct {
+		options ServerOptions
+		wantErr bool
+	}{
+		"insecure without authorizer": {options: ServerOptions{Insecure: true}, wantErr: true},
+		"insecure with authorizer":    {options: ServerOptions{Insecure: true, Authorize: allow}},
+		"insecure with client ca":     {options: ServerOptions{Insecure: true, ClientCAFile: "ca.crt"}, wantErr: true},
+		"tls without client ca":       {options: ServerOptions{CertFile: "tls.crt", KeyFile: "tls.key"}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewServer(cache, test.options); (err != nil) != test.wantErr {
+				t.Errorf("NewServer() error = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// Limits bounds the load a client can put on a server of the admin API, so that a misbehaving client can't exhaust
+// the host of the cache. The rejected connections and requests are counted, and reported as "rejectedConnections" and
+// "rejectedRequests" in the telemetry of the stats endpoint of a server created by NewServer. The zero values disable
+// the limits. A Limits must not be copied after its first use.
+type Limits struct {
+	// MaxConnections is the maximum number of open connections, further connections are closed when accepted.
+	MaxConnections int64
+	// MaxInFlight is the maximum number of requests served concurrently, further requests are rejected with the status
+	// 503 and a Retry-After header.
+	MaxInFlight int64
+	// MaxRequestsPerConnection is the maximum number of requests served on a connection, the connection is closed
+	// after its last request, so a single client can't pipeline an unbounded stream of requests on one connection.
+	MaxRequestsPerConnection int64
+
+	connections         atomic.Int64
+	inFlight            atomic.Int64
+	rejectedConnections atomic.Int64
+	rejectedRequests    atomic.Int64
+}
+
+// connRequestsKey is the context key of the request counter of a connection.
+type connRequestsKey struct{}
+
+// Apply enforces the limits on the specified server: it wraps its handler and hooks its connection states. Apply must
+// be called before the server is started, NewServer applies the Limits of its options.
+//
+// Parameters:
+//   - server: The server to limit, its Handler must be set.
+//
+// Example Usage:
+//
+//	limits := &admin.Limits{MaxConnections: 64, MaxInFlight: 16, MaxRequestsPerConnection: 1000}
+//	server := &http.Server{Addr: "127.0.0.1:6061", Handler: admin.NewHandler(cache)}
+//	limits.Apply(server)
+//	go server.ListenAndServe()
+func (limits *Limits) Apply(server *http.Server) {
+	server.Handler = limits.handler(server.Handler)
+
+	// HTTP/2 would serve many concurrent requests on one connection, HTTP/1.1 serves the requests of a connection one
+	// after another.
+	server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+
+	connContext := server.ConnContext
+	server.ConnContext = func(ctx context.Context, conn net.Conn) context.Context {
+		if connContext != nil {
+			ctx = connContext(ctx, conn)
+		}
+		return context.WithValue(ctx, connRequestsKey{}, new(atomic.Int64))
+	}
+
+	connState := server.ConnState
+	server.ConnState = func(conn net.Conn, state http.ConnState) {
+		limits.track(conn, state)
+		if connState != nil {
+			connState(conn, state)
+		}
+	}
+}
+
+// RejectedConnections returns the number of connections closed because of MaxConnections.
+func (limits *Limits) RejectedConnections() (count int64) {
+	return limits.rejectedConnections.Load()
+}
+
+// RejectedRequests returns the number of requests rejected because of MaxInFlight.
+func (limits *Limits) RejectedRequests() (count int64) {
+	return limits.rejectedRequests.Load()
+}
+
+// track counts the open connections and closes a new connection exceeding MaxConnections.
+func (limits *Limits) track(conn net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		if limits.connections.Add(1) > limits.MaxConnections && limits.MaxConnections > 0 {
+			limits.rejectedConnections.Add(1)
+			_ = conn.Close()
+		}
+	case http.StateClosed, http.StateHijacked:
+		limits.connections.Add(-1)
+	}
+}
+
+// handler wraps the specified handler, so that it rejects the requests exceeding MaxInFlight and closes the
+// connections after MaxRequestsPerConnection requests.
+func (limits *Limits) handler(next http.Handler) (handler http.Handler) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limits.MaxRequestsPerConnection > 0 {
+			if requests, ok := r.Context().Value(connRequestsKey{}).(*atomic.Int64); ok &&
+				requests.Add(1) >= limits.MaxRequestsPerConnection {
+				w.Header().Set("Connection", "close")
+			}
+		}
+
+		if limits.MaxInFlight > 0 {
+			defer limits.inFlight.Add(-1)
+			if limits.inFlight.Add(1) > limits.MaxInFlight {
+				limits.rejectedRequests.Add(1)
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "admin: too many requests in flight", http.StatusServiceUnavailable)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,188 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// auditBatchSize is the maximum number of events passed to the AuditSink at once.
+const auditBatchSize = 64
+
+// AuditEvent represents a mutating operation of the cache, recorded for the AuditSink.
+type AuditEvent[K IKey] struct {
+	Op AuditOp
+	// Key is the key of a Set or Remove, and the prefix of a purged namespace or empty for a Purge.
+	Key K
+	// Actor is who performed the operation, see WithActor, empty if unknown.
+	Actor  string
+	Origin AuditOrigin
+	Time   time.Time
+	// Operation is the metadata of the operation, see WithOperation.
+	Operation Operation
+}
+
+// AuditSink is an interface that receives the audit events of the Sets, Removes and Purges of a cache, e.g. to write
+// them to a structured log. The events are delivered asynchronously in the order they were recorded, by a single
+// goroutine, so Audit doesn't have to be safe for concurrent use. A slow sink doesn't slow down the cache: once the
+// queue of the events (AuditQueueSize) is full, further events are dropped and counted by the AuditDropped counter of
+// the telemetry.
+type AuditSink[K IKey] interface {
+	// Audit receives a batch of audit events, the batch must not be retained.
+	Audit(events []AuditEvent[K])
+}
+
+// auditActorKey and auditOriginKey are the context keys of the actor and the origin of the audited operations.
+type auditActorKey struct{}
+type auditOriginKey struct{}
+
+// WithActor returns a copy of the context, which attributes the operations performed with it to the specified actor,
+// e.g. the authenticated user or service, in the AuditEvents.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - actor: The actor of the operations.
+//
+// Returns:
+//   - actorCtx: The context of the actor.
+//
+// Example Usage:
+//
+//	ctx = sq_cache.WithActor(ctx, "billing-service")
+//	removed, err := cache.RemoveContext(ctx, "invoice:42")
+func WithActor(ctx context.Context, actor string) (actorCtx context.Context) {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// WithOrigin returns a copy of the context, which records the specified origin for the operations performed with it
+// in the AuditEvents. Without an origin, the operations are recorded as LocalOrigin.
+func WithOrigin(ctx context.Context, origin AuditOrigin) (originCtx context.Context) {
+	return context.WithValue(ctx, auditOriginKey{}, origin)
+}
+
+// auditLog represents the bounded queue of the audit events and the goroutine delivering them to the sink.
+type auditLog[K IKey] struct {
+	sink    AuditSink[K]
+	queue   chan AuditEvent[K]
+	dropped atomic.Int64
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// newAuditLog creates an audit log with the specified queue size and starts delivering its events to the sink.
+func newAuditLog[K IKey](sink AuditSink[K], size int64) (log *auditLog[K]) {
+	log = &auditLog[K]{
+		sink:  sink,
+		queue: make(chan AuditEvent[K], max(size, 1)),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	go log.run()
+
+	return log
+}
+
+// record queues an audit event of the operation, the actor and the origin are taken from the context. The event is
+// dropped, if the queue is full.
+func (log *auditLog[K]) record(ctx context.Context, op AuditOp, key K) {
+	event := AuditEvent[K]{Op: op, Key: key, Time: timeNow()}
+	event.Actor, _ = ctx.Value(auditActorKey{}).(string)
+	event.Origin, _ = ctx.Value(auditOriginKey{}).(AuditOrigin)
+	event.Operation, _ = OperationFrom(ctx)
+
+	select {
+	case log.queue <- event:
+	default:
+		log.dropped.Add(1)
+	}
+}
+
+// run delivers the queued events in batches to the sink, until the audit log is closed.
+func (log *auditLog[K]) run() {
+	defer close(log.done)
+
+	batch := make([]AuditEvent[K], 0, auditBatchSize)
+	for {
+		select {
+		case event := <-log.queue:
+			batch = append(batch[:0], event)
+		case <-log.stop:
+			log.drain(batch[:0])
+			return
+		}
+
+	fill:
+		for len(batch) < auditBatchSize {
+			select {
+			case event := <-log.queue:
+				batch = append(batch, event)
+			default:
+				break fill
+			}
+		}
+		log.sink.Audit(batch)
+	}
+}
+
+// drain delivers the events remaining in the queue to the sink.
+func (log *auditLog[K]) drain(batch []AuditEvent[K]) {
+	for {
+		select {
+		case event := <-log.queue:
+			if batch = append(batch, event); len(batch) == auditBatchSize {
+				log.sink.Audit(batch)
+				batch = batch[:0]
+			}
+		default:
+			if len(batch) > 0 {
+				log.sink.Audit(batch)
+			}
+			return
+		}
+	}
+}
+
+// close stops the audit log after the queued events were delivered.
+func (log *auditLog[K]) close() {
+	close(log.stop)
+	<-log.done
+}
+
+// audit records an audit event of the operation, if an AuditSink is configured.
+func (cache *LRUCache[K, V]) audit(ctx context.Context, op AuditOp, key K) {
+	if cache.auditLog != nil {
+		cache.auditLog.record(ctx, op, key)
+	}
+}
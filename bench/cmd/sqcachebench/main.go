@@ -0,0 +1,104 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Command sqcachebench runs the default workloads against sq_cache, a map with a mutex, a sync.Map and golang-lru and
+// prints their throughput, hit ratio and allocation stats.
+//
+// Usage:
+//
+//	sqcachebench -keys 1000000 -capacity 100000 -goroutines 8 -ops 1000000
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/rommarius/sq_cache"
+	"github.com/rommarius/sq_cache/bench"
+)
+
+func main() {
+	keys := flag.Int("keys", 1000000, "size of the key space")
+	capacity := flag.Int("capacity", 100000, "capacity of the LRU caches")
+	shards := flag.Int64("shards", 256, "number of shards of sq_cache")
+	goroutines := flag.Int("goroutines", runtime.GOMAXPROCS(0), "number of concurrent goroutines")
+	ops := flag.Int("ops", 1000000, "number of operations per goroutine")
+	seed := flag.Uint64("seed", 1, "seed of the key streams")
+	flag.Parse()
+
+	// the lifecycle of the caches is logged by default, which would interleave with the results
+	log.SetOutput(io.Discard)
+
+	if err := run(*keys, *capacity, *shards, bench.Options{Goroutines: *goroutines, Ops: *ops, Seed: *seed}); err != nil {
+		fmt.Fprintln(os.Stderr, "sqcachebench:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs every default workload against every target.
+func run(keys, capacity int, shards int64, options bench.Options) (err error) {
+	targets := []func() (bench.Target, error){
+		func() (bench.Target, error) {
+			return bench.NewSQCache(&sq_cache.Config[string, []byte]{MaxShards: shards, MaxItems: int64(capacity)})
+		},
+		func() (bench.Target, error) { return bench.NewGolangLRU(capacity) },
+		func() (bench.Target, error) { return bench.NewMapMutex(), nil },
+		func() (bench.Target, error) { return bench.NewSyncMap(), nil },
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "workload\ttarget\tops/s\thit ratio\tallocs/op\tB/op\t")
+
+	for _, workload := range bench.DefaultWorkloads(keys) {
+		for _, newTarget := range targets {
+			target, err := newTarget()
+			if err != nil {
+				return err
+			}
+
+			result := bench.Run(target, workload, options)
+			target.Close()
+
+			fmt.Fprintf(w, "%s\t%s\t%.0f\t%.2f%%\t%.2f\t%.1f\t\n", result.Workload, result.Target,
+				result.OpsPerSecond(), result.HitRatio()*100, result.AllocsPerOp(), result.BytesPerOp())
+		}
+		if err = w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+module github.com/rommarius/sq_cache/bench
+
+go 1.25
+
+require (
+	github.com/hashicorp/golang-lru/v2 v2.0.7
+	github.com/rommarius/sq_cache v0.0.0
+)
+
+require (
+	github.com/rommarius/generic_syncpool v1.0.0 // indirect
+	github.com/rommarius/sq_config_combine v1.0.0 // indirect
+)
+
+replace github.com/rommarius/sq_cache => ../
@@ -0,0 +1,190 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package bench
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Options is a structure that holds the settings of a run.
+type Options struct {
+	// Goroutines is the number of goroutines issuing operations concurrently.
+	Goroutines int
+	// Ops is the number of operations per goroutine.
+	Ops int
+	// Seed seeds the key streams of the goroutines, runs with the same seed issue the same operations.
+	Seed uint64
+}
+
+// Result holds the throughput, hit ratio and allocation stats of a run.
+type Result struct {
+	Target   string
+	Workload string
+
+	Ops      int64
+	Gets     int64
+	Hits     int64
+	Duration time.Duration
+
+	Allocs     uint64
+	AllocBytes uint64
+}
+
+// OpsPerSecond returns the throughput of the run.
+func (result Result) OpsPerSecond() (ops float64) {
+	if result.Duration <= 0 {
+		return 0
+	}
+
+	return float64(result.Ops) / result.Duration.Seconds()
+}
+
+// HitRatio returns the ratio of the gets that were hits, zero if the run issued no gets.
+func (result Result) HitRatio() (ratio float64) {
+	if result.Gets == 0 {
+		return 0
+	}
+
+	return float64(result.Hits) / float64(result.Gets)
+}
+
+// AllocsPerOp returns the number of heap allocations per operation.
+func (result Result) AllocsPerOp() (allocs float64) {
+	if result.Ops == 0 {
+		return 0
+	}
+
+	return float64(result.Allocs) / float64(result.Ops)
+}
+
+// BytesPerOp returns the number of allocated heap bytes per operation.
+func (result Result) BytesPerOp() (bytes float64) {
+	if result.Ops == 0 {
+		return 0
+	}
+
+	return float64(result.AllocBytes) / float64(result.Ops)
+}
+
+// Run runs the workload against the target and measures its throughput, hit ratio and heap allocations. The keys and
+// the value are created before the measurement starts, so only the allocations of the target are measured.
+//
+// Parameters:
+//   - target: The cache under benchmark.
+//   - workload: The operations to issue.
+//   - options: The concurrency, length and seed of the run.
+//
+// Returns:
+//   - result: The measured stats of the run.
+//
+// Example Usage:
+//
+//	target := bench.NewSyncMap()
+//	result := bench.Run(target, bench.DefaultWorkloads(100000)[0], bench.Options{Goroutines: 8, Ops: 1000000})
+//	fmt.Printf("%.0f ops/s\n", result.OpsPerSecond())
+func Run(target Target, workload Workload, options Options) (result Result) {
+	goroutines := max(options.Goroutines, 1)
+
+	keys := make([]string, workload.Keys)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	value := make([]byte, workload.ValueSize)
+	readThreshold := uint64(workload.ReadRatio * float64(1<<16))
+
+	streams := make([]func() int, goroutines)
+	for i := range streams {
+		streams[i] = workload.Distribution(workload.Keys, options.Seed+uint64(i))
+	}
+
+	var (
+		wg    sync.WaitGroup
+		gets  = make([]int64, goroutines)
+		hits  = make([]int64, goroutines)
+		start = make(chan struct{})
+	)
+
+	for i := range goroutines {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			next := streams[i]
+			mix := (options.Seed+uint64(i))*0x9e3779b97f4a7c15 | 1
+			<-start
+
+			for range options.Ops {
+				// xorshift, to decide between get and set without the allocations and locking of a shared rand
+				mix ^= mix << 13
+				mix ^= mix >> 7
+				mix ^= mix << 17
+
+				key := keys[next()]
+				if mix&0xffff < readThreshold {
+					gets[i]++
+					if target.Get(key) {
+						hits[i]++
+					}
+				} else {
+					target.Set(key, value)
+				}
+			}
+		}(i)
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	begin := time.Now()
+
+	close(start)
+	wg.Wait()
+
+	result.Duration = time.Since(begin)
+	runtime.ReadMemStats(&after)
+
+	result.Target = target.Name()
+	result.Workload = workload.Name
+	result.Ops = int64(goroutines * options.Ops)
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.AllocBytes = after.TotalAlloc - before.TotalAlloc
+	for i := range goroutines {
+		result.Gets += gets[i]
+		result.Hits += hits[i]
+	}
+
+	return result
+}
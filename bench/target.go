@@ -0,0 +1,180 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package bench
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rommarius/sq_cache"
+)
+
+// Target is an interface that defines the cache under benchmark.
+type Target interface {
+	Name() string
+	Get(key string) (found bool)
+	Set(key string, value []byte)
+	Close()
+}
+
+// sqCacheTarget is a Target backed by a sq_cache LRUCache.
+type sqCacheTarget struct {
+	cache  *sq_cache.LRUCache[string, []byte]
+	cancel context.CancelFunc
+}
+
+// NewSQCache creates a Target backed by a sq_cache LRUCache with the specified configuration. The callbacks are
+// replaced by no-ops, so the logging of the default callbacks doesn't distort the results.
+func NewSQCache(config *sq_cache.Config[string, []byte]) (target Target, err error) {
+	benchConfig := *config
+	benchConfig.OnAdd = noopNode
+	benchConfig.OnUpdate = noopNode
+	benchConfig.OnHit = noopNode
+	benchConfig.OnEvict = noopNode
+	benchConfig.OnMiss = noopMiss
+	benchConfig.OnRemove = noopRemove
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cache, err := sq_cache.NewLRUCache[string, []byte](ctx, &benchConfig)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &sqCacheTarget{cache: cache, cancel: cancel}, nil
+}
+
+// noopNode is a no-op callback of the cache items, its type argument is inferred from the callback type.
+func noopNode[N any](loggingOn bool, node N) {}
+
+// noopMiss is a no-op OnMiss callback.
+func noopMiss(loggingOn bool, key string) {}
+
+// noopRemove is a no-op OnRemove callback.
+func noopRemove(loggingOn bool, key string, value []byte, reason sq_cache.RemovalReason) {}
+
+func (target *sqCacheTarget) Name() string { return "sq_cache" }
+
+func (target *sqCacheTarget) Get(key string) (found bool) {
+	value, _ := target.cache.Get(key)
+	return value != nil
+}
+
+func (target *sqCacheTarget) Set(key string, value []byte) {
+	_, _ = target.cache.Set(key, value)
+}
+
+func (target *sqCacheTarget) Close() {
+	target.cache.Close()
+	target.cancel()
+}
+
+// mapMutexTarget is a Target backed by a map with a mutex, without capacity limit.
+type mapMutexTarget struct {
+	sync.RWMutex
+	items map[string][]byte
+}
+
+// NewMapMutex creates a Target backed by a map with a mutex. It has no capacity limit and serves as baseline.
+func NewMapMutex() (target Target) {
+	return &mapMutexTarget{items: make(map[string][]byte)}
+}
+
+func (target *mapMutexTarget) Name() string { return "map+mutex" }
+
+func (target *mapMutexTarget) Get(key string) (found bool) {
+	target.RLock()
+	_, found = target.items[key]
+	target.RUnlock()
+	return found
+}
+
+func (target *mapMutexTarget) Set(key string, value []byte) {
+	target.Lock()
+	target.items[key] = value
+	target.Unlock()
+}
+
+func (target *mapMutexTarget) Close() {}
+
+// syncMapTarget is a Target backed by a sync.Map, without capacity limit.
+type syncMapTarget struct {
+	items sync.Map
+}
+
+// NewSyncMap creates a Target backed by a sync.Map. It has no capacity limit and serves as baseline.
+func NewSyncMap() (target Target) {
+	return &syncMapTarget{}
+}
+
+func (target *syncMapTarget) Name() string { return "sync.Map" }
+
+func (target *syncMapTarget) Get(key string) (found bool) {
+	_, found = target.items.Load(key)
+	return found
+}
+
+func (target *syncMapTarget) Set(key string, value []byte) {
+	target.items.Store(key, value)
+}
+
+func (target *syncMapTarget) Close() {}
+
+// golangLRUTarget is a Target backed by a golang-lru cache.
+type golangLRUTarget struct {
+	cache *lru.Cache[string, []byte]
+}
+
+// NewGolangLRU creates a Target backed by a golang-lru cache with the specified capacity.
+func NewGolangLRU(size int) (target Target, err error) {
+	cache, err := lru.New[string, []byte](size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &golangLRUTarget{cache: cache}, nil
+}
+
+func (target *golangLRUTarget) Name() string { return "golang-lru" }
+
+func (target *golangLRUTarget) Get(key string) (found bool) {
+	_, found = target.cache.Get(key)
+	return found
+}
+
+func (target *golangLRUTarget) Set(key string, value []byte) {
+	target.cache.Add(key, value)
+}
+
+func (target *golangLRUTarget) Close() {}
@@ -0,0 +1,120 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package bench provides reproducible workload generators and a runner to compare the throughput and allocations of a
+// sq_cache LRUCache against a map with a mutex, a sync.Map and golang-lru.
+package bench
+
+import (
+	"math/rand/v2"
+	"strconv"
+)
+
+// Distribution creates the key stream of a goroutine, returning indices in [0, keys). The same seed always yields the
+// same stream, which makes the runs reproducible.
+type Distribution func(keys int, seed uint64) (next func() int)
+
+// Uniform returns a Distribution, which picks all keys with the same probability.
+func Uniform() (distribution Distribution) {
+	return func(keys int, seed uint64) (next func() int) {
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		return func() int {
+			return rng.IntN(keys)
+		}
+	}
+}
+
+// Zipfian returns a Distribution, which picks the keys by a zipfian distribution with the exponent s (s > 1), e.g. 1.01
+// for a heavily skewed workload with a few hot keys.
+func Zipfian(s float64) (distribution Distribution) {
+	return func(keys int, seed uint64) (next func() int) {
+		zipf := rand.NewZipf(rand.New(rand.NewPCG(seed, seed)), s, 1, uint64(keys-1))
+
+		return func() int {
+			return int(zipf.Uint64())
+		}
+	}
+}
+
+// Scan returns a Distribution, which picks the keys sequentially and wraps around, starting at a random offset per
+// goroutine. It is the worst case for a LRU cache smaller than the key space.
+func Scan() (distribution Distribution) {
+	return func(keys int, seed uint64) (next func() int) {
+		position := rand.New(rand.NewPCG(seed, seed)).IntN(keys)
+
+		return func() int {
+			position = (position + 1) % keys
+			return position
+		}
+	}
+}
+
+// Workload is a structure that describes the operations of a run.
+type Workload struct {
+	Name string
+
+	// Distribution picks the keys of the operations.
+	Distribution Distribution
+	// Keys is the size of the key space.
+	Keys int
+	// ReadRatio is the share of the operations that are gets, the remaining operations are sets.
+	ReadRatio float64
+	// ValueSize is the size of the values in bytes.
+	ValueSize int
+}
+
+// DefaultWorkloads returns the workloads used by sqcachebench: zipfian, uniform and scan key distributions with a read
+// heavy and a balanced read/write mix.
+func DefaultWorkloads(keys int) (workloads []Workload) {
+	distributions := []struct {
+		name         string
+		distribution Distribution
+	}{
+		{"zipfian", Zipfian(1.01)},
+		{"uniform", Uniform()},
+		{"scan", Scan()},
+	}
+
+	for _, d := range distributions {
+		for _, readRatio := range []float64{0.9, 0.5} {
+			workloads = append(workloads, Workload{
+				Name:         d.name + "-r" + strconv.Itoa(int(readRatio*100)),
+				Distribution: d.distribution,
+				Keys:         keys,
+				ReadRatio:    readRatio,
+				ValueSize:    128,
+			})
+		}
+	}
+
+	return workloads
+}
@@ -0,0 +1,112 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// bloomFilter represents a bloom filter of the keys of a shard, which answers whether a key may exist in the shard. A
+// key, which was never added, is definitely missing. As keys can't be removed from a bloom filter, removed keys still
+// pass the filter until it is rebuilt.
+type bloomFilter struct {
+	bits   []uint64
+	hashes uint64
+	seed   maphash.Seed
+
+	// capacity is the number of keys the filter is sized for, at the configured false positive rate.
+	capacity int64
+	// added and removed count the keys added and removed since the filter was built, to detect a stale filter.
+	added   int64
+	removed int64
+}
+
+// newBloomFilter creates a bloom filter, which holds the specified number of keys at the specified false positive rate.
+func newBloomFilter(capacity int64, falsePositiveRate float64) (filter *bloomFilter) {
+	capacity = max(capacity, 1)
+
+	bits := math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Round(bits / float64(capacity) * math.Ln2)
+
+	return &bloomFilter{
+		bits:     make([]uint64, (max(uint64(bits), 64)+63)/64),
+		hashes:   max(uint64(hashes), 1),
+		seed:     maphash.MakeSeed(),
+		capacity: capacity,
+	}
+}
+
+// positions calls fn with the bit positions of the specified key, derived by double hashing from a single hash.
+func (filter *bloomFilter) positions(key string, fn func(word uint64, mask uint64) bool) {
+	hash := maphash.String(filter.seed, key)
+	h1, h2 := hash&math.MaxUint32, hash>>32|1
+	size := uint64(len(filter.bits)) * 64
+
+	for i := range filter.hashes {
+		position := (h1 + i*h2) % size
+		if !fn(position/64, 1<<(position%64)) {
+			return
+		}
+	}
+}
+
+// add adds the specified key to the filter.
+func (filter *bloomFilter) add(key string) {
+	filter.positions(key, func(word uint64, mask uint64) bool {
+		filter.bits[word] |= mask
+		return true
+	})
+	filter.added++
+}
+
+// remove records the removal of a key, which stays in the filter until it is rebuilt.
+func (filter *bloomFilter) remove() {
+	filter.removed++
+}
+
+// mayContain reports whether the specified key may have been added to the filter.
+func (filter *bloomFilter) mayContain(key string) (found bool) {
+	found = true
+	filter.positions(key, func(word uint64, mask uint64) bool {
+		found = filter.bits[word]&mask != 0
+		return found
+	})
+
+	return found
+}
+
+// stale reports whether the filter should be rebuilt, because half of its capacity was taken by removed keys or it holds
+// more keys than it was sized for.
+func (filter *bloomFilter) stale() bool {
+	return filter.removed > filter.capacity/2 || filter.added > filter.capacity
+}
@@ -0,0 +1,176 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bulkLoaderBatch represents a batch of keys, which are loaded from the origin by a single call.
+type bulkLoaderBatch[K IKey, V IValue] struct {
+	keys []K
+	done chan struct{}
+
+	values map[K]V
+	err    error
+}
+
+// bulkLoader represents a thread-safe loader, which coalesces the keys of concurrent loads within a short window into
+// a single batched call of the bulk load function.
+type bulkLoader[K IKey, V IValue] struct {
+	sync.Mutex
+
+	load func(ctx context.Context, keys []K) (values map[K]V, err error)
+
+	window  time.Duration
+	maxKeys int64
+	timeout time.Duration
+	limiter loadLimiter
+
+	batch *bulkLoaderBatch[K, V]
+	// timer flushes the current batch at the end of its window.
+	timer *time.Timer
+
+	// ctx is the parent context of the loads, which is cancelled by close. flushes tracks the running flushes.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	flushes sync.WaitGroup
+	closed  bool
+}
+
+// newBulkLoader initializes and returns a new bulkLoader instance with user-configured settings.
+// The limiter limits the number of concurrent calls of the bulk load function, nil means no limit.
+func newBulkLoader[K IKey, V IValue](config *Config[K, V], limiter loadLimiter) (bl *bulkLoader[K, V]) {
+	bl = &bulkLoader[K, V]{
+		load: config.BulkLoader,
+
+		window:  config.BulkLoadWindow,
+		maxKeys: config.BulkLoadMaxKeys,
+		timeout: config.LoadTimeout,
+		limiter: limiter,
+	}
+	bl.ctx, bl.cancel = context.WithCancel(context.Background())
+
+	return bl
+}
+
+// Load adds the specified key to the current batch and waits for the result of the batch. The batch is flushed once
+// the window has passed or the maximum number of keys is reached.
+func (bl *bulkLoader[K, V]) Load(ctx context.Context, key K) (value V, err error) {
+	bl.Lock()
+	if bl.closed {
+		bl.Unlock()
+		return value, ErrClosed
+	}
+	batch := bl.batch
+	if batch == nil {
+		batch = &bulkLoaderBatch[K, V]{
+			done: make(chan struct{}),
+		}
+		bl.batch = batch
+		bl.timer = time.AfterFunc(bl.window, func() {
+			bl.flush(batch)
+		})
+	}
+	batch.keys = append(batch.keys, key)
+	full := int64(len(batch.keys)) >= bl.maxKeys
+	bl.Unlock()
+
+	if full {
+		go bl.flush(batch)
+	}
+
+	select {
+	case <-ctx.Done():
+		return value, ctx.Err()
+	case <-batch.done:
+	}
+
+	if batch.err != nil {
+		return value, batch.err
+	}
+
+	value, found := batch.values[key]
+	if !found {
+		return value, ErrLoaderKeyNotFound
+	}
+
+	return value, nil
+}
+
+// flush detaches the specified batch, so that no further keys are added, and loads its keys from the origin.
+// A batch is only flushed once, no matter how often flush is called.
+func (bl *bulkLoader[K, V]) flush(batch *bulkLoaderBatch[K, V]) {
+	bl.Lock()
+	if bl.batch != batch || bl.closed {
+		bl.Unlock()
+		return
+	}
+	bl.batch = nil
+	bl.flushes.Add(1)
+	bl.Unlock()
+	defer bl.flushes.Done()
+
+	ctx, cancel := bl.ctx, context.CancelFunc(func() {})
+	if bl.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, bl.timeout)
+	}
+	defer cancel()
+
+	if batch.err = bl.limiter.acquire(ctx); batch.err == nil {
+		batch.values, batch.err = bl.load(ctx, batch.keys)
+		bl.limiter.release()
+	}
+	close(batch.done)
+}
+
+// close fails the current batch, cancels the running loads and waits for their flushes to finish. Further loads fail.
+func (bl *bulkLoader[K, V]) close() {
+	bl.Lock()
+	batch := bl.batch
+	bl.batch = nil
+	bl.closed = true
+	if bl.timer != nil {
+		bl.timer.Stop()
+	}
+	bl.Unlock()
+
+	if batch != nil {
+		batch.err = ErrClosed
+		close(batch.done)
+	}
+
+	bl.cancel()
+	bl.flushes.Wait()
+}
@@ -0,0 +1,69 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is the public key-value API of a cache, implemented by LRUCache. Consumers can depend on the interface instead
+// of the sharded cache, so their unit tests can use the deterministic fake or the mock of the cachetest package, or
+// a mock generated by mockgen (go.uber.org/mock) or minimock.
+type Cache[K IKey, V IValue] interface {
+	Set(key K, value V) (returnKey K, err error)
+	SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error)
+
+	Get(key K) (value V, err error)
+	GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error)
+	GetOrLoad(ctx context.Context, key K) (value V, err error)
+	Peek(key K) (value V, err error)
+	Contains(key K) (found bool, err error)
+
+	ContainsOrAdd(key K, value V) (existed, evicted bool, err error)
+	PeekOrAdd(key K, value V) (previous V, existed bool, err error)
+
+	Remove(key K) (removed bool, err error)
+	RemoveOldest(n int) (removed int, err error)
+	Purge() (err error)
+
+	Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error)
+	Len() (len int64)
+
+	Status() (status CacheStatus)
+	Start()
+	Stop()
+	Close()
+}
+
+// LRUCache implements the Cache interface.
+var _ Cache[string, []byte] = (*LRUCache[string, []byte])(nil)
@@ -0,0 +1,393 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package cachetest provides reusable concurrency and contract tests for caches, e.g. for custom eviction policies or
+// storage backends built on sq_cache, which are run by the tests of their implementations, preferably with -race.
+package cachetest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// Cache is the contract verified by Run. A Set, which returned, must be visible to the following Gets of any goroutine
+// (e.g. caches with write buffers must read their own writes), and an expired cache item must never be returned.
+type Cache interface {
+	// Set adds a key-value pair, a ttl of zero means no expiry.
+	Set(key string, value []byte, ttl time.Duration) (err error)
+	// Get retrieves the value of a key.
+	Get(key string) (value []byte, found bool, err error)
+	// Remove removes a key.
+	Remove(key string) (err error)
+	// Len returns the number of cache items.
+	Len() (len int64)
+}
+
+// Factory creates an empty Cache, which holds at most the specified number of cache items. The Cache should be closed
+// by a cleanup function of the test.
+type Factory func(t testing.TB, capacity int64) (cache Cache)
+
+// Options tunes the load generated by Run.
+type Options struct {
+	// Goroutines is the number of concurrent goroutines per test, 8 if zero.
+	Goroutines int
+	// Operations is the number of operations per goroutine, 1000 if zero.
+	Operations int
+	// TTL is the TTL (time to live) of the expiry test, 20 milliseconds if zero.
+	TTL time.Duration
+}
+
+// withDefaults returns the options with the defaults applied.
+func (options Options) withDefaults() Options {
+	if options.Goroutines <= 0 {
+		options.Goroutines = 8
+	}
+	if options.Operations <= 0 {
+		options.Operations = 1000
+	}
+	if options.TTL <= 0 {
+		options.TTL = time.Millisecond * 20
+	}
+
+	return options
+}
+
+// Run runs all tests of the contract as subtests against the caches created by the specified factory.
+//
+// Parameters:
+//   - t: The test.
+//   - newCache: The factory of the caches under test.
+//   - options: The load of the tests.
+//
+// Example Usage:
+//
+//	func TestMyPolicy(t *testing.T) {
+//	    cachetest.Run(t, cachetest.LRUCacheFactory(func(capacity int64) *sq_cache.Config[string, []byte] {
+//	        return &sq_cache.Config[string, []byte]{MaxItems: capacity, EvictionPolicy: sq_cache.ClockEviction}
+//	    }), cachetest.Options{})
+//	}
+func Run(t *testing.T, newCache Factory, options Options) {
+	options = options.withDefaults()
+
+	t.Run("PerKeyLinearizable", func(t *testing.T) { TestPerKeyLinearizable(t, newCache, options) })
+	t.Run("NoLostUpdates", func(t *testing.T) { TestNoLostUpdates(t, newCache, options) })
+	t.Run("EvictionBound", func(t *testing.T) { TestEvictionBound(t, newCache, options) })
+	t.Run("TTL", func(t *testing.T) { TestTTL(t, newCache, options) })
+}
+
+// TestPerKeyLinearizable verifies that the Gets of a key never observe an older value than a value they observed
+// before or than a value, whose Set returned before, and that a removed key is missed: every key has a single writer,
+// which sets increasing versions and finally removes the key, while another goroutine reads the key concurrently.
+func TestPerKeyLinearizable(t *testing.T, newCache Factory, options Options) {
+	options = options.withDefaults()
+	cache := newCache(t, int64(options.Goroutines)*2)
+
+	var wg sync.WaitGroup
+	for writer := range options.Goroutines {
+		key := "key-" + strconv.Itoa(writer)
+
+		// writtenVersion is the last version, whose Set returned, stopped reports whether the writer is done.
+		var written sync.Mutex
+		var writtenVersion int
+		var stopped bool
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				written.Lock()
+				stopped = true
+				written.Unlock()
+			}()
+
+			for version := 1; version <= options.Operations; version++ {
+				if err := cache.Set(key, []byte(strconv.Itoa(version)), 0); err != nil {
+					t.Errorf("Set(%q): %v", key, err)
+					return
+				}
+				written.Lock()
+				writtenVersion = version
+				written.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+
+			observed := 0
+			for {
+				written.Lock()
+				floor, done := max(writtenVersion, observed), stopped
+				written.Unlock()
+				if done && observed >= writtenVersion {
+					break
+				}
+
+				value, found, err := cache.Get(key)
+				if err != nil {
+					t.Errorf("Get(%q): %v", key, err)
+					return
+				}
+				if !found {
+					if floor > 0 {
+						t.Errorf("Get(%q) missed after version %d", key, floor)
+						return
+					}
+					continue
+				}
+
+				version, err := strconv.Atoi(string(value))
+				if err != nil {
+					t.Errorf("Get(%q) returned the foreign value %q", key, value)
+					return
+				}
+				if version < floor {
+					t.Errorf("Get(%q) returned version %d after version %d", key, version, floor)
+					return
+				}
+				observed = version
+			}
+
+			if err := cache.Remove(key); err != nil {
+				t.Errorf("Remove(%q): %v", key, err)
+				return
+			}
+			if value, found, err := cache.Get(key); err != nil || found {
+				t.Errorf("Get(%q) = %q, %t, %v after Remove", key, value, found, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestNoLostUpdates verifies that concurrent Sets don't lose each other: every goroutine sets its own keys and a
+// shared key, afterwards all keys hold the last value of their goroutine and the shared key holds the last value of
+// one of the goroutines.
+func TestNoLostUpdates(t *testing.T, newCache Factory, options Options) {
+	options = options.withDefaults()
+	keysPerGoroutine := 16
+	cache := newCache(t, int64(options.Goroutines*keysPerGoroutine+1))
+
+	var wg sync.WaitGroup
+	for writer := range options.Goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range options.Operations {
+				key := fmt.Sprintf("key-%d-%d", writer, i%keysPerGoroutine)
+				if err := cache.Set(key, []byte(strconv.Itoa(i)), 0); err != nil {
+					t.Errorf("Set(%q): %v", key, err)
+					return
+				}
+				if err := cache.Set("shared", []byte(fmt.Sprintf("%d-%d", writer, i)), 0); err != nil {
+					t.Errorf("Set(shared): %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	lastValues := make(map[string]bool, options.Goroutines)
+	for writer := range options.Goroutines {
+		lastValues[fmt.Sprintf("%d-%d", writer, options.Operations-1)] = true
+
+		for k := range keysPerGoroutine {
+			key := fmt.Sprintf("key-%d-%d", writer, k)
+			last := options.Operations - 1 - (options.Operations-1-k)%keysPerGoroutine
+			if last < 0 {
+				continue
+			}
+
+			value, found, err := cache.Get(key)
+			switch {
+			case err != nil:
+				t.Errorf("Get(%q): %v", key, err)
+			case !found:
+				t.Errorf("Get(%q) lost the key", key)
+			case string(value) != strconv.Itoa(last):
+				t.Errorf("Get(%q) = %q, want the last value %d", key, value, last)
+			}
+		}
+	}
+
+	value, found, err := cache.Get("shared")
+	switch {
+	case err != nil:
+		t.Errorf("Get(shared): %v", err)
+	case !found || !lastValues[string(value)]:
+		t.Errorf("Get(shared) = %q, %t, want the last value of a goroutine", value, found)
+	}
+}
+
+// TestEvictionBound verifies that the cache doesn't exceed its capacity: while goroutines set more keys than fit,
+// the length stays below the capacity plus one in-flight Set per goroutine, and once they are done, within the
+// capacity.
+func TestEvictionBound(t *testing.T, newCache Factory, options Options) {
+	options = options.withDefaults()
+	capacity := int64(64)
+	cache := newCache(t, capacity)
+
+	var wg sync.WaitGroup
+	for writer := range options.Goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range options.Operations {
+				key := fmt.Sprintf("key-%d-%d", writer, i)
+				if err := cache.Set(key, []byte(key), 0); err != nil {
+					t.Errorf("Set(%q): %v", key, err)
+					return
+				}
+				if len := cache.Len(); len > capacity+int64(options.Goroutines) {
+					t.Errorf("Len() = %d exceeds the capacity %d", len, capacity)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len := cache.Len(); len > capacity {
+		t.Errorf("Len() = %d exceeds the capacity %d after the Sets", len, capacity)
+	}
+}
+
+// TestTTL verifies that expired cache items are never returned under concurrent Gets, while cache items are found
+// before their expiry.
+func TestTTL(t *testing.T, newCache Factory, options Options) {
+	options = options.withDefaults()
+	cache := newCache(t, int64(options.Goroutines)*2)
+
+	var wg sync.WaitGroup
+	for writer := range options.Goroutines {
+		key := "key-" + strconv.Itoa(writer)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			setAt := time.Now()
+			if err := cache.Set(key, []byte(key), options.TTL); err != nil {
+				t.Errorf("Set(%q): %v", key, err)
+				return
+			}
+			// The cache item expires between its Set and its Set plus the TTL.
+			expiresBefore := time.Now().Add(options.TTL)
+			expiresAfter := setAt.Add(options.TTL)
+
+			for deadline := expiresBefore.Add(options.TTL); time.Now().Before(deadline); {
+				getAt := time.Now()
+				_, found, err := cache.Get(key)
+				gotAt := time.Now()
+				switch {
+				case err != nil:
+					t.Errorf("Get(%q): %v", key, err)
+					return
+				case found && getAt.After(expiresBefore):
+					t.Errorf("Get(%q) returned the cache item %s after its expiry", key, getAt.Sub(expiresBefore))
+					return
+				case !found && gotAt.Before(expiresAfter):
+					t.Errorf("Get(%q) missed the cache item %s before its expiry", key, expiresAfter.Sub(gotAt))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// lruCache adapts a LRUCache to the Cache interface.
+type lruCache struct {
+	cache *sq_cache.LRUCache[string, []byte]
+}
+
+// LRUCacheFactory creates a Factory of LRUCaches, e.g. to run the tests against a custom configuration.
+//
+// Parameters:
+//   - config: The function returning the configuration of a cache with the specified capacity.
+//
+// Returns:
+//   - newCache: The factory of the caches.
+//
+// Example Usage:
+//
+//	newCache := cachetest.LRUCacheFactory(func(capacity int64) *sq_cache.Config[string, []byte] {
+//	    return &sq_cache.Config[string, []byte]{MaxItems: capacity, ShardIndex: sq_cache.OpenAddressingIndex}
+//	})
+func LRUCacheFactory(config func(capacity int64) *sq_cache.Config[string, []byte]) (newCache Factory) {
+	return func(t testing.TB, capacity int64) Cache {
+		cache, err := sq_cache.NewLRUCache[string, []byte](context.Background(), config(capacity))
+		if err != nil {
+			t.Fatalf("NewLRUCache: %v", err)
+		}
+		t.Cleanup(cache.Close)
+
+		return &lruCache{cache: cache}
+	}
+}
+
+// Set adds a key-value pair by Set or SetWithTTL.
+func (adapter *lruCache) Set(key string, value []byte, ttl time.Duration) (err error) {
+	if ttl == 0 {
+		_, err = adapter.cache.Set(key, value)
+	} else {
+		_, err = adapter.cache.SetWithTTL(key, value, ttl)
+	}
+
+	return err
+}
+
+// Get retrieves the value of a key by Get.
+func (adapter *lruCache) Get(key string) (value []byte, found bool, err error) {
+	value, err = adapter.cache.Get(key)
+
+	return value, value != nil, err
+}
+
+// Remove removes a key by Remove.
+func (adapter *lruCache) Remove(key string) (err error) {
+	_, err = adapter.cache.Remove(key)
+
+	return err
+}
+
+// Len returns the number of cache items by Len.
+func (adapter *lruCache) Len() (len int64) {
+	return adapter.cache.Len()
+}
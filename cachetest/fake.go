@@ -0,0 +1,442 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package cachetest
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// FakeConfig holds the settings of a Fake.
+type FakeConfig[K sq_cache.IKey, V sq_cache.IValue] struct {
+	// MaxItems is the capacity of the fake, the least recently used cache item is evicted beyond it. Zero means no
+	// limit.
+	MaxItems int64
+	// DefaultTTL is used by the Sets without a duration and by GetOrLoad. Zero or negative means no expiry.
+	DefaultTTL time.Duration
+	// Loader loads the missing values of GetOrLoad, nil fails GetOrLoad with ErrLoaderNotConfigured.
+	Loader func(ctx context.Context, key K) (value V, err error)
+}
+
+// fakeItem is a cache item of a Fake.
+type fakeItem[K sq_cache.IKey, V sq_cache.IValue] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// Fake is a deterministic in-memory implementation of the sq_cache.Cache interface for the unit tests of consumers. It
+// has no goroutines and doesn't depend on the time: its clock only moves by Advance, and the expired cache items are
+// removed on the next access of the fake, as if the cleanup ran immediately. A new Fake is started, like a new
+// LRUCache.
+type Fake[K sq_cache.IKey, V sq_cache.IValue] struct {
+	sync.Mutex
+
+	config FakeConfig[K, V]
+	status sq_cache.CacheStatus
+	now    time.Time
+
+	// list holds the cache items from the most to the least recently used.
+	list  *list.List
+	items map[K]*list.Element
+}
+
+// Fake implements the sq_cache.Cache interface.
+var _ sq_cache.Cache[string, []byte] = (*Fake[string, []byte])(nil)
+
+// NewFake creates a started Fake with the specified configuration.
+//
+// Parameters:
+//   - config: The configuration of the fake.
+//
+// Returns:
+//   - fake: The new fake.
+//
+// Example Usage:
+//
+//	fake := cachetest.NewFake(cachetest.FakeConfig[string, []byte]{MaxItems: 2})
+//	service := NewService(fake)
+func NewFake[K sq_cache.IKey, V sq_cache.IValue](config FakeConfig[K, V]) (fake *Fake[K, V]) {
+	return &Fake[K, V]{
+		config: config,
+		status: sq_cache.Started,
+		now:    time.Unix(0, 0),
+		list:   list.New(),
+		items:  make(map[K]*list.Element),
+	}
+}
+
+// Advance moves the clock of the fake forward by the specified duration, expiring the cache items whose TTL (time to
+// live) elapsed.
+//
+// Parameters:
+//   - d: The duration to move the clock by.
+func (fake *Fake[K, V]) Advance(d time.Duration) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	fake.now = fake.now.Add(d)
+}
+
+// check returns the error of the specified method, if the fake is stopped or closed, and removes the expired cache
+// items otherwise. Must be called with the lock held.
+func (fake *Fake[K, V]) check(method string) (err error) {
+	switch fake.status {
+	case sq_cache.Closed:
+		return sq_cache.ErrClosed
+	case sq_cache.Stopped:
+		return fmt.Errorf("cache is stopped, must be started before calling method %s()", method)
+	}
+
+	fake.expire()
+
+	return nil
+}
+
+// expire removes the expired cache items. Must be called with the lock held.
+func (fake *Fake[K, V]) expire() {
+	for element := fake.list.Front(); element != nil; {
+		next := element.Next()
+		if item := element.Value.(*fakeItem[K, V]); !item.expiresAt.IsZero() && !fake.now.Before(item.expiresAt) {
+			fake.list.Remove(element)
+			delete(fake.items, item.key)
+		}
+		element = next
+	}
+}
+
+// expiresAt returns the expiry time of the specified duration, the zero time if the cache item never expires.
+func (fake *Fake[K, V]) expiresAt(duration time.Duration) (expiresAt time.Time) {
+	if duration == 0 {
+		duration = fake.config.DefaultTTL
+	}
+	if duration <= 0 {
+		return expiresAt
+	}
+
+	return fake.now.Add(duration)
+}
+
+// set adds or updates a cache item and reports whether a cache item was evicted. Must be called with the lock held.
+func (fake *Fake[K, V]) set(key K, value V, expiresAt time.Time) (returnKey K, evicted bool) {
+	if key == "" {
+		sum := sha1.Sum([]byte(value))
+		key = K(hex.EncodeToString(sum[:]))
+	}
+
+	if element, found := fake.items[key]; found {
+		item := element.Value.(*fakeItem[K, V])
+		item.value, item.expiresAt = value, expiresAt
+		fake.list.MoveToFront(element)
+		return key, false
+	}
+
+	if fake.config.MaxItems > 0 && int64(fake.list.Len()) >= fake.config.MaxItems {
+		oldest := fake.list.Back()
+		fake.list.Remove(oldest)
+		delete(fake.items, oldest.Value.(*fakeItem[K, V]).key)
+		evicted = true
+	}
+	fake.items[key] = fake.list.PushFront(&fakeItem[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	return key, evicted
+}
+
+// Set adds a key-value pair with the DefaultTTL, see LRUCache.Set.
+func (fake *Fake[K, V]) Set(key K, value V) (returnKey K, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("Set"); err != nil {
+		return returnKey, err
+	}
+	returnKey, _ = fake.set(key, value, fake.expiresAt(0))
+
+	return returnKey, nil
+}
+
+// SetWithTTL adds a key-value pair with a TTL (time to live), see LRUCache.SetWithTTL.
+func (fake *Fake[K, V]) SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("SetWithTTL"); err != nil {
+		return returnKey, err
+	}
+	if duration > 0 && duration < sq_cache.MinTTL {
+		return returnKey, sq_cache.ErrTTLTooShort
+	}
+	returnKey, _ = fake.set(key, value, fake.expiresAt(duration))
+
+	return returnKey, nil
+}
+
+// Get retrieves a value and updates the recent-ness of the cache item, see LRUCache.Get.
+func (fake *Fake[K, V]) Get(key K) (value V, err error) {
+	value, _, _, err = fake.get("Get", key, true)
+
+	return value, err
+}
+
+// get retrieves a cache item and its remaining TTL (time to live), updating its recent-ness if requested.
+func (fake *Fake[K, V]) get(method string, key K, touch bool) (value V, ttl time.Duration, found bool, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check(method); err != nil {
+		return value, 0, false, err
+	}
+
+	element, found := fake.items[key]
+	if !found {
+		return value, 0, false, nil
+	}
+	if touch {
+		fake.list.MoveToFront(element)
+	}
+
+	item := element.Value.(*fakeItem[K, V])
+	if item.expiresAt.IsZero() {
+		return item.value, sq_cache.NoExpiry, true, nil
+	}
+
+	return item.value, item.expiresAt.Sub(fake.now), true, nil
+}
+
+// GetWithTTL retrieves a value and its remaining TTL (time to live), see LRUCache.GetWithTTL.
+func (fake *Fake[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error) {
+	return fake.get("GetWithTTL", key, true)
+}
+
+// GetOrLoad retrieves a value, or loads it by the configured loader, see LRUCache.GetOrLoad. Unlike LRUCache, the
+// loads are neither coalesced nor retried.
+func (fake *Fake[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	value, _, found, err := fake.get("GetOrLoad", key, true)
+	if err != nil || found {
+		return value, err
+	}
+
+	if fake.config.Loader == nil {
+		return value, sq_cache.ErrLoaderNotConfigured
+	}
+	if value, err = fake.config.Loader(ctx, key); err != nil {
+		return value, err
+	}
+
+	fake.Lock()
+	defer fake.Unlock()
+
+	fake.set(key, value, fake.expiresAt(0))
+
+	return value, nil
+}
+
+// Peek retrieves a value without updating the recent-ness of the cache item, see LRUCache.Peek.
+func (fake *Fake[K, V]) Peek(key K) (value V, err error) {
+	value, _, _, err = fake.get("Peek", key, false)
+
+	return value, err
+}
+
+// Contains checks if a key exists, see LRUCache.Contains.
+func (fake *Fake[K, V]) Contains(key K) (found bool, err error) {
+	_, _, found, err = fake.get("Contains", key, false)
+
+	return found, err
+}
+
+// ContainsOrAdd adds a key-value pair, if the key doesn't exist, see LRUCache.ContainsOrAdd.
+func (fake *Fake[K, V]) ContainsOrAdd(key K, value V) (existed, evicted bool, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("ContainsOrAdd"); err != nil {
+		return false, false, err
+	}
+	if _, existed = fake.items[key]; existed {
+		return true, false, nil
+	}
+	_, evicted = fake.set(key, value, fake.expiresAt(0))
+
+	return false, evicted, nil
+}
+
+// PeekOrAdd retrieves a value, or adds the key-value pair if the key doesn't exist, see LRUCache.PeekOrAdd.
+func (fake *Fake[K, V]) PeekOrAdd(key K, value V) (previous V, existed bool, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("PeekOrAdd"); err != nil {
+		return previous, false, err
+	}
+	if element, found := fake.items[key]; found {
+		return element.Value.(*fakeItem[K, V]).value, true, nil
+	}
+	fake.set(key, value, fake.expiresAt(0))
+
+	return previous, false, nil
+}
+
+// Remove removes a key, see LRUCache.Remove.
+func (fake *Fake[K, V]) Remove(key K) (removed bool, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("Remove"); err != nil {
+		return false, err
+	}
+	element, removed := fake.items[key]
+	if removed {
+		fake.list.Remove(element)
+		delete(fake.items, key)
+	}
+
+	return removed, nil
+}
+
+// RemoveOldest removes up to n least recently used cache items, see LRUCache.RemoveOldest.
+func (fake *Fake[K, V]) RemoveOldest(n int) (removed int, err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if err = fake.check("RemoveOldest"); err != nil {
+		return 0, err
+	}
+	for ; removed < n && fake.list.Len() > 0; removed++ {
+		oldest := fake.list.Back()
+		fake.list.Remove(oldest)
+		delete(fake.items, oldest.Value.(*fakeItem[K, V]).key)
+	}
+
+	return removed, nil
+}
+
+// Purge removes all cache items, the fake must be stopped, see LRUCache.Purge.
+func (fake *Fake[K, V]) Purge() (err error) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	switch fake.status {
+	case sq_cache.Closed:
+		return sq_cache.ErrClosed
+	case sq_cache.Started:
+		return errors.New("cache is started, must be stopped before calling method Purge()")
+	}
+
+	fake.list.Init()
+	clear(fake.items)
+
+	return nil
+}
+
+// Range calls the specified function for the cache items from the most to the least recently used, until it returns
+// false, see LRUCache.Range. The cache items are copied before, so the function can call the fake.
+func (fake *Fake[K, V]) Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error) {
+	fake.Lock()
+	if err = fake.check("Range"); err != nil {
+		fake.Unlock()
+		return 0, err
+	}
+	items := make([]fakeItem[K, V], 0, fake.list.Len())
+	for element := fake.list.Front(); element != nil; element = element.Next() {
+		items = append(items, *element.Value.(*fakeItem[K, V]))
+	}
+	fake.Unlock()
+
+	for _, item := range items {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+		count++
+		if !fn(item.key, item.value) {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+// Len returns the number of unexpired cache items.
+func (fake *Fake[K, V]) Len() (len int64) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	fake.expire()
+
+	return int64(fake.list.Len())
+}
+
+// Status returns the status of the fake.
+func (fake *Fake[K, V]) Status() (status sq_cache.CacheStatus) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	return fake.status
+}
+
+// Start starts the fake, unless it is closed.
+func (fake *Fake[K, V]) Start() {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if fake.status != sq_cache.Closed {
+		fake.status = sq_cache.Started
+	}
+}
+
+// Stop stops the fake, unless it is closed.
+func (fake *Fake[K, V]) Stop() {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if fake.status != sq_cache.Closed {
+		fake.status = sq_cache.Stopped
+	}
+}
+
+// Close closes the fake and removes all cache items.
+func (fake *Fake[K, V]) Close() {
+	fake.Lock()
+	defer fake.Unlock()
+
+	fake.list.Init()
+	clear(fake.items)
+	fake.status = sq_cache.Closed
+}
@@ -0,0 +1,295 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package cachetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// MockCall is a call of a Mock.
+type MockCall struct {
+	Method string
+	Args   []any
+}
+
+// Mock is a mock of the sq_cache.Cache interface, whose methods call the function of the same name with the suffix
+// "Func", if it is set, and return the zero values otherwise. The calls are recorded, see Calls.
+//
+// Example Usage:
+//
+//	mock := &cachetest.Mock[string, []byte]{
+//	    GetFunc: func(key string) ([]byte, error) {
+//	        return nil, sq_cache.ErrClosed
+//	    },
+//	}
+//	service := NewService(mock)
+type Mock[K sq_cache.IKey, V sq_cache.IValue] struct {
+	SetFunc           func(key K, value V) (K, error)
+	SetWithTTLFunc    func(key K, value V, duration time.Duration) (K, error)
+	GetFunc           func(key K) (V, error)
+	GetWithTTLFunc    func(key K) (V, time.Duration, bool, error)
+	GetOrLoadFunc     func(ctx context.Context, key K) (V, error)
+	PeekFunc          func(key K) (V, error)
+	ContainsFunc      func(key K) (bool, error)
+	ContainsOrAddFunc func(key K, value V) (bool, bool, error)
+	PeekOrAddFunc     func(key K, value V) (V, bool, error)
+	RemoveFunc        func(key K) (bool, error)
+	RemoveOldestFunc  func(n int) (int, error)
+	PurgeFunc         func() error
+	RangeFunc         func(ctx context.Context, fn func(key K, value V) bool) (int64, error)
+	LenFunc           func() int64
+	StatusFunc        func() sq_cache.CacheStatus
+	StartFunc         func()
+	StopFunc          func()
+	CloseFunc         func()
+
+	mutex sync.Mutex
+	calls []MockCall
+}
+
+// Mock implements the sq_cache.Cache interface.
+var _ sq_cache.Cache[string, []byte] = (*Mock[string, []byte])(nil)
+
+// record records a call of the specified method.
+func (mock *Mock[K, V]) record(method string, args ...any) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	mock.calls = append(mock.calls, MockCall{Method: method, Args: args})
+}
+
+// Calls returns the recorded calls in their order.
+//
+// Returns:
+//   - calls: The recorded calls.
+func (mock *Mock[K, V]) Calls() (calls []MockCall) {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	return append([]MockCall(nil), mock.calls...)
+}
+
+// Set records the call and calls SetFunc.
+func (mock *Mock[K, V]) Set(key K, value V) (returnKey K, err error) {
+	mock.record("Set", key, value)
+
+	if mock.SetFunc != nil {
+		returnKey, err = mock.SetFunc(key, value)
+	}
+
+	return returnKey, err
+}
+
+// SetWithTTL records the call and calls SetWithTTLFunc.
+func (mock *Mock[K, V]) SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error) {
+	mock.record("SetWithTTL", key, value, duration)
+
+	if mock.SetWithTTLFunc != nil {
+		returnKey, err = mock.SetWithTTLFunc(key, value, duration)
+	}
+
+	return returnKey, err
+}
+
+// Get records the call and calls GetFunc.
+func (mock *Mock[K, V]) Get(key K) (value V, err error) {
+	mock.record("Get", key)
+
+	if mock.GetFunc != nil {
+		value, err = mock.GetFunc(key)
+	}
+
+	return value, err
+}
+
+// GetWithTTL records the call and calls GetWithTTLFunc.
+func (mock *Mock[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error) {
+	mock.record("GetWithTTL", key)
+
+	if mock.GetWithTTLFunc != nil {
+		value, ttl, found, err = mock.GetWithTTLFunc(key)
+	}
+
+	return value, ttl, found, err
+}
+
+// GetOrLoad records the call and calls GetOrLoadFunc.
+func (mock *Mock[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	mock.record("GetOrLoad", ctx, key)
+
+	if mock.GetOrLoadFunc != nil {
+		value, err = mock.GetOrLoadFunc(ctx, key)
+	}
+
+	return value, err
+}
+
+// Peek records the call and calls PeekFunc.
+func (mock *Mock[K, V]) Peek(key K) (value V, err error) {
+	mock.record("Peek", key)
+
+	if mock.PeekFunc != nil {
+		value, err = mock.PeekFunc(key)
+	}
+
+	return value, err
+}
+
+// Contains records the call and calls ContainsFunc.
+func (mock *Mock[K, V]) Contains(key K) (found bool, err error) {
+	mock.record("Contains", key)
+
+	if mock.ContainsFunc != nil {
+		found, err = mock.ContainsFunc(key)
+	}
+
+	return found, err
+}
+
+// ContainsOrAdd records the call and calls ContainsOrAddFunc.
+func (mock *Mock[K, V]) ContainsOrAdd(key K, value V) (existed, evicted bool, err error) {
+	mock.record("ContainsOrAdd", key, value)
+
+	if mock.ContainsOrAddFunc != nil {
+		existed, evicted, err = mock.ContainsOrAddFunc(key, value)
+	}
+
+	return existed, evicted, err
+}
+
+// PeekOrAdd records the call and calls PeekOrAddFunc.
+func (mock *Mock[K, V]) PeekOrAdd(key K, value V) (previous V, existed bool, err error) {
+	mock.record("PeekOrAdd", key, value)
+
+	if mock.PeekOrAddFunc != nil {
+		previous, existed, err = mock.PeekOrAddFunc(key, value)
+	}
+
+	return previous, existed, err
+}
+
+// Remove records the call and calls RemoveFunc.
+func (mock *Mock[K, V]) Remove(key K) (removed bool, err error) {
+	mock.record("Remove", key)
+
+	if mock.RemoveFunc != nil {
+		removed, err = mock.RemoveFunc(key)
+	}
+
+	return removed, err
+}
+
+// RemoveOldest records the call and calls RemoveOldestFunc.
+func (mock *Mock[K, V]) RemoveOldest(n int) (removed int, err error) {
+	mock.record("RemoveOldest", n)
+
+	if mock.RemoveOldestFunc != nil {
+		removed, err = mock.RemoveOldestFunc(n)
+	}
+
+	return removed, err
+}
+
+// Purge records the call and calls PurgeFunc.
+func (mock *Mock[K, V]) Purge() (err error) {
+	mock.record("Purge")
+
+	if mock.PurgeFunc != nil {
+		err = mock.PurgeFunc()
+	}
+
+	return err
+}
+
+// Range records the call and calls RangeFunc.
+func (mock *Mock[K, V]) Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error) {
+	mock.record("Range", ctx, fn)
+
+	if mock.RangeFunc != nil {
+		count, err = mock.RangeFunc(ctx, fn)
+	}
+
+	return count, err
+}
+
+// Len records the call and calls LenFunc.
+func (mock *Mock[K, V]) Len() (len int64) {
+	mock.record("Len")
+
+	if mock.LenFunc != nil {
+		len = mock.LenFunc()
+	}
+
+	return len
+}
+
+// Status records the call and calls StatusFunc.
+func (mock *Mock[K, V]) Status() (status sq_cache.CacheStatus) {
+	mock.record("Status")
+
+	if mock.StatusFunc != nil {
+		status = mock.StatusFunc()
+	}
+
+	return status
+}
+
+// Start records the call and calls StartFunc.
+func (mock *Mock[K, V]) Start() {
+	mock.record("Start")
+
+	if mock.StartFunc != nil {
+		mock.StartFunc()
+	}
+}
+
+// Stop records the call and calls StopFunc.
+func (mock *Mock[K, V]) Stop() {
+	mock.record("Stop")
+
+	if mock.StopFunc != nil {
+		mock.StopFunc()
+	}
+}
+
+// Close records the call and calls CloseFunc.
+func (mock *Mock[K, V]) Close() {
+	mock.record("Close")
+
+	if mock.CloseFunc != nil {
+		mock.CloseFunc()
+	}
+}
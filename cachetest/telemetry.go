@@ -0,0 +1,148 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package cachetest
+
+import (
+	"testing"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// TelemetryDelta holds the changes of the telemetry counters of a cache.
+type TelemetryDelta struct {
+	Add    int64
+	Update int64
+	Hit    int64
+	Miss   int64
+	Evict  int64
+
+	LoadSuccess int64
+	LoadFailure int64
+	LoadTimeout int64
+
+	Oversized int64
+	Rejected  int64
+}
+
+// telemetryOf returns the current telemetry counters of a cache as a TelemetryDelta from zero.
+func telemetryOf[K sq_cache.IKey, V sq_cache.IValue](
+	t testing.TB,
+	cache *sq_cache.LRUCache[K, V],
+) (counters TelemetryDelta) {
+	t.Helper()
+
+	telemetry, err := cache.Telemetry()
+	if err != nil {
+		t.Fatalf("Telemetry: %v", err)
+	}
+
+	return TelemetryDelta{
+		Add:         telemetry.GetAddCounter(),
+		Update:      telemetry.GetUpdateCounter(),
+		Hit:         telemetry.GetHitCounter(),
+		Miss:        telemetry.GetMissCounter(),
+		Evict:       telemetry.GetEvictCounter(),
+		LoadSuccess: telemetry.GetLoadSuccessCounter(),
+		LoadFailure: telemetry.GetLoadFailureCounter(),
+		LoadTimeout: telemetry.GetLoadTimeoutCounter(),
+		Oversized:   telemetry.GetOversizedCounter(),
+		Rejected:    telemetry.GetRejectedCounter(),
+	}
+}
+
+// MeasureTelemetry runs the specified function and returns the changes of the telemetry counters of the cache, which
+// must have its telemetry enabled.
+//
+// Parameters:
+//   - t: The test.
+//   - cache: The cache to measure.
+//   - fn: The function to run.
+//
+// Returns:
+//   - delta: The changes of the telemetry counters while fn ran.
+//
+// Example Usage:
+//
+//	delta := cachetest.MeasureTelemetry(t, cache, func() {
+//	    _, _ = cache.Get("missing")
+//	})
+func MeasureTelemetry[K sq_cache.IKey, V sq_cache.IValue](
+	t testing.TB,
+	cache *sq_cache.LRUCache[K, V],
+	fn func(),
+) (delta TelemetryDelta) {
+	t.Helper()
+
+	before := telemetryOf(t, cache)
+	fn()
+	after := telemetryOf(t, cache)
+
+	return TelemetryDelta{
+		Add:         after.Add - before.Add,
+		Update:      after.Update - before.Update,
+		Hit:         after.Hit - before.Hit,
+		Miss:        after.Miss - before.Miss,
+		Evict:       after.Evict - before.Evict,
+		LoadSuccess: after.LoadSuccess - before.LoadSuccess,
+		LoadFailure: after.LoadFailure - before.LoadFailure,
+		LoadTimeout: after.LoadTimeout - before.LoadTimeout,
+		Oversized:   after.Oversized - before.Oversized,
+		Rejected:    after.Rejected - before.Rejected,
+	}
+}
+
+// AssertTelemetryDelta runs the specified function and fails the test, if the telemetry counters of the cache didn't
+// change by exactly the expected delta. The cache must have its telemetry enabled and shouldn't be used concurrently.
+//
+// Parameters:
+//   - t: The test.
+//   - cache: The cache to measure.
+//   - want: The expected changes of the telemetry counters.
+//   - fn: The function to run.
+//
+// Example Usage:
+//
+//	cachetest.AssertTelemetryDelta(t, cache, cachetest.TelemetryDelta{Miss: 1, LoadFailure: 1}, func() {
+//	    _, _ = cache.GetOrLoad(ctx, "key")
+//	})
+func AssertTelemetryDelta[K sq_cache.IKey, V sq_cache.IValue](
+	t testing.TB,
+	cache *sq_cache.LRUCache[K, V],
+	want TelemetryDelta,
+	fn func(),
+) {
+	t.Helper()
+
+	if got := MeasureTelemetry(t, cache, fn); got != want {
+		t.Errorf("telemetry delta %+v, want %+v", got, want)
+	}
+}
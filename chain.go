@@ -0,0 +1,333 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Chain composes caches into a multi-level cache, e.g. a small in-process cache in front of a large shared one. Reads
+// go through the levels in order, a hit on a lower level back-fills the higher levels with the remaining TTL (time to
+// live) of the cache item, and writes go through all levels from the lowest to the highest. The lowest level is the
+// authoritative one, it serves Range and Len and loads the missing values of GetOrLoad. The hits, misses and back-fills
+// (counted as adds) are tracked per level, see LevelTelemetry.
+type Chain[K IKey, V IValue] struct {
+	levels    []Cache[K, V]
+	telemetry []*telemetry
+}
+
+// Chain implements the Cache interface.
+var _ Cache[string, []byte] = (*Chain[string, []byte])(nil)
+
+// NewChain creates a multi-level cache of the specified levels, from the highest (fastest) to the lowest level.
+//
+// Parameters:
+//   - levels: The caches of the levels, at least one.
+//
+// Returns:
+//   - chain: The new multi-level cache.
+//   - err: An error if no levels were specified.
+//
+// Example Usage:
+//
+//	chain, err := sq_cache.NewChain[string, []byte](local, shared)
+//	if err != nil {
+//	    panic(err)
+//	}
+//
+//	value, err := chain.GetOrLoad(ctx, "my-key")
+func NewChain[K IKey, V IValue](levels ...Cache[K, V]) (chain *Chain[K, V], err error) {
+	if len(levels) == 0 {
+		return nil, errors.New("chain must have at least one level")
+	}
+
+	chain = &Chain[K, V]{
+		levels:    levels,
+		telemetry: make([]*telemetry, len(levels)),
+	}
+	for level := range levels {
+		chain.telemetry[level] = newTelemetry()
+	}
+
+	return chain, nil
+}
+
+// lowest returns the lowest level of the chain.
+func (chain *Chain[K, V]) lowest() (cache Cache[K, V]) {
+	return chain.levels[len(chain.levels)-1]
+}
+
+// backfill adds a cache item found on the specified level to the levels above it, attributed to the PromotionSource.
+func (chain *Chain[K, V]) backfill(level int, key K, value V, ttl time.Duration) {
+	ctx := WithSource(context.Background(), PromotionSource)
+	for upper := range level {
+		var err error
+		if setter, ok := chain.levels[upper].(contextSetter[K, V]); ok {
+			_, err = setter.SetContext(ctx, key, value, ttl)
+		} else {
+			_, err = chain.levels[upper].SetWithTTL(key, value, ttl)
+		}
+		if err == nil {
+			chain.telemetry[upper].incrementCounter(Add)
+		}
+	}
+}
+
+// get reads through the levels, until the key is found, and back-fills the levels above the one it was found on.
+func (chain *Chain[K, V]) get(key K) (value V, ttl time.Duration, found bool, err error) {
+	for level, cache := range chain.levels {
+		if value, ttl, found, err = cache.GetWithTTL(key); err != nil {
+			return value, 0, false, err
+		}
+		if found {
+			chain.telemetry[level].incrementCounter(Hit)
+			chain.backfill(level, key, value, ttl)
+			return value, ttl, true, nil
+		}
+		chain.telemetry[level].incrementCounter(Miss)
+	}
+
+	return value, 0, false, nil
+}
+
+// Set adds a key-value pair to all levels, see LRUCache.Set.
+func (chain *Chain[K, V]) Set(key K, value V) (returnKey K, err error) {
+	for level := len(chain.levels) - 1; level >= 0; level-- {
+		if returnKey, err = chain.levels[level].Set(key, value); err != nil {
+			return returnKey, err
+		}
+		key = returnKey
+	}
+
+	return returnKey, nil
+}
+
+// SetWithTTL adds a key-value pair with a TTL (time to live) to all levels, see LRUCache.SetWithTTL.
+func (chain *Chain[K, V]) SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error) {
+	for level := len(chain.levels) - 1; level >= 0; level-- {
+		if returnKey, err = chain.levels[level].SetWithTTL(key, value, duration); err != nil {
+			return returnKey, err
+		}
+		key = returnKey
+	}
+
+	return returnKey, nil
+}
+
+// Get reads a value through the levels, see LRUCache.Get.
+func (chain *Chain[K, V]) Get(key K) (value V, err error) {
+	value, _, _, err = chain.get(key)
+
+	return value, err
+}
+
+// GetWithTTL reads a value and its remaining TTL (time to live) through the levels, see LRUCache.GetWithTTL.
+func (chain *Chain[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error) {
+	return chain.get(key)
+}
+
+// GetOrLoad reads a value through the levels. If no level has the key, it is loaded by the lowest level and
+// back-filled into the higher levels, see LRUCache.GetOrLoad.
+func (chain *Chain[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	value, _, found, err := chain.get(key)
+	if err != nil || found {
+		return value, err
+	}
+
+	if value, err = chain.lowest().GetOrLoad(ctx, key); err != nil {
+		return value, err
+	}
+	chain.backfill(len(chain.levels)-1, key, value, 0)
+
+	return value, nil
+}
+
+// Peek reads a value through the levels, without updating the recent-ness of the cache item and without back-filling,
+// see LRUCache.Peek.
+func (chain *Chain[K, V]) Peek(key K) (value V, err error) {
+	for _, cache := range chain.levels {
+		if value, err = cache.Peek(key); err != nil || value != nil {
+			return value, err
+		}
+	}
+
+	return value, nil
+}
+
+// Contains checks if any level contains the key, see LRUCache.Contains.
+func (chain *Chain[K, V]) Contains(key K) (found bool, err error) {
+	for _, cache := range chain.levels {
+		if found, err = cache.Contains(key); err != nil || found {
+			return found, err
+		}
+	}
+
+	return false, nil
+}
+
+// ContainsOrAdd adds a key-value pair to all levels, if the lowest level doesn't contain the key, see
+// LRUCache.ContainsOrAdd.
+func (chain *Chain[K, V]) ContainsOrAdd(key K, value V) (existed, evicted bool, err error) {
+	if existed, evicted, err = chain.lowest().ContainsOrAdd(key, value); err != nil || existed {
+		return existed, evicted, err
+	}
+	for level := len(chain.levels) - 2; level >= 0; level-- {
+		if _, err = chain.levels[level].Set(key, value); err != nil {
+			return false, evicted, err
+		}
+	}
+
+	return false, evicted, nil
+}
+
+// PeekOrAdd retrieves a value from the lowest level, or adds the key-value pair to all levels, if the lowest level
+// doesn't contain the key, see LRUCache.PeekOrAdd.
+func (chain *Chain[K, V]) PeekOrAdd(key K, value V) (previous V, existed bool, err error) {
+	if previous, existed, err = chain.lowest().PeekOrAdd(key, value); err != nil || existed {
+		return previous, existed, err
+	}
+	for level := len(chain.levels) - 2; level >= 0; level-- {
+		if _, err = chain.levels[level].Set(key, value); err != nil {
+			return previous, false, err
+		}
+	}
+
+	return previous, false, nil
+}
+
+// Remove removes a key from all levels, see LRUCache.Remove.
+func (chain *Chain[K, V]) Remove(key K) (removed bool, err error) {
+	for _, cache := range chain.levels {
+		var levelRemoved bool
+		if levelRemoved, err = cache.Remove(key); err != nil {
+			return removed, err
+		}
+		removed = removed || levelRemoved
+	}
+
+	return removed, nil
+}
+
+// RemoveOldest removes up to n least recently used cache items of every level, and returns the number of cache items
+// removed from the lowest level, see LRUCache.RemoveOldest.
+func (chain *Chain[K, V]) RemoveOldest(n int) (removed int, err error) {
+	for _, cache := range chain.levels {
+		if removed, err = cache.RemoveOldest(n); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// Purge removes all cache items of all levels, see LRUCache.Purge.
+func (chain *Chain[K, V]) Purge() (err error) {
+	for _, cache := range chain.levels {
+		if err = cache.Purge(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Range calls the specified function for the cache items of the lowest level, see LRUCache.Range.
+func (chain *Chain[K, V]) Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error) {
+	return chain.lowest().Range(ctx, fn)
+}
+
+// Len returns the number of cache items of the lowest level.
+func (chain *Chain[K, V]) Len() (len int64) {
+	return chain.lowest().Len()
+}
+
+// Status returns the status of the highest level.
+func (chain *Chain[K, V]) Status() (status CacheStatus) {
+	return chain.levels[0].Status()
+}
+
+// Start starts all levels.
+func (chain *Chain[K, V]) Start() {
+	for _, cache := range chain.levels {
+		cache.Start()
+	}
+}
+
+// Stop stops all levels.
+func (chain *Chain[K, V]) Stop() {
+	for _, cache := range chain.levels {
+		cache.Stop()
+	}
+}
+
+// Close closes all levels.
+func (chain *Chain[K, V]) Close() {
+	for _, cache := range chain.levels {
+		cache.Close()
+	}
+}
+
+// Levels returns the number of levels of the chain.
+func (chain *Chain[K, V]) Levels() (levels int) {
+	return len(chain.levels)
+}
+
+// LevelTelemetry returns a copy of the telemetry of the specified level: the hits and misses of the reads through the
+// level, and the back-fills of the level as adds.
+//
+// Parameters:
+//   - level: The level, between 0 (highest) and Levels() - 1 (lowest).
+//
+// Returns:
+//   - telemetry: The telemetry of the level.
+//   - err: An error if the level doesn't exist.
+//
+// Example Usage:
+//
+//	telemetry, err := chain.LevelTelemetry(0)
+//	if err != nil {
+//	    panic(err)
+//	}
+//
+//	fmt.Println("L1 hits:", telemetry.GetHitCounter())
+func (chain *Chain[K, V]) LevelTelemetry(level int) (telemetry *telemetry, err error) {
+	if level < 0 || level >= len(chain.levels) {
+		return nil, errors.New("chain level doesn't exist")
+	}
+
+	telemetry = newTelemetry()
+	telemetry.merge(chain.telemetry[level])
+
+	return telemetry, nil
+}
@@ -0,0 +1,175 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CleanupPacing represents the budget of the periodic cleanup (and of Cleanup), which bounds the impact of the removal
+// of the expired cache items on the foreground traffic. A paced cleanup sweeps a shard in steps: between the steps the
+// lock of the shard is released and the sweep yields, so that the waiting operations proceed. The timing wheels of
+// ExpiryResolution aren't paced, as they already spread the removals over their ticks.
+type CleanupPacing struct {
+	// MaxRemovalsPerCycle is the maximum number of expired cache items removed by a cleanup, the remaining ones are
+	// removed by the following cleanups. Zero means no limit.
+	MaxRemovalsPerCycle int64
+	// MaxRemovalsPerSecond is the maximum rate of the removals of expired cache items, the cleanup waits for the rate
+	// with the lock of the shard released. Zero means no limit.
+	MaxRemovalsPerSecond int64
+	// MaxLockHold is the maximum time a step of the sweep holds the lock of a shard, beyond which the sweep yields and
+	// resumes. Zero means no limit.
+	MaxLockHold time.Duration
+}
+
+// removalLimiter represents a token bucket, which limits the rate of the removals of a paced cleanup. The bucket holds
+// the tokens of up to one second.
+type removalLimiter struct {
+	sync.Mutex
+
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// newRemovalLimiter creates a removalLimiter with the specified rate per second and a full bucket.
+func newRemovalLimiter(rate int64) (limiter *removalLimiter) {
+	return &removalLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// take takes up to the specified number of tokens. If no token is available, it returns the time until the next token
+// is available.
+func (limiter *removalLimiter) take(n int64) (taken int64, wait time.Duration) {
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	now := time.Now()
+	limiter.tokens = min(limiter.rate, limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.rate)
+	limiter.last = now
+
+	taken = min(n, int64(limiter.tokens))
+	if taken <= 0 {
+		return 0, time.Duration((1 - limiter.tokens) / limiter.rate * float64(time.Second))
+	}
+	limiter.tokens -= float64(taken)
+
+	return taken, 0
+}
+
+// put returns the specified number of unused tokens.
+func (limiter *removalLimiter) put(n int64) {
+	limiter.Lock()
+	limiter.tokens = min(limiter.rate, limiter.tokens+float64(n))
+	limiter.Unlock()
+}
+
+// takeBudget takes up to the specified number of removals from the remaining budget of a cleanup.
+func takeBudget(budget *atomic.Int64, n int64) (taken int64) {
+	for {
+		remaining := budget.Load()
+		taken = min(n, remaining)
+		if taken <= 0 || budget.CompareAndSwap(remaining, remaining-taken) {
+			return max(taken, 0)
+		}
+	}
+}
+
+// sweepShard removes the expired cache items of the shard in steps, paced by the cleanup pacing of the cache, until
+// the shard was swept, the budget of the cleanup is spent or the context is done. The budget is shared by the shards
+// of a cleanup, nil if the removals per cleanup aren't limited.
+func (cache *LRUCache[K, V]) sweepShard(
+	ctx context.Context, shard *lruCacheShard[K, V], budget *atomic.Int64,
+) (removed int64, err error) {
+	var cursor *lruListNode[K, V]
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return removed, err
+		}
+
+		limit := int64(math.MaxInt64)
+		if budget != nil {
+			if limit = takeBudget(budget, limit); limit == 0 {
+				return removed, nil
+			}
+		}
+		if cache.cleanupLimiter != nil {
+			taken, wait := cache.cleanupLimiter.take(limit)
+			if taken == 0 {
+				if budget != nil {
+					budget.Add(limit)
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return removed, ctx.Err()
+				case <-timer.C:
+				}
+				continue
+			}
+			limit = taken
+		}
+
+		shard.Lock()
+		var deadline time.Time
+		if cache.cleanupPacing.MaxLockHold > 0 {
+			deadline = time.Now().Add(cache.cleanupPacing.MaxLockHold)
+		}
+		evictCount, next := shard.CleanupShardStep(cursor, limit, deadline)
+		cache.len.Add(-evictCount)
+		shard.Unlock()
+
+		removed += evictCount
+		if budget != nil {
+			budget.Add(limit - evictCount)
+		}
+		if cache.cleanupLimiter != nil {
+			cache.cleanupLimiter.put(limit - evictCount)
+		}
+
+		if next == nil {
+			return removed, nil
+		}
+		cursor = next
+		runtime.Gosched()
+	}
+}
@@ -0,0 +1,180 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Command sqcachectl inspects and manages the cache of a running process over its admin API (see package admin).
+//
+// Usage:
+//
+//	sqcachectl [-addr http://127.0.0.1:6061] stats
+//	sqcachectl [-addr ...] list [-limit 100] [-cursor c]   (prints a page of the keys, and the cursor of the next page)
+//	sqcachectl [-addr ...] get <key>
+//	sqcachectl [-addr ...] set [-ttl 5m] <key> [value]   (reads the value from stdin, if omitted)
+//	sqcachectl [-addr ...] delete <key>
+//	sqcachectl [-addr ...] purge
+//	sqcachectl [-addr ...] cleanup
+//
+// The flags -cert, -key and -cacert configure mutual TLS against a server created by admin.NewServer, the flag -token
+// or the environment variable SQCACHE_TOKEN a bearer token.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache/admin"
+)
+
+func main() {
+	addr := flag.String("addr", "http://127.0.0.1:6061", "address of the admin API")
+	timeout := flag.Duration("timeout", time.Second*10, "timeout of a request")
+	cert := flag.String("cert", "", "PEM file of the client certificate")
+	key := flag.String("key", "", "PEM file of the private key of the client certificate")
+	cacert := flag.String("cacert", "", "PEM file of the certificate authorities of the server")
+	token := flag.String("token", os.Getenv("SQCACHE_TOKEN"), "bearer token of the requests")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	client, err := admin.NewClient(admin.ClientOptions{CertFile: *cert, KeyFile: *key, CAFile: *cacert, Token: *token})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sqcachectl:", err)
+		os.Exit(1)
+	}
+	client.Timeout = *timeout
+	base := strings.TrimSuffix(*addr, "/")
+
+	if err := run(client, base, flag.Arg(0), flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "sqcachectl:", err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the usage of the command.
+func usage() {
+	fmt.Fprintln(flag.CommandLine.Output(), "usage: sqcachectl [flags] stats | list [-limit n] [-cursor c] | get <key> | set [-ttl d] <key> [value] | "+
+		"delete <key> | purge | cleanup")
+	flag.PrintDefaults()
+}
+
+// run executes the specified command against the admin API.
+func run(client *http.Client, base, command string, args []string) (err error) {
+	switch command {
+	case "stats":
+		return do(client, http.MethodGet, base+"/stats", nil)
+	case "list":
+		flags := flag.NewFlagSet("list", flag.ContinueOnError)
+		limit := flags.Int("limit", 100, "maximum number of keys of the page")
+		cursor := flags.String("cursor", "", "cursor of the page, returned by the previous page")
+		if err = flags.Parse(args); err != nil {
+			return err
+		}
+
+		query := url.Values{"limit": {fmt.Sprint(*limit)}}
+		if *cursor != "" {
+			query.Set("cursor", *cursor)
+		}
+		return do(client, http.MethodGet, base+"/entries?"+query.Encode(), nil)
+	case "get":
+		if len(args) != 1 {
+			return errors.New("get requires a key")
+		}
+		return do(client, http.MethodGet, keyURL(base, args[0]), nil)
+	case "set":
+		flags := flag.NewFlagSet("set", flag.ContinueOnError)
+		ttl := flags.Duration("ttl", 0, "time to live of the value, zero means the default of the cache")
+		if err = flags.Parse(args); err != nil {
+			return err
+		}
+		args = flags.Args()
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("set requires a key and an optional value")
+		}
+
+		var body io.Reader = os.Stdin
+		if len(args) == 2 {
+			body = strings.NewReader(args[1])
+		}
+
+		target := keyURL(base, args[0])
+		if *ttl > 0 {
+			target += "?ttl=" + url.QueryEscape(ttl.String())
+		}
+		return do(client, http.MethodPut, target, body)
+	case "delete":
+		if len(args) != 1 {
+			return errors.New("delete requires a key")
+		}
+		return do(client, http.MethodDelete, keyURL(base, args[0]), nil)
+	case "purge", "cleanup":
+		return do(client, http.MethodPost, base+"/"+command, nil)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// keyURL returns the URL of the key endpoint.
+func keyURL(base, key string) (target string) {
+	return base + "/keys/" + url.PathEscape(key)
+}
+
+// do sends the request and copies the response body to stdout.
+func do(client *http.Client, method, target string, body io.Reader) (err error) {
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	_, err = io.Copy(os.Stdout, resp.Body)
+
+	return err
+}
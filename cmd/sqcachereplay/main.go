@@ -0,0 +1,125 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Command sqcachereplay replays an access trace recorded by LRUCache.StartTrace against every combination of the
+// specified shard counts and capacities and reports their hit ratios.
+//
+// Usage:
+//
+//	sqcachereplay -trace cache.trace -shards 16,64,256 -items 10000,100000
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rommarius/sq_cache"
+)
+
+func main() {
+	tracePath := flag.String("trace", "", "path of the recorded trace")
+	shards := flag.String("shards", "256", "comma separated list of shard counts")
+	items := flag.String("items", "1000000", "comma separated list of capacities")
+	flag.Parse()
+
+	if *tracePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*tracePath, *shards, *items); err != nil {
+		fmt.Fprintln(os.Stderr, "sqcachereplay:", err)
+		os.Exit(1)
+	}
+}
+
+// run replays the trace against every combination of shard counts and capacities.
+func run(tracePath, shardList, itemList string) (err error) {
+	shardCounts, err := parseList(shardList)
+	if err != nil {
+		return fmt.Errorf("invalid shards: %w", err)
+	}
+	capacities, err := parseList(itemList)
+	if err != nil {
+		return fmt.Errorf("invalid items: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "shards\titems\tgets\thits\thit ratio\tsets\tremoves\tevictions\t")
+
+	for _, shardCount := range shardCounts {
+		for _, capacity := range capacities {
+			result, err := replay(tracePath, shardCount, capacity)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%.2f%%\t%d\t%d\t%d\t\n", shardCount, capacity,
+				result.Gets, result.Hits, result.HitRatio()*100, result.Sets, result.Removes, result.Evicted)
+		}
+	}
+
+	return w.Flush()
+}
+
+// replay replays the trace against a cache with the specified shard count and capacity.
+func replay(tracePath string, shardCount, capacity int64) (result sq_cache.TraceReplayResult, err error) {
+	file, err := os.Open(tracePath)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	return sq_cache.ReplayTrace(context.Background(), file, &sq_cache.Config[string, []byte]{
+		MaxShards: shardCount,
+		MaxItems:  capacity,
+	})
+}
+
+// parseList parses a comma separated list of positive integers.
+func parseList(list string) (values []int64, err error) {
+	for _, part := range strings.Split(list, ",") {
+		value, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if value <= 0 {
+			return nil, fmt.Errorf("%d is not positive", value)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
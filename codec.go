@@ -0,0 +1,81 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec is an interface that marshals values of rich types to the bytes stored in the cache and back, e.g. by JSON,
+// gob, protobuf or msgpack. As the cache keeps the marshaled bytes, the byte size accounting, the compression and the
+// persistence work the same for every type.
+type Codec[T any] interface {
+	Marshal(value T) (data []byte, err error)
+	Unmarshal(data []byte) (value T, err error)
+}
+
+// JSONCodec is a Codec, which marshals the values by encoding/json.
+type JSONCodec[T any] struct{}
+
+// Marshal marshals the specified value to JSON.
+func (JSONCodec[T]) Marshal(value T) (data []byte, err error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal unmarshals the specified JSON to a value.
+func (JSONCodec[T]) Unmarshal(data []byte) (value T, err error) {
+	err = json.Unmarshal(data, &value)
+
+	return value, err
+}
+
+// GobCodec is a Codec, which marshals the values by encoding/gob.
+type GobCodec[T any] struct{}
+
+// Marshal marshals the specified value to gob.
+func (GobCodec[T]) Marshal(value T) (data []byte, err error) {
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal unmarshals the specified gob to a value.
+func (GobCodec[T]) Unmarshal(data []byte) (value T, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+
+	return value, err
+}
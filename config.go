@@ -31,23 +31,379 @@
 
 package sq_cache
 
+import (
+	"context"
+	"time"
+)
+
 // Config is a structure that holds user-configured settings.
 type Config[K IKey, V IValue] struct {
-	LoggingOn   bool
+	// LoggingDisabled, TelemetryDisabled and CallbacksDisabled switch off the logging, the telemetry counters and the
+	// On... callbacks, which are all on by default. They are negated, because the merge with the defaults replaces the
+	// zero values of the user configuration, so a LoggingOn of false would always be turned back to true.
+	LoggingDisabled   bool
+	TelemetryDisabled bool
+	CallbacksDisabled bool
+
+	// Deprecated: Use LoggingDisabled instead. The logging is on by default, a LoggingOn of true overrides
+	// LoggingDisabled, a LoggingOn of false has no effect.
+	LoggingOn bool
+	// Deprecated: Use TelemetryDisabled instead. The telemetry is on by default, a TelemetryOn of true overrides
+	// TelemetryDisabled, a TelemetryOn of false has no effect.
 	TelemetryOn bool
+	// Deprecated: Use CallbacksDisabled instead. The callbacks are on by default, a CallbacksOn of true overrides
+	// CallbacksDisabled, a CallbacksOn of false has no effect.
+	CallbacksOn bool
 
 	MaxShards int64
 	MaxItems  int64
 
-	ExpiryDurationInSeconds  int64
+	// EvictBatchSize is the maximum number of items evicted by a single Set, if the cache is over its capacity.
+	EvictBatchSize int64
+
+	// ShardStrategy selects the mapping of the keys to the shards, if no GenerateShardId was specified.
+	ShardStrategy ShardStrategy
+	// VirtualNodes is the number of virtual nodes per shard on the hash ring of the ConsistentHashSharding strategy.
+	VirtualNodes int64
+
+	// ShardIndex selects the implementation of the key index of the shards.
+	ShardIndex ShardIndexType
+
+	// EvictionPolicy selects the cache items to evict. ClockEviction approximates LRU by a reference bit, which is set by
+	// the reads under the read lock of the shard instead of moving the cache item, so the reads of a read-heavy shard
+	// don't take its write lock. A referenced cache item gets a second chance at the eviction. SampledEviction
+	// approximates LRU like Redis: an access only stamps the cache item, the eviction samples EvictionSamples random
+	// cache items of the shard and evicts the least recently used one among them. FIFOEviction evicts the cache items in
+	// their insertion order, regardless of their accesses. NoEviction never evicts, a Set of a new key fails with
+	// ErrCacheFull once the cache is at its capacity; it doesn't support the write buffers and the set coalescing.
+	// TTLEviction samples like SampledEviction, but evicts the sampled cache item closest to its expiry, as it is about
+	// to expire anyway; if no sampled cache item expires, the least recently used one is evicted. CostEviction samples
+	// like SampledEviction, but evicts by GreedyDual-Size: the sampled cache item with the lowest cost per value byte,
+	// aged by the priority of the last evicted cache item, so cheap cache items go first without pinning expensive
+	// ones forever. FairEviction moves the accessed cache items like LRUEviction, but counts the cache items per bucket
+	// of FairnessBucket and evicts the least recently used cache item of the bucket with the most cache items in the
+	// shard, so that a single busy bucket, e.g. a tenant, can't monopolize the cache.
+	EvictionPolicy EvictionPolicy
+	// EvictionSamples is the number of cache items sampled per eviction by the SampledEviction, TTLEviction and
+	// CostEviction policies, at least 2.
+	EvictionSamples int64
+	// FairnessBucket maps the keys to the buckets of the FairEviction policy, by default the prefix of the keys up to
+	// and including the first ":" (see PrefixBucket).
+	FairnessBucket func(key K) K
+
+	// MaxCost is the capacity of the cache in cost units, split evenly across the shards; the cache items of a shard are
+	// evicted by the EvictionPolicy, until their total cost fits. Zero means no limit. It isn't supported by the
+	// NoEviction policy.
+	MaxCost int64
+	// Cost returns the cost of a cache item, which was set without an explicit cost (see SetWithCost). If nil, every
+	// cache item costs 1.
+	Cost func(key K, value V) int64
+	// MaxMemory is the capacity of the cache in bytes of estimated heap memory, split evenly across the shards like
+	// MaxCost. The cache items are weighed by EstimateSize, unless a Cost function is specified to override it. Zero
+	// means no limit. It is an alternative to MaxCost and isn't supported by the NoEviction policy.
+	MaxMemory int64
+
+	// VictimRatio enables a victim cache, a small LRU of the cache items recently evicted by capacity pressure, whose
+	// capacity is the specified fraction of MaxItems (e.g. 0.05). A miss consults it before the loader or the lower
+	// levels of a Chain, and moves a found cache item back into its shard, which rescues the cache items from eager
+	// shard-local evictions. The values of the victim cache aren't charged against MaxCost or MaxMemory. Zero disables
+	// it, it isn't supported by the NoEviction policy.
+	VictimRatio float64
+
+	// GhostRatio enables a ghost list, which remembers the hashes of the keys recently evicted by capacity pressure, up
+	// to the specified fraction of MaxItems (e.g. 0.5), at a few bytes per key. The misses of the remembered keys are
+	// counted by the additional capacity that would have kept them, in steps of 10% of MaxItems up to the GhostRatio,
+	// see GetMissesSaved of the telemetry. Zero disables it, it must be at most 1 and isn't supported by the NoEviction
+	// policy.
+	GhostRatio float64
+
+	// KeyLockStripes is the number of mutexes of LockKey, the keys are striped across them.
+	KeyLockStripes int64
+
+	// BloomFalsePositiveRate enables a bloom filter per shard, which skips the lookups of keys that definitely don't
+	// exist, at the specified false positive rate (e.g. 0.01). The filters are rebuilt on the cleanup, once they are
+	// stale, and on Purge. Zero disables the filters.
+	BloomFalsePositiveRate float64
+
+	// HotKeysCapacity enables the tracking of the most frequently accessed keys with the specified number of counters,
+	// reported by TopKeys. The counters are split across the shards. Zero disables the tracking.
+	HotKeysCapacity int64
+
+	// AccessStatsOn enables the tracking of the number of hits and the time of the last hit of every cache item,
+	// reported by GetEntry, RangeEntries, TopEntries and the exports, e.g. to find the cache items that are never hit.
+	AccessStatsOn bool
+
+	// ChecksumOn stores a CRC-32 checksum with the value of every cache item, which is verified by the Get methods. A
+	// cache item, whose value doesn't match its checksum, is evicted and the Get fails with ErrCorruptedEntry.
+	ChecksumOn bool
+
+	// ShardTuningOn enables the sampling of the shard lock contention and skew, which is used to recommend a shard count.
+	ShardTuningOn bool
+
+	// DebugChecks enables the verification of the internal invariants (list and index lengths, list links, length
+	// counter, no expired cache items served) after the operations, panicking with diagnostics on a violation. The
+	// checks walk the shards, they are meant for tests and debugging only.
+	DebugChecks bool
+
+	// SetCoalesceWindow enables the coalescing of repeated Sets of the same key (write-debounce). The first Set of a key
+	// is applied right away, the further Sets of the key within the window are coalesced into a single Set of the latest
+	// value at the end of the window, unless WriteBufferReadYourWrites applies it before a read. Zero disables the
+	// coalescing.
+	SetCoalesceWindow time.Duration
+
+	// WriteBufferSize enables a lock-free ring buffer per shard with the specified capacity, which buffers the Sets. The
+	// buffered Sets are applied to the shards by a background applier, so they become visible with a small delay.
+	// If the buffer of a shard is full, the Set is applied right away. Zero disables the buffers.
+	WriteBufferSize int64
+	// WriteBufferReadYourWrites applies the buffered Sets and the coalesced Sets of a shard before a read of the shard, so
+	// that a read sees the preceding Sets. It applies to the set coalescing without the write buffers as well.
+	WriteBufferReadYourWrites bool
+
+	// ExportBatchSize is the maximum number of entries passed to the function of ExportEntries at once.
+	ExportBatchSize int64
+
+	// Snapshot configures the automatic snapshots of the cache, e.g. for warm restarts. Nil disables the snapshots.
+	Snapshot *SnapshotPolicy
+
+	// WAL configures the write-ahead log of the cache, which is replayed by NewLRUCache to recover the cache items after
+	// a crash. Nil disables the write-ahead log.
+	WAL *WALPolicy
+
+	// DefaultTTL is used by all Sets, which don't specify a duration, e.g. Set, SetWithTTL with a zero duration,
+	// ContainsOrAdd, Warm and the loads of GetOrLoad. Zero or negative means no expiry.
+	DefaultTTL time.Duration
+	// TTLFunc derives the duration of a cache item from its key and value, e.g. from the Cache-Control header of an HTTP
+	// response or the expiry of a token. It is consulted wherever the DefaultTTL would be used, i.e. if no duration was
+	// specified, and takes precedence over it. Returning zero falls back to the DefaultTTL, negative means no expiry.
+	TTLFunc         func(key K, value V) time.Duration
+	CleanupInterval time.Duration
+	// CleanupPacing bounds the removals and the lock hold times of the cleanup. Nil removes the expired cache items of
+	// a shard under a single lock.
+	CleanupPacing *CleanupPacing
+	// CleanupDisabled doesn't start the periodic cleanup and its goroutine, e.g. for short-lived tools and tests. The
+	// expired cache items are still never served, but are only removed by Cleanup, by StartCleanup or by the eviction.
+	// It isn't supported with an ExpiryResolution.
+	CleanupDisabled bool
+
+	// ExpiryResolution enables a hierarchical timing wheel per shard, which removes the expired cache items at most one
+	// ExpiryResolution after their expiry, instead of scanning the shards every CleanupInterval. Zero disables the
+	// timing wheels. Resolutions below a millisecond are not recommended, as the wheels are advanced at the resolution.
+	ExpiryResolution time.Duration
+
+	// ExpiredKeysBuffer is the capacity of the channel returned by ExpiredKeys. If it is full, the oldest key is dropped.
+	// Negative disables the reporting of the expired keys.
+	ExpiredKeysBuffer int64
+
+	// Deprecated: Use DefaultTTL instead.
+	ExpiryDurationInSeconds int64
+	// Deprecated: Use CleanupInterval instead.
 	CleanupDurationInSeconds int64
 
-	GenerateKey     func(value V) K
-	GenerateShardId func(key K, maxItems int64) int64
+	// CopyOnRead returns copies of the cached values, so callers can't modify the cached values in place.
+	CopyOnRead bool
+	// CopyOnWrite caches copies of the specified values, so callers can reuse their values after caching them.
+	CopyOnWrite bool
+	// Clone copies a value for CopyOnRead and CopyOnWrite, by default the bytes of the value are copied.
+	Clone func(value V) V
+
+	// MaxValueBytes limits the size of the cached values in bytes, the oversized values are counted by the Oversized
+	// counter of the telemetry. Zero, the default, means no limit.
+	MaxValueBytes int64
+	// OversizedPassThrough skips the Sets of oversized values without an error, the value just isn't cached. False, the
+	// default, fails them with ErrValueTooLarge.
+	OversizedPassThrough bool
+
+	// MaxDependencyDepth limits the levels of dependents (see DependOn), which are removed with a cache item.
+	MaxDependencyDepth int64
+
+	// TombstoneTTL enables the tombstones: Remove records a tombstone of the key for the duration, which is dropped by
+	// the next write of the key. The imports skip the entries of a key with a tombstone, unless they were created after
+	// the removal, so a slower replica or a stale snapshot doesn't resurrect a deleted value. Zero disables the
+	// tombstones.
+	TombstoneTTL time.Duration
+
+	// Resolver decides whether an imported entry replaces the cached entry of its key, e.g. LastWriterWins, so that the
+	// concurrent writes of the instances of a replicated cache converge to the same value. With a Resolver, every local
+	// write without a creation time stamps the metadata of the cache item by a hybrid logical clock, which follows the
+	// imported creation times. Nil imports every entry.
+	Resolver func(key K, current, incoming Entry[K, V]) (useIncoming bool)
+
+	// AuditSink receives an AuditEvent of every Set, Remove and Purge, e.g. for the traceability of the invalidations.
+	// The events are queued and delivered asynchronously, AuditQueueSize bounds the queue, the events exceeding it are
+	// dropped. Nil disables the audit log.
+	AuditSink      AuditSink[K]
+	AuditQueueSize int64
+
+	// Namespaces bounds the cache items of the keys with the specified prefixes, e.g. of the tenants of a multi-tenant
+	// service or of the views of TypedCache, so that a burst of one namespace only evicts its own cache items. A key
+	// belongs to the namespace with the longest matching prefix, an empty prefix matches all keys. The quotas are divided
+	// between the shards like MaxCost. They aren't supported by the NoEviction policy.
+	Namespaces map[K]NamespaceQuota
+
+	// Admit is consulted before a value is cached, returning false prevents the value from being cached. A vetoed Set of
+	// a cached key removes the cached value, so that it isn't read stale.
+	Admit func(key K, value V) bool
+
+	// Loader loads the value of a key from the origin on a miss of GetOrLoad, the concurrent loads of a key are
+	// coalesced into one call. Nil, the default, fails GetOrLoad with ErrLoaderNotConfigured, unless a BulkLoader is set.
+	Loader func(ctx context.Context, key K) (value V, err error)
+	// LoadTimeout bounds every call of the loader (or bulk loader). Zero uses the default of 30 seconds, a negative
+	// timeout disables the bound.
+	LoadTimeout time.Duration
+	// LoadRetries is the number of retries of a failed load. Zero, the default, doesn't retry; it must not be negative.
+	LoadRetries int64
+	// LoadRetryBackoff is the wait before the first retry, doubled for every further retry. Zero uses the default of
+	// 100 milliseconds, a negative backoff retries right away.
+	LoadRetryBackoff time.Duration
+	// LoadFailureThreshold is the number of consecutive failed loads of a key, which opens the circuit of the key, so
+	// that its loads fail with ErrLoaderCircuitOpen for the LoadCircuitBreakDuration. Zero uses the default of 5.
+	LoadFailureThreshold int64
+	// LoadCircuitBreakDuration is how long the circuit of a key stays open. Zero uses the default of 30 seconds, a
+	// negative duration disables the circuit breaking.
+	LoadCircuitBreakDuration time.Duration
+
+	// MaxConcurrentLoads limits the number of concurrent calls of the loader (or bulk loader) across all keys, further
+	// loads wait for a free slot. Zero, the default, means no limit.
+	MaxConcurrentLoads int64
+	// MaxLoadsPerKey limits the number of calls sharing the in-flight load of a key, further calls fail with
+	// ErrLoaderBusy. Zero, the default, means no limit.
+	MaxLoadsPerKey int64
+
+	// BulkLoader replaces the Loader: the keys missed by GetOrLoad within the BulkLoadWindow are loaded by a single
+	// call, the keys missing in the returned values fail with ErrLoaderKeyNotFound. Nil, the default, loads every key by
+	// the Loader.
+	BulkLoader func(ctx context.Context, keys []K) (values map[K]V, err error)
+	// BulkLoadWindow is how long the keys are collected for a call of the bulk loader. Zero uses the default of 10
+	// milliseconds.
+	BulkLoadWindow time.Duration
+	// BulkLoadMaxKeys is the number of keys, which calls the bulk loader before the window has passed. Zero uses the
+	// default of 100.
+	BulkLoadMaxKeys int64
+
+	GenerateKey func(value V) K
+	// NormalizeKey maps the specified keys to their canonical form on every operation, e.g. by case-folding, trimming or
+	// prefixing them, so that equivalent keys always refer to the same cache item and shard. The generated keys are
+	// normalized as well. It must be idempotent, as the keys returned by the cache may be passed to it again.
+	NormalizeKey func(key K) K
+	// GenerateShardId maps the keys to the shards, shardIds out of range are mapped into the range by modulo MaxShards.
+	GenerateShardId func(key K, maxShards int64) int64
 
 	OnAdd    func(logginOn bool, node *lruListNode[K, V])
 	OnUpdate func(logginOn bool, node *lruListNode[K, V])
+	// OnHit and OnMiss are called by the reads under the read lock of the shard, so they run concurrently with each
+	// other, also for the same shard and key, and must be safe for concurrent use.
 	OnHit    func(logginOn bool, node *lruListNode[K, V])
 	OnMiss   func(logginOn bool, key K)
 	OnEvict  func(logginOn bool, node *lruListNode[K, V])
+	OnRemove func(logginOn bool, key K, value V, reason RemovalReason)
+	// OnAddContext, OnUpdateContext, OnEvictContext and OnRemoveContext are triggered in addition to the callbacks
+	// above, with the context of the operation causing them, e.g. of SetContext, RemoveContext or PurgeContext, which
+	// may carry an Operation (see WithOperation). The operations without a context, the buffered writes and the
+	// cleanup pass the background context.
+	OnAddContext    func(ctx context.Context, node *lruListNode[K, V])
+	OnUpdateContext func(ctx context.Context, node *lruListNode[K, V])
+	OnEvictContext  func(ctx context.Context, node *lruListNode[K, V])
+	OnRemoveContext func(ctx context.Context, key K, value V, reason RemovalReason)
+	// OnShardPurge is triggered after a shard was purged or trimmed, with the number of removed cache items.
+	OnShardPurge func(loggingOn bool, shardId int64, removed int64)
+}
+
+// ConfigSnapshot is a read-only copy of the effective configuration of a cache, merged from the defaults and the user
+// configuration and validated, returned by the Config method of the cache. The functions of the configuration are
+// reported by whether they are set.
+type ConfigSnapshot struct {
+	LoggingOn   bool
+	TelemetryOn bool
+	CallbacksOn bool
+
+	MaxShards int64
+	MaxItems  int64
+
+	EvictBatchSize int64
+
+	ShardStrategy ShardStrategy
+	VirtualNodes  int64
+	// CustomShardId reports whether a GenerateShardId function was specified, which overrides the ShardStrategy.
+	CustomShardId bool
+	// HasNormalizeKey reports whether a NormalizeKey function was specified.
+	HasNormalizeKey bool
+	// HasResolver reports whether a Resolver function was specified.
+	HasResolver bool
+
+	ShardIndex ShardIndexType
+
+	EvictionPolicy  EvictionPolicy
+	EvictionSamples int64
+
+	MaxCost int64
+	// HasCost reports whether a Cost function was specified.
+	HasCost bool
+
+	MaxMemory int64
+
+	VictimRatio float64
+	GhostRatio  float64
+
+	KeyLockStripes int64
+
+	BloomFalsePositiveRate float64
+	HotKeysCapacity        int64
+	AccessStatsOn          bool
+	ChecksumOn             bool
+
+	ShardTuningOn bool
+	DebugChecks   bool
+
+	SetCoalesceWindow time.Duration
+
+	WriteBufferSize           int64
+	WriteBufferReadYourWrites bool
+
+	ExportBatchSize int64
+
+	// Snapshot and WAL are copies of the policies, nil if they are disabled.
+	Snapshot *SnapshotPolicy
+	WAL      *WALPolicy
+
+	DefaultTTL time.Duration
+	// HasTTLFunc reports whether a TTLFunc was specified.
+	HasTTLFunc      bool
+	CleanupInterval time.Duration
+	// CleanupPacing is a copy of the pacing, nil if the cleanup isn't paced.
+	CleanupPacing     *CleanupPacing
+	CleanupDisabled   bool
+	ExpiryResolution  time.Duration
+	ExpiredKeysBuffer int64
+
+	CopyOnRead  bool
+	CopyOnWrite bool
+
+	MaxValueBytes        int64
+	OversizedPassThrough bool
+
+	MaxDependencyDepth int64
+	TombstoneTTL       time.Duration
+
+	// AuditQueueSize is the size of the queue of the audit events, zero if no AuditSink was specified.
+	AuditQueueSize int64
+
+	// Namespaces is a copy of the namespace quotas by their prefixes.
+	Namespaces map[string]NamespaceQuota
+
+	// HasAdmit, HasLoader and HasBulkLoader report whether the Admit, Loader and BulkLoader functions were specified.
+	HasAdmit      bool
+	HasLoader     bool
+	HasBulkLoader bool
+
+	LoadTimeout              time.Duration
+	LoadRetries              int64
+	LoadRetryBackoff         time.Duration
+	LoadFailureThreshold     int64
+	LoadCircuitBreakDuration time.Duration
+
+	MaxConcurrentLoads int64
+	MaxLoadsPerKey     int64
+
+	BulkLoadWindow  time.Duration
+	BulkLoadMaxKeys int64
 }
@@ -0,0 +1,119 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"slices"
+	"strconv"
+)
+
+// consistentHashRing represents a hash ring, on which every shard is placed multiple times (virtual nodes).
+type consistentHashRing struct {
+	points   []uint64
+	shardIds map[uint64]int64
+}
+
+// newConsistentHashRing creates a hash ring with the specified number of shards and virtual nodes per shard.
+func newConsistentHashRing(maxShards, virtualNodes int64) (ring *consistentHashRing) {
+	ring = &consistentHashRing{
+		points:   make([]uint64, 0, maxShards*virtualNodes),
+		shardIds: make(map[uint64]int64, maxShards*virtualNodes),
+	}
+
+	for shardId := range maxShards {
+		for virtualNode := range virtualNodes {
+			point := hashUint64(strconv.FormatInt(shardId, 10) + "-" + strconv.FormatInt(virtualNode, 10))
+			if _, found := ring.shardIds[point]; found {
+				continue
+			}
+
+			ring.points = append(ring.points, point)
+			ring.shardIds[point] = shardId
+		}
+	}
+
+	slices.Sort(ring.points)
+
+	return ring
+}
+
+// shardId returns the shardId of the first virtual node on the ring at or after the hash of the specified key.
+func (ring *consistentHashRing) shardId(key string) (shardId int64) {
+	point := hashUint64(key)
+
+	i, _ := slices.BinarySearch(ring.points, point)
+	if i == len(ring.points) {
+		i = 0
+	}
+
+	return ring.shardIds[ring.points[i]]
+}
+
+// hashUint64 hashes the specified string to an unsigned integer.
+func hashUint64(s string) (hash uint64) {
+	h := sha1.Sum([]byte(s))
+
+	return binary.BigEndian.Uint64(h[:])
+}
+
+// NewConsistentHashShardId creates a shardId generation function, which maps the keys to the shards by consistent
+// hashing. Every shard is placed virtualNodes times on a hash ring, so that a change of the number of shards only
+// relocates a fraction of the keys, instead of nearly all keys as with modulo sharding.
+// The hash ring is built once for the specified number of shards, the number of shards passed to the returned function
+// is ignored.
+//
+// Parameters:
+//   - maxShards: The number of shards to place on the hash ring.
+//   - virtualNodes: The number of virtual nodes per shard.
+//
+// Returns:
+//   - generateShardId: The shardId generation function, e.g. for the GenerateShardId configuration.
+//
+// Example Usage:
+//
+//	config := &sq_cache.Config[string, []byte]{
+//	    MaxShards:       256,
+//	    GenerateShardId: sq_cache.NewConsistentHashShardId[string](256, 128),
+//	}
+func NewConsistentHashShardId[K IKey](maxShards, virtualNodes int64) (generateShardId func(key K, maxShards int64) int64) {
+	if virtualNodes < 1 {
+		virtualNodes = 1
+	}
+
+	ring := newConsistentHashRing(maxShards, virtualNodes)
+
+	return func(key K, _ int64) (shardId int64) {
+		return ring.shardId(string(key))
+	}
+}
@@ -0,0 +1,58 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"testing"
+)
+
+func FuzzConsistentHashShardId(f *testing.F) {
+	f.Add("", uint8(1), uint8(1))
+	f.Add("my-key", uint8(16), uint8(128))
+	f.Add("\x00\xff", uint8(255), uint8(0))
+
+	f.Fuzz(func(t *testing.T, key string, shards, virtualNodes uint8) {
+		maxShards := int64(shards)
+		if maxShards < 1 {
+			t.Skip()
+		}
+
+		generateShardId := NewConsistentHashShardId[string](maxShards, int64(virtualNodes))
+		shardId := generateShardId(key, maxShards)
+		if shardId < 0 || shardId >= maxShards {
+			t.Fatalf("shardId of %q with %d shards = %d, want a shardId in range", key, maxShards, shardId)
+		}
+		if again := generateShardId(key, maxShards); again != shardId {
+			t.Fatalf("shardId of %q = %d and %d, want the same shardId", key, shardId, again)
+		}
+	})
+}
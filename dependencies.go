@@ -0,0 +1,238 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// dependencyGraph represents the thread-safe dependencies between the cache items. It is only consulted once a
+// dependency was declared, so the removals of caches without dependencies don't take its lock.
+type dependencyGraph[K IKey] struct {
+	sync.Mutex
+
+	used     atomic.Bool
+	maxDepth int64
+
+	// dependents maps the keys to the keys depending on them, dependencies maps the keys to the keys they depend on.
+	dependents   map[K]map[K]struct{}
+	dependencies map[K]map[K]struct{}
+}
+
+// newDependencyGraph initializes and returns a new, empty dependencyGraph instance, which removes up to maxDepth levels
+// of dependents.
+func newDependencyGraph[K IKey](maxDepth int64) (graph *dependencyGraph[K]) {
+	return &dependencyGraph[K]{
+		maxDepth: maxDepth,
+
+		dependents:   make(map[K]map[K]struct{}),
+		dependencies: make(map[K]map[K]struct{}),
+	}
+}
+
+// add declares that the key depends on the specified dependencies. No dependency is added, if one of them would
+// create a cycle.
+func (graph *dependencyGraph[K]) add(key K, dependencies []K) (err error) {
+	graph.Lock()
+	defer graph.Unlock()
+
+	for _, dependency := range dependencies {
+		if dependency == key || graph.reaches(key, dependency) {
+			return ErrDependencyCycle
+		}
+	}
+
+	for _, dependency := range dependencies {
+		if graph.dependents[dependency] == nil {
+			graph.dependents[dependency] = make(map[K]struct{})
+		}
+		graph.dependents[dependency][key] = struct{}{}
+
+		if graph.dependencies[key] == nil {
+			graph.dependencies[key] = make(map[K]struct{})
+		}
+		graph.dependencies[key][dependency] = struct{}{}
+	}
+	graph.used.Store(true)
+
+	return nil
+}
+
+// reaches reports whether the target is a direct or indirect dependent of the key. Must be called with the lock held.
+func (graph *dependencyGraph[K]) reaches(key, target K) bool {
+	visited := map[K]struct{}{key: {}}
+	queue := []K{key}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for dependent := range graph.dependents[current] {
+			if dependent == target {
+				return true
+			}
+			if _, found := visited[dependent]; !found {
+				visited[dependent] = struct{}{}
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	return false
+}
+
+// dependentsOf returns the direct and indirect dependents of the key, up to maxDepth levels, each one once.
+func (graph *dependencyGraph[K]) dependentsOf(key K) (dependents []K) {
+	if !graph.used.Load() {
+		return nil
+	}
+
+	graph.Lock()
+	defer graph.Unlock()
+
+	visited := map[K]struct{}{key: {}}
+	level := []K{key}
+	for depth := int64(0); depth < graph.maxDepth && len(level) > 0; depth++ {
+		var next []K
+		for _, current := range level {
+			for dependent := range graph.dependents[current] {
+				if _, found := visited[dependent]; !found {
+					visited[dependent] = struct{}{}
+					next = append(next, dependent)
+				}
+			}
+		}
+		dependents = append(dependents, next...)
+		level = next
+	}
+
+	return dependents
+}
+
+// forget drops all dependencies from and to the key, e.g. once its cache item was removed.
+func (graph *dependencyGraph[K]) forget(key K) {
+	if !graph.used.Load() {
+		return
+	}
+
+	graph.Lock()
+	defer graph.Unlock()
+
+	for dependency := range graph.dependencies[key] {
+		delete(graph.dependents[dependency], key)
+		if len(graph.dependents[dependency]) == 0 {
+			delete(graph.dependents, dependency)
+		}
+	}
+	delete(graph.dependencies, key)
+
+	for dependent := range graph.dependents[key] {
+		delete(graph.dependencies[dependent], key)
+		if len(graph.dependencies[dependent]) == 0 {
+			delete(graph.dependencies, dependent)
+		}
+	}
+	delete(graph.dependents, key)
+}
+
+// clear drops all dependencies.
+func (graph *dependencyGraph[K]) clear() {
+	if !graph.used.Load() {
+		return
+	}
+
+	graph.Lock()
+	clear(graph.dependents)
+	clear(graph.dependencies)
+	graph.Unlock()
+}
+
+// DependOn declares that the cache item of the key depends on the cache items of the specified dependencies, e.g. a
+// derived computation on its inputs. Removing a dependency by Remove removes its dependents as well, up to
+// MaxDependencyDepth levels. The dependencies of a cache item are dropped once it leaves the cache, also if it expires
+// or is evicted; its dependents are only removed by Remove.
+//
+// Parameters:
+//   - key: The key of the dependent cache item.
+//   - dependencies: The keys of the cache items, which the cache item depends on.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, ErrKeyNotFound if a cache item doesn't exist,
+//     ErrDependencyCycle if a dependency would create a cycle, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	_, err := cache.Set("report:42", report)
+//	err = cache.DependOn("report:42", "orders:42", "customer:7")
+//
+//	// removes "report:42" as well
+//	_, err = cache.Remove("orders:42")
+func (cache *LRUCache[K, V]) DependOn(key K, dependencies ...K) (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	case Stopped:
+		return errors.New("cache is stopped, must be started before calling method DependOn()")
+	}
+
+	key = cache.normalizeKey(key)
+	normalized := make([]K, len(dependencies))
+	for i, dependency := range dependencies {
+		normalized[i] = cache.normalizeKey(dependency)
+	}
+
+	if found, _ := cache.Contains(key); !found {
+		return ErrKeyNotFound
+	}
+	for _, dependency := range normalized {
+		if found, _ := cache.Contains(dependency); !found {
+			return ErrKeyNotFound
+		}
+	}
+
+	return cache.dependencies.add(key, normalized)
+}
+
+// Dependents returns the direct and indirect dependents of the key, which are removed with it, up to
+// MaxDependencyDepth levels.
+//
+// Parameters:
+//   - key: The key of the cache item.
+//
+// Returns:
+//   - dependents: The keys of the dependents, the direct dependents first.
+func (cache *LRUCache[K, V]) Dependents(key K) (dependents []K) {
+	return cache.dependencies.dependentsOf(cache.normalizeKey(key))
+}
@@ -0,0 +1,226 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package dns_cache provides a caching DNS resolver on top of a sq_cache LRUCache, which honors the TTLs returned by
+// its upstream and caches NXDOMAIN answers. The default NetUpstream can't see the TTLs of the records, it returns a
+// fixed TTL, so the TTLs of the records are only honored with a TTL-aware Upstream.
+package dns_cache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// keyPrefix is the key prefix of the cached answers.
+	keyPrefix = "dns:"
+	// notFound is the cached value of a NXDOMAIN answer.
+	notFound = "NXDOMAIN"
+)
+
+// Upstream is an interface that defines the resolver, whose answers are cached. Implementations with access to the
+// DNS messages (e.g. based on golang.org/x/net/dns/dnsmessage) return the minimum TTL of the records.
+type Upstream interface {
+	LookupIPAddrTTL(ctx context.Context, host string) (addrs []net.IPAddr, ttl time.Duration, err error)
+}
+
+// NetUpstream is an Upstream backed by a net.Resolver. As net.Resolver doesn't expose the TTLs of the records, the
+// answers are cached for the fixed TTL, regardless of the TTLs of the records: a record with a shorter TTL is served
+// stale until the fixed TTL passes, e.g. after a DNS failover. Keep the TTL below the shortest record TTL of the
+// looked up hosts, or use a TTL-aware Upstream, which parses the DNS messages.
+type NetUpstream struct {
+	Resolver *net.Resolver
+	TTL      time.Duration
+}
+
+// LookupIPAddrTTL looks up the IP addresses of the host by the net.Resolver and returns them with the fixed TTL.
+func (upstream *NetUpstream) LookupIPAddrTTL(
+	ctx context.Context, host string,
+) (addrs []net.IPAddr, ttl time.Duration, err error) {
+	resolver := upstream.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	addrs, err = resolver.LookupIPAddr(ctx, host)
+
+	return addrs, upstream.TTL, err
+}
+
+// Resolver is a caching DNS resolver with the lookup methods of net.Resolver. The answers are cached for the TTL
+// returned by the upstream, capped by MaxTTL. NXDOMAIN answers are cached for NegativeTTL.
+type Resolver struct {
+	cache    *sq_cache.LRUCache[string, []byte]
+	upstream Upstream
+
+	// MaxTTL caps the TTLs of the records, zero means no cap.
+	MaxTTL time.Duration
+	// NegativeTTL is the time a NXDOMAIN answer is cached, zero means NXDOMAIN answers are not cached.
+	NegativeTTL time.Duration
+}
+
+// NewResolver creates a Resolver, which caches the answers of the specified upstream in the specified cache. The
+// default upstream doesn't know the TTLs of the records and caches every answer for one minute, see NetUpstream.
+//
+// Parameters:
+//   - cache: The cache to store the answers in.
+//   - upstream: The resolver, whose answers are cached. A NetUpstream with a fixed TTL of one minute is used if nil,
+//     which ignores the TTLs of the records.
+//
+// Returns:
+//   - resolver: The created Resolver object.
+//
+// Example Usage:
+//
+//	resolver := dns_cache.NewResolver(cache, nil)
+//	addrs, err := resolver.LookupHost(ctx, "example.com")
+//	if err != nil {
+//	    panic(err)
+//	}
+func NewResolver(cache *sq_cache.LRUCache[string, []byte], upstream Upstream) (resolver *Resolver) {
+	if upstream == nil {
+		upstream = &NetUpstream{TTL: time.Minute}
+	}
+
+	return &Resolver{
+		cache:    cache,
+		upstream: upstream,
+
+		MaxTTL:      time.Hour,
+		NegativeTTL: time.Second * 30,
+	}
+}
+
+// LookupIPAddr looks up the IP addresses of the host, like net.Resolver.LookupIPAddr.
+func (resolver *Resolver) LookupIPAddr(ctx context.Context, host string) (addrs []net.IPAddr, err error) {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return []net.IPAddr{{IP: ip.AsSlice(), Zone: ip.Zone()}}, nil
+	}
+
+	key := keyPrefix + strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if value, err := resolver.cache.Get(key); err == nil && value != nil {
+		if string(value) == notFound {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return decodeAddrs(value), nil
+	}
+
+	addrs, ttl, err := resolver.upstream.LookupIPAddrTTL(ctx, host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound && resolver.NegativeTTL > 0 {
+			_, _ = resolver.cache.SetWithTTL(key, []byte(notFound), resolver.NegativeTTL)
+		}
+		return nil, err
+	}
+
+	if resolver.MaxTTL > 0 {
+		ttl = min(ttl, resolver.MaxTTL)
+	}
+	if ttl > 0 && len(addrs) > 0 {
+		_, _ = resolver.cache.SetWithTTL(key, encodeAddrs(addrs), ttl)
+	}
+
+	return addrs, nil
+}
+
+// LookupHost looks up the addresses of the host, like net.Resolver.LookupHost.
+func (resolver *Resolver) LookupHost(ctx context.Context, host string) (addrs []string, err error) {
+	ipAddrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs = make([]string, 0, len(ipAddrs))
+	for _, ipAddr := range ipAddrs {
+		addrs = append(addrs, ipAddr.String())
+	}
+
+	return addrs, nil
+}
+
+// LookupIP looks up the IP addresses of the host for the network ("ip", "ip4" or "ip6"), like
+// net.Resolver.LookupIP.
+func (resolver *Resolver) LookupIP(ctx context.Context, network, host string) (ips []net.IP, err error) {
+	switch network {
+	case "ip", "ip4", "ip6":
+	default:
+		return nil, net.UnknownNetworkError(network)
+	}
+
+	ipAddrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ipAddr := range ipAddrs {
+		isIPv4 := ipAddr.IP.To4() != nil
+		if network == "ip4" && !isIPv4 || network == "ip6" && isIPv4 {
+			continue
+		}
+		ips = append(ips, ipAddr.IP)
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no suitable address found", Name: host, IsNotFound: true}
+	}
+
+	return ips, nil
+}
+
+// encodeAddrs encodes the IP addresses as newline separated list.
+func encodeAddrs(addrs []net.IPAddr) (value []byte) {
+	parts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		parts = append(parts, addr.String())
+	}
+
+	return []byte(strings.Join(parts, "\n"))
+}
+
+// decodeAddrs decodes a newline separated list of IP addresses.
+func decodeAddrs(value []byte) (addrs []net.IPAddr) {
+	for _, part := range strings.Split(string(value), "\n") {
+		ip, err := netip.ParseAddr(part)
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, net.IPAddr{IP: ip.AsSlice(), Zone: ip.Zone()})
+	}
+
+	return addrs
+}
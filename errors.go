@@ -0,0 +1,89 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+)
+
+var (
+	// ErrClosed is returned by the methods of a closed cache. Close is final, a closed cache can't be started again.
+	ErrClosed = errors.New("cache is closed")
+
+	// ErrKeyNotFound is returned when the key doesn't exist in the cache, by methods without a found result.
+	ErrKeyNotFound = errors.New("cache key not found")
+
+	// ErrNotModified is returned by GetIfChanged when the cache item wasn't changed since the specified version.
+	ErrNotModified = errors.New("cache item not modified")
+
+	// ErrReadOnly is returned when a frozen cache is modified.
+	ErrReadOnly = errors.New("cache is read-only")
+
+	// ErrCacheFull is returned when a new key is added to a cache at its capacity with the NoEviction policy.
+	ErrCacheFull = errors.New("cache is full")
+
+	// ErrTTLTooShort is returned when a positive TTL (time to live) is shorter than MinTTL.
+	ErrTTLTooShort = errors.New("cache ttl is shorter than the minimum ttl")
+
+	// ErrValueTooLarge is returned when a value exceeds the configured maximum value size.
+	ErrValueTooLarge = errors.New("cache value is too large")
+
+	// ErrLoaderNotConfigured is returned when a load is requested, but no loader is configured.
+	ErrLoaderNotConfigured = errors.New("cache loader is not configured")
+
+	// ErrLoaderCircuitOpen is returned when the loads of a key failed too often and the circuit of the key is open.
+	ErrLoaderCircuitOpen = errors.New("cache loader circuit is open")
+
+	// ErrLoaderBusy is returned when the in-flight load of a key is already shared by MaxLoadsPerKey calls.
+	ErrLoaderBusy = errors.New("cache loader is busy loading the key")
+
+	// ErrLoaderKeyNotFound is returned when the bulk loader didn't return a value for a requested key.
+	ErrLoaderKeyNotFound = errors.New("cache loader didn't return a value for the key")
+
+	// ErrKeyNotInTransaction is returned by the view of a transaction of Update for a key, which isn't part of it.
+	ErrKeyNotInTransaction = errors.New("cache key is not part of the transaction")
+
+	// ErrDependencyCycle is returned by DependOn when the declared dependency would create a cycle.
+	ErrDependencyCycle = errors.New("cache dependency would create a cycle")
+
+	// ErrCorruptedEntry is returned when the checksum of a cache item doesn't match its value, see ChecksumOn. The cache
+	// item is evicted.
+	ErrCorruptedEntry = errors.New("cache entry is corrupted")
+
+	// ErrSnapshotChecksum is returned when the checksum of a snapshot doesn't match its cache items, e.g. if the snapshot
+	// was truncated or corrupted in the store.
+	ErrSnapshotChecksum = errors.New("cache snapshot checksum mismatch")
+
+	// ErrUnsupportedFormat is returned when a snapshot or a write-ahead log segment was written in a format version or
+	// with a codec, which this version of the package can't read, e.g. by a newer version of the package.
+	ErrUnsupportedFormat = errors.New("cache dump format is not supported")
+)
@@ -0,0 +1,103 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"strings"
+)
+
+// PrefixBucket returns a bucket function for the FairEviction policy, which buckets the keys by their prefix up to and
+// including the first separator, e.g. the tenant of "tenant-a:user:42" with the separator ":". Keys without the
+// separator share the empty bucket.
+//
+// Parameters:
+//   - separator: The separator ending the prefix of the keys.
+//
+// Returns:
+//   - bucket: The bucket function of the keys.
+//
+// Example Usage:
+//
+//	cache, err := sq_cache.NewLRUCache(ctx, &sq_cache.Config[string, []byte]{
+//	    EvictionPolicy: sq_cache.FairEviction,
+//	    FairnessBucket: sq_cache.PrefixBucket[string]("/"),
+//	})
+func PrefixBucket[K IKey](separator string) (bucket func(key K) K) {
+	return func(key K) K {
+		index := strings.Index(string(key), separator)
+		if index < 0 {
+			return ""
+		}
+
+		return key[:index+len(separator)]
+	}
+}
+
+// countBucket adds the specified delta to the number of cache items of the bucket of a key, if the shard evicts by the
+// FairEviction policy. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) countBucket(key K, delta int64) {
+	if shard.bucketCounts == nil {
+		return
+	}
+
+	bucket := shard.bucketOf(key)
+	if shard.bucketCounts[bucket] += delta; shard.bucketCounts[bucket] <= 0 {
+		delete(shard.bucketCounts, bucket)
+	}
+}
+
+// removeItemsFair removes up to the specified number of items from the shard by the FairEviction policy: each eviction
+// evicts the least recently used item of the bucket with the most items in the shard, but never the most recently used
+// item of the shard.
+func (shard *lruCacheShard[K, V]) removeItemsFair(count int64) (evictCount int64) {
+	for ; evictCount < count && shard.list.Len() > 1; evictCount++ {
+		var largest K
+		largestCount := int64(-1)
+		for bucket, bucketCount := range shard.bucketCounts {
+			if bucketCount > largestCount {
+				largest, largestCount = bucket, bucketCount
+			}
+		}
+
+		victim := shard.list.Back()
+		for item, front := victim, shard.list.Front(); item != nil && item != front; item = item.Prev() {
+			if shard.bucketOf(item.Key) == largest {
+				victim = item
+				break
+			}
+		}
+
+		shard.removeItem(victim, Evicted)
+	}
+
+	return evictCount
+}
@@ -0,0 +1,111 @@
+//go:build sq_cache_faults
+
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Faults describes the faults injected into all caches of the process by InjectFaults, e.g. to verify the behavior of
+// an application when the cache misbehaves. The fault injection is only compiled in with the "sq_cache_faults" build
+// tag (go test -tags sq_cache_faults), without it the hooks are no-ops.
+type Faults struct {
+	// LockDelay delays every measured acquisition of a shard lock, e.g. to simulate lock contention.
+	LockDelay time.Duration
+	// EvictError fails the Sets of new keys, which would evict cache items, instead of evicting.
+	EvictError error
+	// LoadError fails the loads instead of calling the loader, counted as load failures.
+	LoadError error
+	// ClockSkew shifts the clock of the expiry, e.g. a positive skew expires the cache items early.
+	ClockSkew time.Duration
+}
+
+// faults holds the injected faults, nil if no faults are injected.
+var faults atomic.Pointer[Faults]
+
+// InjectFaults injects the specified faults into all caches of the process, replacing the previously injected faults.
+// The faults apply to the operations started after the injection.
+//
+// Parameters:
+//   - injected: The faults to inject.
+//
+// Returns:
+//   - restore: The function restoring the previously injected faults.
+//
+// Example Usage:
+//
+//	restore := sq_cache.InjectFaults(sq_cache.Faults{LoadError: errors.New("backend down")})
+//	defer restore()
+func InjectFaults(injected Faults) (restore func()) {
+	previous := faults.Swap(&injected)
+
+	return func() {
+		faults.Store(previous)
+	}
+}
+
+// timeNow returns the current time of the expiry, shifted by the injected clock skew.
+func timeNow() (now time.Time) {
+	if injected := faults.Load(); injected != nil {
+		return time.Now().Add(injected.ClockSkew)
+	}
+
+	return time.Now()
+}
+
+// faultLockDelay sleeps for the injected lock delay.
+func faultLockDelay() {
+	if injected := faults.Load(); injected != nil && injected.LockDelay > 0 {
+		time.Sleep(injected.LockDelay)
+	}
+}
+
+// faultEvictError returns the injected eviction error, nil if none was injected.
+func faultEvictError() (err error) {
+	if injected := faults.Load(); injected != nil {
+		return injected.EvictError
+	}
+
+	return nil
+}
+
+// faultLoadError returns the injected load error, nil if none was injected.
+func faultLoadError() (err error) {
+	if injected := faults.Load(); injected != nil {
+		return injected.LoadError
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// The snapshots and the write-ahead log segments share a versioned binary format, which doesn't depend on the layout of
+// any Go struct, so the dumps written by older versions of the package can still be read after an upgrade:
+//
+//	header:  magic ("SQS" or "SQW") | version (1 byte) | codec id (1 byte, since snapshot version 3 and log version 2)
+//	frame:   payload length (uvarint) | payload | CRC-32 (IEEE) of the payload (4 bytes, little endian)
+//	record:  operation (1 byte) | key length (uvarint) | key
+//	         set only: value length (uvarint) | value | expiry time in Unix nanoseconds (varint, zero if none) |
+//	                   metadata flag (1 byte) | created at (varint) | source | tag count (uvarint) | tags | cost (varint)
+//	trailer: end operation (1 byte) | entry count (uvarint) | checksum length (uvarint) | SHA-256 checksum
+//
+// A snapshot is a header, a record frame per entry and a trailer frame, a log segment is a header and a record frame
+// per write. The readers reject unknown versions and codecs with ErrUnsupportedFormat instead of misreading them, new
+// fields are only appended to the end of a payload, so older payloads simply end before them.
+
+// errFrameChecksum is returned by readFrame when the checksum of a frame doesn't match its payload.
+var errFrameChecksum = errors.New("record checksum mismatch")
+
+// formatCodecBinary is the codec id of the binary record encoding above, the only codec so far.
+const formatCodecBinary byte = 1
+
+// readFormatHeader reads the header of a dump with the specified magic and returns its version. The codec id is read
+// and validated for the versions starting at codecVersion.
+func readFormatHeader(r *bufio.Reader, magic string, maxVersion, codecVersion byte) (version byte, err error) {
+	header := make([]byte, len(magic)+1)
+	if _, err = io.ReadFull(r, header); err != nil || string(header[:len(magic)]) != magic {
+		return 0, errors.New("invalid header")
+	}
+
+	version = header[len(magic)]
+	if version == 0 || version > maxVersion {
+		return 0, fmt.Errorf("%w: version %d", ErrUnsupportedFormat, version)
+	}
+
+	if version >= codecVersion {
+		codec, err := r.ReadByte()
+		if err != nil {
+			return 0, errors.New("invalid header")
+		}
+		if codec != formatCodecBinary {
+			return 0, fmt.Errorf("%w: codec %d", ErrUnsupportedFormat, codec)
+		}
+	}
+
+	return version, nil
+}
+
+// appendFormatHeader appends the header of a dump with the specified magic and version, and the binary codec id.
+func appendFormatHeader(buf []byte, magic string, version byte) []byte {
+	buf = append(buf, magic...)
+
+	return append(buf, version, formatCodecBinary)
+}
+
+// appendFrame appends the specified payload to the buffer, framed by its length and its CRC-32 checksum.
+func appendFrame(buf []byte, payload []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	buf = append(buf, payload...)
+
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(payload))
+}
+
+// readFrame reads the payload of the next frame. It returns io.EOF at the end of the dump, and an error if the frame is
+// truncated or corrupted, e.g. by a crash while it was written.
+func readFrame(r *bufio.Reader) (payload []byte, err error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The length of a corrupted frame is arbitrary, so the frame grows with the read bytes instead of being allocated
+	// upfront.
+	if length > math.MaxInt32 {
+		return nil, errors.New("record is too large")
+	}
+	var buf bytes.Buffer
+	if _, err = io.CopyN(&buf, r, int64(length)+4); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	frame := buf.Bytes()
+	payload = frame[:length]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(frame[length:]) {
+		return nil, errFrameChecksum
+	}
+
+	return payload, nil
+}
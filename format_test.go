@@ -0,0 +1,170 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// The golden files in testdata were written by the package versions that wrote the respective format version, they
+// must never be regenerated. Each holds the cache items "never", which never expires, "later", which expires in 2125,
+// and "meta", which carries metadata; the write-ahead log segments also hold the Set and the Remove of "removed".
+
+// goldenExpiry is the expiry time of the cache items "later" and "meta" of the golden files.
+var goldenExpiry = time.Date(2125, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// checkGoldenEntries checks that the cache holds the cache items of the golden files.
+func checkGoldenEntries(t *testing.T, cache *LRUCache[string, []byte]) {
+	t.Helper()
+
+	want := map[string]struct {
+		value string
+		ttl   time.Time
+	}{
+		"never": {value: "expires"},
+		"later": {value: "expires later", ttl: goldenExpiry},
+		"meta":  {value: "with metadata", ttl: goldenExpiry},
+	}
+	for key, want := range want {
+		entry, err := cache.GetEntry(key)
+		if err != nil {
+			t.Errorf("GetEntry(%q) error = %v", key, err)
+			continue
+		}
+		if string(entry.Value) != want.value {
+			t.Errorf("GetEntry(%q).Value = %q, want %q", key, entry.Value, want.value)
+		}
+		// The expiry times were computed from the time the golden files were written, so only the day is compared.
+		if entry.TTL.IsZero() != want.ttl.IsZero() || entry.TTL.Sub(want.ttl).Abs() > time.Hour*24 {
+			t.Errorf("GetEntry(%q).TTL = %v, want %v", key, entry.TTL, want.ttl)
+		}
+	}
+
+	entry, err := cache.GetEntry("meta")
+	if err != nil {
+		t.Fatalf("GetEntry(%q) error = %v", "meta", err)
+	}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !entry.Meta.CreatedAt.Equal(createdAt) || entry.Meta.Source != "golden" ||
+		!maps.Equal(entry.Meta.Tags, map[string]string{"tier": "gold"}) {
+		t.Errorf("GetEntry(%q).Meta = %+v, want the golden metadata", "meta", entry.Meta)
+	}
+
+	if found, err := cache.Contains("removed"); err != nil || found {
+		t.Errorf("Contains(%q) = %v, %v, want false, nil", "removed", found, err)
+	}
+}
+
+func TestReadSnapshotGoldenFiles(t *testing.T) {
+	for _, version := range []string{"v1", "v2", "v3"} {
+		t.Run(version, func(t *testing.T) {
+			snapshot, err := os.ReadFile(filepath.Join("testdata", "snapshot-"+version+".snap"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cache := newTestCache(t, &Config[string, []byte]{})
+			if count, err := cache.ReadSnapshot(context.Background(), bytes.NewReader(snapshot)); err != nil || count != 3 {
+				t.Fatalf("ReadSnapshot() = %d, %v, want 3, nil", count, err)
+			}
+			checkGoldenEntries(t, cache)
+		})
+	}
+}
+
+func TestReplayWALGoldenFiles(t *testing.T) {
+	for _, version := range []string{"v1", "v2"} {
+		t.Run(version, func(t *testing.T) {
+			cache := newTestCache(t, &Config[string, []byte]{})
+			count, err := cache.replayWALSegment(filepath.Join("testdata", "wal-"+version+".wal"), time.Now())
+			if err != nil || count != 5 {
+				t.Fatalf("replayWALSegment() = %d, %v, want 5, nil", count, err)
+			}
+			checkGoldenEntries(t, cache)
+		})
+	}
+}
+
+func TestUnsupportedFormats(t *testing.T) {
+	snapshot, err := os.ReadFile(filepath.Join("testdata", "snapshot-v3.snap"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	segment, err := os.ReadFile(filepath.Join("testdata", "wal-v2.wal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The version byte follows the magic, the codec id follows the version.
+	headers := map[string]func(dump []byte, magic string){
+		"version 0": func(dump []byte, magic string) {
+			dump[len(magic)] = 0
+		},
+		"unknown version": func(dump []byte, magic string) {
+			dump[len(magic)]++
+		},
+		"unknown codec": func(dump []byte, magic string) {
+			dump[len(magic)+1] = formatCodecBinary + 1
+		},
+	}
+
+	for name, modify := range headers {
+		t.Run(name, func(t *testing.T) {
+			dump := bytes.Clone(snapshot)
+			modify(dump, snapshotMagic)
+
+			cache := newTestCache(t, &Config[string, []byte]{})
+			if count, err := cache.ReadSnapshot(context.Background(), bytes.NewReader(dump)); !errors.Is(err,
+				ErrUnsupportedFormat) || count != 0 {
+				t.Errorf("ReadSnapshot() = %d, %v, want 0, ErrUnsupportedFormat", count, err)
+			}
+
+			dump = bytes.Clone(segment)
+			modify(dump, walMagic)
+			path := filepath.Join(t.TempDir(), walSegmentName(1))
+			if err := os.WriteFile(path, dump, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if count, err := cache.replayWALSegment(path, time.Now()); !errors.Is(err, ErrUnsupportedFormat) ||
+				count != 0 {
+				t.Errorf("replayWALSegment() = %d, %v, want 0, ErrUnsupportedFormat", count, err)
+			}
+		})
+	}
+}
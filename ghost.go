@@ -0,0 +1,153 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// ghostStep is the capacity step of the estimates of the ghost list, as fraction of the item capacity of the cache.
+const ghostStep = 0.1
+
+// ghostList represents a thread-safe list of the hashes of the keys recently evicted by capacity pressure, shared by
+// all shards of the cache. It remembers the order of the evictions, but not the cache items, so it costs a few bytes
+// per key. A miss of a key in the ghost list would have been a hit, if the cache had room for the cache items evicted
+// after it; the misses are counted by the additional capacity they needed, in steps of 10% of the capacity.
+type ghostList struct {
+	sync.Mutex
+
+	// ratio is the capacity of the ghost list as fraction of the item capacity of the cache (maxItems).
+	ratio    float64
+	maxItems *atomic.Int64
+	seed     maphash.Seed
+
+	// ring holds the hashes by their eviction sequence modulo its length, evictions the sequence of the latest eviction
+	// and sequences the sequence of every hash in the ring.
+	ring      []uint64
+	evictions uint64
+	sequences map[uint64]uint64
+
+	// saved counts the misses by the number of capacity steps they needed, minus one.
+	saved []int64
+}
+
+// newGhostList initializes and returns a new ghostList, which remembers the specified fraction of the item capacity of
+// the cache, with estimates up to that fraction.
+func newGhostList(ratio float64, maxItems *atomic.Int64) (ghosts *ghostList) {
+	return &ghostList{
+		ratio:    ratio,
+		maxItems: maxItems,
+		seed:     maphash.MakeSeed(),
+
+		ring:      make([]uint64, max(int(ratio*float64(maxItems.Load())), 1)),
+		sequences: make(map[uint64]uint64),
+
+		saved: make([]int64, int(math.Ceil(ratio/ghostStep-1e-9))),
+	}
+}
+
+// evict records the eviction of the key.
+func (ghosts *ghostList) evict(key string) {
+	hash := maphash.String(ghosts.seed, key)
+
+	ghosts.Lock()
+	defer ghosts.Unlock()
+
+	ghosts.evictions++
+	slot := ghosts.evictions % uint64(len(ghosts.ring))
+	if ghosts.evictions > uint64(len(ghosts.ring)) {
+		overwritten := ghosts.ring[slot]
+		if ghosts.sequences[overwritten] == ghosts.evictions-uint64(len(ghosts.ring)) {
+			delete(ghosts.sequences, overwritten)
+		}
+	}
+	ghosts.ring[slot] = hash
+	ghosts.sequences[hash] = ghosts.evictions
+}
+
+// miss records the miss of the key, and reports whether it was in the ghost list. The key is forgotten, as it is
+// usually loaded and added again.
+func (ghosts *ghostList) miss(key string) (found bool) {
+	hash := maphash.String(ghosts.seed, key)
+
+	ghosts.Lock()
+	defer ghosts.Unlock()
+
+	sequence, found := ghosts.sequences[hash]
+	if !found {
+		return false
+	}
+	delete(ghosts.sequences, hash)
+
+	// Under LRU eviction, the key would still be cached with room for itself and the cache items evicted after it.
+	needed := float64(ghosts.evictions-sequence+1) / float64(max(ghosts.maxItems.Load(), 1))
+	if step := int(math.Ceil(needed/ghostStep-1e-9)) - 1; step < len(ghosts.saved) {
+		ghosts.saved[max(step, 0)]++
+	}
+
+	return true
+}
+
+// missesSaved returns the number of misses, which would have been hits with 10%, 20%, ... more capacity; every
+// element includes the misses of the smaller steps.
+func (ghosts *ghostList) missesSaved() (saved []int64) {
+	ghosts.Lock()
+	defer ghosts.Unlock()
+
+	saved = make([]int64, len(ghosts.saved))
+	var sum int64
+	for step, n := range ghosts.saved {
+		sum += n
+		saved[step] = sum
+	}
+
+	return saved
+}
+
+// reset resets the counted misses to zero, the remembered keys are kept.
+func (ghosts *ghostList) reset() {
+	ghosts.Lock()
+	defer ghosts.Unlock()
+
+	clear(ghosts.saved)
+}
+
+// ghostMiss records the miss of the key in the ghost list, and counts it by the GhostHit counter, if the key was
+// recently evicted.
+func (cache *LRUCache[K, V]) ghostMiss(key K) {
+	if cache.ghosts.miss(string(key)) && cache.telemetryOn {
+		cache.telemetry.incrementCounter(GhostHit)
+	}
+}
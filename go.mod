@@ -5,4 +5,5 @@ go 1.23.5
 require (
     github.com/rommarius/sq_config_combine v1.0.0
     github.com/rommarius/generic_syncpool v1.0.0
+    go.uber.org/goleak v1.3.0
 )
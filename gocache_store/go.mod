@@ -0,0 +1,16 @@
+module github.com/rommarius/sq_cache/gocache_store
+
+go 1.25
+
+require (
+	github.com/eko/gocache/lib/v4 v4.4.0
+	github.com/rommarius/sq_cache v0.0.0
+)
+
+require (
+	github.com/rommarius/generic_syncpool v1.0.0 // indirect
+	github.com/rommarius/sq_config_combine v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20251209150349-8475f28825e9 // indirect
+)
+
+replace github.com/rommarius/sq_cache => ../
@@ -0,0 +1,242 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package gocache_store provides a store of "github.com/eko/gocache/lib/v4" backed by a sq_cache LRUCache, so that
+// sq_cache can be used with the chain, loadable and metric caches of gocache.
+package gocache_store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// StoreType is the type of the store, as returned by GetType.
+	StoreType = "sq_cache"
+)
+
+// ErrValueType is returned when a value of an unsupported type is set.
+var ErrValueType = errors.New("gocache_store: value must be of type []byte or string")
+
+// ErrKeyType is returned when a key of an unsupported type is used.
+var ErrKeyType = errors.New("gocache_store: key must be of type string or fmt.Stringer")
+
+// Store implements the store.StoreInterface of gocache, backed by a sq_cache LRUCache.
+// The tags of the cache items are kept in an index of the store, so that the cache items can be invalidated by tag.
+type Store struct {
+	cache *sq_cache.LRUCache[string, []byte]
+
+	options *store.Options
+
+	tagsMutex sync.Mutex
+	tags      map[string]map[string]struct{}
+}
+
+// NewStore creates a Store backed by the specified cache. The options are used as defaults for Set.
+//
+// Parameters:
+//   - cache: The cache backing the store.
+//   - options: The default options for Set, e.g. store.WithExpiration.
+//
+// Returns:
+//   - s: The created Store object.
+//
+// Example Usage:
+//
+//	cacheManager := cache.New[[]byte](gocache_store.NewStore(lruCache, store.WithExpiration(time.Minute)))
+func NewStore(cache *sq_cache.LRUCache[string, []byte], options ...store.Option) (s *Store) {
+	return &Store{
+		cache:   cache,
+		options: store.ApplyOptions(options...),
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+// keyString converts a gocache key to a cache key.
+func keyString(key any) (k string, err error) {
+	switch k := key.(type) {
+	case string:
+		return k, nil
+	case fmt.Stringer:
+		return k.String(), nil
+	default:
+		return "", ErrKeyType
+	}
+}
+
+// Get returns the value of the specified key, or a store.NotFound error if the key doesn't exist.
+func (s *Store) Get(_ context.Context, key any) (value any, err error) {
+	value, _, err = s.GetWithTTL(context.Background(), key)
+
+	return value, err
+}
+
+// GetWithTTL returns the value and the remaining TTL (time to live) of the specified key, or a store.NotFound error if
+// the key doesn't exist. The remaining TTL is zero, if the cache item never expires.
+func (s *Store) GetWithTTL(_ context.Context, key any) (value any, ttl time.Duration, err error) {
+	k, err := keyString(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v, ttl, found, err := s.cache.GetWithTTL(k)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !found {
+		return nil, 0, store.NotFoundWithCause(errors.New("key not found in sq_cache"))
+	}
+
+	if ttl == sq_cache.NoExpiry {
+		ttl = 0
+	}
+
+	return v, ttl, nil
+}
+
+// Set sets the value of the specified key. The expiration and tags of the options are applied, falling back to the
+// default options of the store.
+func (s *Store) Set(_ context.Context, key any, value any, options ...store.Option) (err error) {
+	k, err := keyString(key)
+	if err != nil {
+		return err
+	}
+
+	var v []byte
+	switch value := value.(type) {
+	case []byte:
+		v = value
+	case string:
+		v = []byte(value)
+	default:
+		return ErrValueType
+	}
+
+	opts := store.ApplyOptionsWithDefault(s.options, options...)
+
+	expiration := opts.Expiration
+	if expiration == 0 {
+		expiration = sq_cache.NoExpiry
+	}
+
+	if _, err = s.cache.SetWithTTL(k, v, expiration); err != nil {
+		return err
+	}
+
+	s.setTags(k, opts.Tags)
+
+	return nil
+}
+
+// setTags adds the specified key to the index of the specified tags.
+func (s *Store) setTags(key string, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	s.tagsMutex.Lock()
+	defer s.tagsMutex.Unlock()
+
+	for _, tag := range tags {
+		keys, found := s.tags[tag]
+		if !found {
+			keys = make(map[string]struct{})
+			s.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// Delete removes the specified key.
+func (s *Store) Delete(_ context.Context, key any) (err error) {
+	k, err := keyString(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cache.Remove(k)
+
+	return err
+}
+
+// Invalidate removes all keys of the tags of the options.
+func (s *Store) Invalidate(_ context.Context, options ...store.InvalidateOption) (err error) {
+	opts := store.ApplyInvalidateOptions(options...)
+
+	s.tagsMutex.Lock()
+	var keys []string
+	for _, tag := range opts.Tags {
+		for key := range s.tags[tag] {
+			keys = append(keys, key)
+		}
+		delete(s.tags, tag)
+	}
+	s.tagsMutex.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if _, err := s.cache.Remove(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Clear removes all keys. The cache is stopped for the purge and started again afterwards.
+func (s *Store) Clear(_ context.Context) (err error) {
+	s.cache.Stop()
+	defer s.cache.Start()
+
+	if err = s.cache.Purge(); err != nil {
+		return err
+	}
+
+	s.tagsMutex.Lock()
+	s.tags = make(map[string]map[string]struct{})
+	s.tagsMutex.Unlock()
+
+	return nil
+}
+
+// GetType returns the type of the store.
+func (s *Store) GetType() (storeType string) {
+	return StoreType
+}
+
+// Ensure Store implements the store.StoreInterface.
+var _ store.StoreInterface = (*Store)(nil)
@@ -0,0 +1,277 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// GolangLRUCache mirrors the simplelru.LRUCache interface of "github.com/hashicorp/golang-lru/v2", which is
+// implemented by GolangLRUAdapter, without depending on golang-lru.
+type GolangLRUCache[K comparable, V any] interface {
+	Add(key K, value V) (evicted bool)
+	Get(key K) (value V, ok bool)
+	Contains(key K) (ok bool)
+	Peek(key K) (value V, ok bool)
+	Remove(key K) (present bool)
+	RemoveOldest() (key K, value V, ok bool)
+	GetOldest() (key K, value V, ok bool)
+	Keys() (keys []K)
+	Values() (values []V)
+	Len() int
+	Cap() int
+	Purge()
+	Resize(size int) (evicted int)
+}
+
+// GolangLRUAdapter implements the GolangLRUCache interface.
+var _ GolangLRUCache[string, []byte] = (*GolangLRUAdapter[string, []byte])(nil)
+
+// GolangLRUAdapter adapts a LRUCache to the simplelru.LRUCache interface of "github.com/hashicorp/golang-lru/v2", so
+// that code written against golang-lru can switch to sq_cache without changing its call sites.
+// As the interface doesn't return errors, the operations of a stopped or closed cache behave like the operations of an
+// empty cache. The operations are running operations of the cache, which Close waits for, like its own methods.
+type GolangLRUAdapter[K IKey, V IValue] struct {
+	cache *LRUCache[K, V]
+}
+
+// NewGolangLRUAdapter creates a GolangLRUAdapter backed by the specified cache.
+//
+// Parameters:
+//   - cache: The cache backing the adapter.
+//
+// Returns:
+//   - adapter: The created GolangLRUAdapter object.
+//
+// Example Usage:
+//
+//	var lru simplelru.LRUCache[string, []byte] = sq_cache.NewGolangLRUAdapter(cache)
+func NewGolangLRUAdapter[K IKey, V IValue](cache *LRUCache[K, V]) (adapter *GolangLRUAdapter[K, V]) {
+	return &GolangLRUAdapter[K, V]{
+		cache: cache,
+	}
+}
+
+// isStarted reports whether the backing cache accepts operations.
+func (adapter *GolangLRUAdapter[K, V]) isStarted() bool {
+	return adapter.cache.Status() == Started
+}
+
+// Add adds a value to the cache, returns true if an eviction occurred.
+func (adapter *GolangLRUAdapter[K, V]) Add(key K, value V) (evicted bool) {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return false
+	}
+
+	key = adapter.cache.normalizeKey(key)
+	_, evicted, _ = adapter.cache.set(key, value, adapter.cache.expiresAt(key, value, 0), nil)
+
+	return evicted
+}
+
+// Get returns the value of a key and updates the recent-ness of the cache item.
+func (adapter *GolangLRUAdapter[K, V]) Get(key K) (value V, ok bool) {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return value, false
+	}
+
+	key = adapter.cache.normalizeKey(key)
+	shardId := adapter.cache.generateShardId(key, adapter.cache.maxShards)
+
+	value, ok, _ = adapter.cache.get(shardId, key)
+
+	return value, ok
+}
+
+// Contains checks if a key exists in the cache without updating the recent-ness of the cache item.
+func (adapter *GolangLRUAdapter[K, V]) Contains(key K) (ok bool) {
+	ok, _ = adapter.cache.Contains(key)
+
+	return ok
+}
+
+// Peek returns the value of a key without updating the recent-ness of the cache item.
+func (adapter *GolangLRUAdapter[K, V]) Peek(key K) (value V, ok bool) {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return value, false
+	}
+
+	key = adapter.cache.normalizeKey(key)
+	shardId := adapter.cache.generateShardId(key, adapter.cache.maxShards)
+	adapter.cache.readYourWrites(shardId)
+
+	adapter.cache.shards[shardId].RLockMeasured()
+	defer adapter.cache.shards[shardId].RUnlock()
+
+	value, ok = adapter.cache.shards[shardId].Peek(key)
+
+	return adapter.cache.readValue(value), ok
+}
+
+// Remove removes a key from the cache, returns true if the key was contained.
+func (adapter *GolangLRUAdapter[K, V]) Remove(key K) (present bool) {
+	present, _ = adapter.cache.Remove(key)
+
+	return present
+}
+
+// RemoveOldest removes the oldest (least recently used) cache item from the cache.
+func (adapter *GolangLRUAdapter[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return key, value, false
+	}
+
+	items := adapter.cache.removeOldest(1)
+	if len(items) == 0 {
+		return key, value, false
+	}
+
+	return items[0].Key, adapter.cache.readValue(items[0].Value), true
+}
+
+// GetOldest returns the oldest (least recently used) cache item of the cache.
+func (adapter *GolangLRUAdapter[K, V]) GetOldest() (key K, value V, ok bool) {
+	key, value, ok, _ = adapter.cache.Oldest()
+
+	return key, value, ok
+}
+
+// Keys returns the keys of the unexpired cache items, from the oldest to the newest.
+func (adapter *GolangLRUAdapter[K, V]) Keys() (keys []K) {
+	items := adapter.items()
+
+	keys = make([]K, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.key)
+	}
+
+	return keys
+}
+
+// Values returns the values of the unexpired cache items, from the oldest to the newest. The values are copied like
+// the values returned by Get, if CopyOnRead is enabled.
+func (adapter *GolangLRUAdapter[K, V]) Values() (values []V) {
+	items := adapter.items()
+
+	values = make([]V, 0, len(items))
+	for _, item := range items {
+		values = append(values, adapter.cache.readValue(item.value))
+	}
+
+	return values
+}
+
+// adapterItem is a snapshot of a cache item, used to order the cache items across shards.
+type adapterItem[K IKey, V IValue] struct {
+	key        K
+	value      V
+	accessedAt int64
+}
+
+// items returns a snapshot of the unexpired cache items of all shards, ordered from the oldest to the newest. The
+// expired cache items, which weren't removed by the cleanup yet, are skipped, as Get and Peek don't find them either.
+func (adapter *GolangLRUAdapter[K, V]) items() (items []adapterItem[K, V]) {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return nil
+	}
+
+	now := timeNow()
+	for shardId, shard := range adapter.cache.shards {
+		adapter.cache.readYourWrites(int64(shardId))
+
+		shard.RLock()
+		for item := shard.Oldest(); item != nil; item = item.Prev() {
+			if item.expired(now) {
+				continue
+			}
+			items = append(items, adapterItem[K, V]{
+				key:        item.Key,
+				value:      item.Value,
+				accessedAt: item.accessedAt.Load(),
+			})
+		}
+		shard.RUnlock()
+	}
+
+	slices.SortFunc(items, func(a, b adapterItem[K, V]) int {
+		return cmp.Compare(a.accessedAt, b.accessedAt)
+	})
+
+	return items
+}
+
+// Len returns the number of cache items in the cache.
+func (adapter *GolangLRUAdapter[K, V]) Len() int {
+	return int(adapter.cache.Len())
+}
+
+// Cap returns the maximum number of items that can be stored in the cache.
+func (adapter *GolangLRUAdapter[K, V]) Cap() int {
+	return int(adapter.cache.MaxItems())
+}
+
+// Purge clears all cache items. Unlike LRUCache.Purge, the cache doesn't need to be stopped, as the shards are purged
+// one by one under their locks, and the status of the cache is left alone.
+func (adapter *GolangLRUAdapter[K, V]) Purge() {
+	adapter.cache.inFlight.Add(1)
+	defer adapter.cache.inFlight.Add(-1)
+
+	if !adapter.isStarted() {
+		return
+	}
+
+	_, _ = adapter.cache.purge(context.Background())
+}
+
+// Resize changes the maximum number of items that can be stored in the cache, returns the number of evicted items.
+func (adapter *GolangLRUAdapter[K, V]) Resize(size int) (evicted int) {
+	evicted, _ = adapter.cache.Resize(int64(size))
+
+	return evicted
+}
@@ -0,0 +1,211 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGolangLRUAdapterEvictionOrder(t *testing.T) {
+	lru := NewGolangLRUAdapter(newTestCache(t, &Config[string, []byte]{MaxShards: 1, MaxItems: 3}))
+
+	for _, key := range []string{"a", "b", "c"} {
+		if evicted := lru.Add(key, []byte(key)); evicted {
+			t.Fatalf("Add(%q) evicted = true, want false", key)
+		}
+	}
+	if _, ok := lru.Get("a"); !ok {
+		t.Fatal(`Get("a") ok = false, want true`)
+	}
+	if evicted := lru.Add("d", []byte("d")); !evicted {
+		t.Fatal(`Add("d") evicted = false, want true`)
+	}
+
+	if lru.Contains("b") {
+		t.Error(`Contains("b") = true, want the least recently used key evicted`)
+	}
+	if keys, want := lru.Keys(), []string{"c", "a", "d"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+	if key, _, ok := lru.GetOldest(); !ok || key != "c" {
+		t.Errorf("GetOldest() = %q, %t, want %q, true", key, ok, "c")
+	}
+}
+
+func TestGolangLRUAdapterPeek(t *testing.T) {
+	lru := NewGolangLRUAdapter(newTestCache(t, &Config[string, []byte]{MaxShards: 1, MaxItems: 2}))
+
+	lru.Add("a", []byte("1"))
+	lru.Add("b", []byte("2"))
+	if value, ok := lru.Peek("a"); !ok || string(value) != "1" {
+		t.Fatalf(`Peek("a") = %q, %t, want "1", true`, value, ok)
+	}
+	lru.Add("c", []byte("3"))
+
+	if lru.Contains("a") {
+		t.Error(`Contains("a") = true, want Peek not to update the recent-ness`)
+	}
+	if _, ok := lru.Peek("missing"); ok {
+		t.Error(`Peek("missing") ok = true, want false`)
+	}
+}
+
+func TestGolangLRUAdapterRemoveOldest(t *testing.T) {
+	lru := NewGolangLRUAdapter(newTestCache(t, nil))
+
+	if _, _, ok := lru.RemoveOldest(); ok {
+		t.Fatal("RemoveOldest() of an empty cache ok = true, want false")
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		lru.Add(key, []byte(key))
+	}
+	lru.Get("a")
+
+	for _, want := range []string{"b", "c", "a"} {
+		key, value, ok := lru.RemoveOldest()
+		if !ok || key != want || string(value) != want {
+			t.Fatalf("RemoveOldest() = %q, %q, %t, want %q, %q, true", key, value, ok, want, want)
+		}
+	}
+	if n := lru.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
+
+func TestGolangLRUAdapterResize(t *testing.T) {
+	lru := NewGolangLRUAdapter(newTestCache(t, nil))
+
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		lru.Add(key, []byte(key))
+	}
+
+	if evicted := lru.Resize(2); evicted != 3 {
+		t.Fatalf("Resize(2) = %d, want 3", evicted)
+	}
+	if n, c := lru.Len(), lru.Cap(); n != 2 || c != 2 {
+		t.Errorf("Len(), Cap() = %d, %d, want 2, 2", n, c)
+	}
+	if keys, want := lru.Keys(), []string{"d", "e"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+
+	if evicted := lru.Resize(10); evicted != 0 {
+		t.Errorf("Resize(10) = %d, want 0", evicted)
+	}
+	if c := lru.Cap(); c != 10 {
+		t.Errorf("Cap() = %d, want 10", c)
+	}
+}
+
+func TestGolangLRUAdapterPurge(t *testing.T) {
+	cache := newTestCache(t, nil)
+	lru := NewGolangLRUAdapter(cache)
+
+	for _, key := range []string{"a", "b", "c"} {
+		lru.Add(key, []byte(key))
+	}
+	lru.Purge()
+
+	if n := lru.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+	if keys := lru.Keys(); len(keys) != 0 {
+		t.Errorf("Keys() = %v, want none", keys)
+	}
+	if status := cache.Status(); status != Started {
+		t.Errorf("Status() = %v, want the cache to stay started", status)
+	}
+
+	cache.Stop()
+	lru.Add("d", []byte("d"))
+	lru.Purge()
+	if status := cache.Status(); status != Stopped {
+		t.Errorf("Status() = %v, want a stopped cache not to be started by Purge", status)
+	}
+}
+
+func TestGolangLRUAdapterSkipsExpired(t *testing.T) {
+	cache := newTestCache(t, &Config[string, []byte]{CleanupDisabled: true})
+	lru := NewGolangLRUAdapter(cache)
+
+	lru.Add("a", []byte("a"))
+	if _, err := cache.SetWithTTL("b", []byte("b"), time.Millisecond); err != nil {
+		t.Fatalf("SetWithTTL() error = %v", err)
+	}
+	time.Sleep(time.Millisecond * 5)
+
+	if _, ok := lru.Get("b"); ok {
+		t.Fatal(`Get("b") ok = true, want the expired cache item not found`)
+	}
+	if keys, want := lru.Keys(), []string{"a"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+	if values := lru.Values(); len(values) != 1 || string(values[0]) != "a" {
+		t.Errorf("Values() = %q, want [a]", values)
+	}
+}
+
+func TestGolangLRUAdapterCopyOnRead(t *testing.T) {
+	lru := NewGolangLRUAdapter(newTestCache(t, &Config[string, []byte]{CopyOnRead: true}))
+
+	lru.Add("a", []byte("a"))
+	lru.Values()[0][0] = 'x'
+
+	if value, _ := lru.Get("a"); string(value) != "a" {
+		t.Errorf(`Get("a") = %q, want "a" unchanged by modifying the result of Values`, value)
+	}
+}
+
+func TestGolangLRUAdapterClosed(t *testing.T) {
+	cache := newTestCache(t, nil)
+	lru := NewGolangLRUAdapter(cache)
+
+	lru.Add("a", []byte("a"))
+	cache.Close()
+
+	if lru.Add("b", []byte("b")) {
+		t.Error("Add() of a closed cache evicted = true, want false")
+	}
+	if _, ok := lru.Get("a"); ok {
+		t.Error(`Get("a") of a closed cache ok = true, want false`)
+	}
+	if _, _, ok := lru.RemoveOldest(); ok {
+		t.Error("RemoveOldest() of a closed cache ok = true, want false")
+	}
+	if keys := lru.Keys(); len(keys) != 0 {
+		t.Errorf("Keys() of a closed cache = %v, want none", keys)
+	}
+	lru.Purge()
+}
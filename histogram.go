@@ -0,0 +1,137 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"math"
+	"math/bits"
+)
+
+// histogramBuckets is the number of buckets of a Histogram, one per bit length of a non-negative int64 value.
+const histogramBuckets = 64
+
+// Histogram is a distribution of non-negative values in power-of-two buckets: the bucket 0 holds the zeros, the bucket
+// i the values from 2^(i-1) to 2^i-1. Counts holds the number of values per bucket and Sums their sum, e.g. to see
+// whether a few large values in the upper buckets dominate the total.
+type Histogram struct {
+	Counts [histogramBuckets]int64
+	Sums   [histogramBuckets]int64
+}
+
+// histogramBucket returns the bucket of the specified value.
+func histogramBucket(value int64) (bucket int) {
+	return bits.Len64(uint64(max(value, 0)))
+}
+
+// BucketBound returns the largest value of the specified bucket.
+//
+// Parameters:
+//   - bucket: The index of the bucket.
+//
+// Returns:
+//   - bound: The largest value, which falls into the bucket.
+func BucketBound(bucket int) (bound int64) {
+	return int64(uint64(1)<<bucket - 1)
+}
+
+// add adds the value delta times to the histogram, a negative delta removes it.
+func (h *Histogram) add(value, delta int64) {
+	bucket := histogramBucket(value)
+	h.Counts[bucket] += delta
+	h.Sums[bucket] += value * delta
+}
+
+// merge adds the values of the other histogram to the histogram.
+func (h *Histogram) merge(other *Histogram) {
+	for bucket := range histogramBuckets {
+		h.Counts[bucket] += other.Counts[bucket]
+		h.Sums[bucket] += other.Sums[bucket]
+	}
+}
+
+// Count returns the number of values of the histogram.
+func (h Histogram) Count() (count int64) {
+	for _, n := range h.Counts {
+		count += n
+	}
+
+	return count
+}
+
+// Sum returns the sum of the values of the histogram.
+func (h Histogram) Sum() (sum int64) {
+	for _, n := range h.Sums {
+		sum += n
+	}
+
+	return sum
+}
+
+// Mean returns the mean of the values of the histogram, zero if it is empty.
+func (h Histogram) Mean() (mean float64) {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	return float64(h.Sum()) / float64(count)
+}
+
+// Quantile returns an upper bound of the value at the specified quantile, i.e. the largest value of its bucket.
+//
+// Parameters:
+//   - q: The quantile, from 0 to 1, e.g. 0.99 for the 99th percentile.
+//
+// Returns:
+//   - bound: The largest value of the bucket holding the quantile, zero if the histogram is empty.
+//
+// Example Usage:
+//
+//	telemetry, err := cache.Telemetry()
+//	sizes := telemetry.GetValueSizeHistogram()
+//	fmt.Println(sizes.Quantile(0.5), sizes.Quantile(0.99))
+func (h Histogram) Quantile(q float64) (bound int64) {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	rank := min(max(int64(math.Ceil(q*float64(count))), 1), count)
+	var seen int64
+	for bucket, n := range h.Counts {
+		if seen += n; seen >= rank {
+			return BucketBound(bucket)
+		}
+	}
+
+	return math.MaxInt64
+}
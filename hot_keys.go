@@ -0,0 +1,331 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// HotKey represents a frequently accessed key reported by TopKeys. The count is approximate: it overestimates the true
+// number of accesses by at most Error.
+type HotKey[K IKey] struct {
+	Key   K
+	Count int64
+	Error int64
+}
+
+// TopKeys returns the most frequently accessed keys (hits and misses of the lookups) since the start of the cache,
+// ordered by their approximate access count, e.g. to identify hot keys which deserve dedicated handling or replication.
+// The tracking must be enabled by HotKeysCapacity.
+//
+// Parameters:
+//   - n: The maximum number of keys to return.
+//
+// Returns:
+//   - keys: The hot keys with their approximate access counts.
+//   - err: An error if the tracking is disabled, if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	keys, err := cache.TopKeys(10)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	for _, key := range keys {
+//	    fmt.Println(key.Key, key.Count)
+//	}
+func (cache *LRUCache[K, V]) TopKeys(n int) (keys []HotKey[K], err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, ErrClosed
+	}
+
+	for _, shard := range cache.shards {
+		if shard.hotKeys == nil {
+			return nil, errors.New("cache hot key tracking is disabled")
+		}
+		keys = append(keys, shard.hotKeys.Keys()...)
+	}
+
+	slices.SortFunc(keys, func(a, b HotKey[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	n = max(n, 0)
+
+	return keys[:min(n, len(keys))], nil
+}
+
+// TopEntries returns the most hit cache items of the cache, ordered by their number of hits, with their access stats,
+// e.g. to compare the hot cache items against the cache items that are never hit. Unlike TopKeys, the hits are exact,
+// but the misses are not counted and the hits of a removed cache item are lost. The tracking must be enabled by
+// AccessStatsOn.
+//
+// Parameters:
+//   - n: The maximum number of cache items to return.
+//
+// Returns:
+//   - entries: The most hit cache items.
+//   - err: An error if the tracking is disabled, if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	entries, err := cache.TopEntries(10)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	for _, entry := range entries {
+//	    fmt.Println(entry.Key, entry.Hits, entry.LastAccess)
+//	}
+func (cache *LRUCache[K, V]) TopEntries(n int) (entries []Entry[K, V], err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, ErrClosed
+	case Stopped:
+		return nil, errors.New("cache is stopped, must be started before calling method TopEntries()")
+	}
+
+	if !cache.config.AccessStatsOn {
+		return nil, errors.New("cache access stats are disabled")
+	}
+
+	n = max(n, 0)
+	byHits := func(a, b Entry[K, V]) int {
+		return cmp.Compare(b.Hits, a.Hits)
+	}
+	_, err = cache.rangeEntries(context.Background(), func(entry Entry[K, V]) bool {
+		if len(entries) == n && (n == 0 || entry.Hits <= entries[n-1].Hits) {
+			return true
+		}
+		if len(entries) == n {
+			entries = entries[:n-1]
+		}
+		index, _ := slices.BinarySearchFunc(entries, entry, byHits)
+		entries = slices.Insert(entries, index, entry)
+		return true
+	})
+
+	return entries, err
+}
+
+// ExportHot streams the cached items of the specified number of the most frequently accessed keys (see TopKeys) to the
+// specified function in batches of up to ExportBatchSize entries, e.g. to replicate the hot cache items to further
+// peers. The cache items are exported from the coldest to the hottest key, so an import makes the hottest cache item
+// the most recent one. The hot keys, which aren't cached, are skipped.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the export.
+//   - n: The maximum number of hot keys.
+//   - fn: The function receiving the batches. The batch is reused after fn returned and must not be retained.
+//
+// Returns:
+//   - count: The number of exported entries.
+//   - err: An error if the tracking is disabled, if the cache is stopped or closed, if the context is done, the error
+//     returned by fn, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.ExportHot(ctx, 1000, func(batch []sq_cache.Entry[string, []byte]) error {
+//	    return encoder.Encode(batch)
+//	})
+func (cache *LRUCache[K, V]) ExportHot(
+	ctx context.Context, n int, fn func(batch []Entry[K, V]) error,
+) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method ExportHot()")
+	}
+
+	keys, err := cache.TopKeys(n)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = cache.Flush()
+
+	batch := make([]Entry[K, V], 0, cache.exportBatchSize)
+	for _, hotKey := range slices.Backward(keys) {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		shard := cache.shards[cache.generateShardId(hotKey.Key, cache.maxShards)]
+		shard.RLockMeasured()
+		item, found := shard.lookup(hotKey.Key, timeNow())
+		if found {
+			batch = append(batch, cache.entry(item))
+		}
+		shard.RUnlock()
+
+		if int64(len(batch)) < cache.exportBatchSize {
+			continue
+		}
+
+		if err = fn(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+		batch = batch[:0]
+	}
+
+	if len(batch) > 0 {
+		if err = fn(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+	}
+
+	return count, nil
+}
+
+// hotKeyCounter represents the counter of a tracked key.
+type hotKeyCounter[K IKey] struct {
+	key   K
+	count int64
+	error int64
+	index int
+}
+
+// hotKeys represents a thread-safe space-saving top-K tracker, which approximates the access frequencies of the most
+// frequently accessed keys with a fixed number of counters. Once all counters are in use, the key with the lowest count
+// is replaced by the newly accessed key, which inherits its count as error.
+type hotKeys[K IKey] struct {
+	sync.Mutex
+
+	capacity int
+	counters map[K]*hotKeyCounter[K]
+	heap     hotKeysByCount[K]
+}
+
+// newHotKeys initializes and returns a new hotKeys instance with the specified number of counters.
+func newHotKeys[K IKey](capacity int) (h *hotKeys[K]) {
+	h = &hotKeys[K]{
+		capacity: capacity,
+		counters: make(map[K]*hotKeyCounter[K], capacity),
+		heap:     make(hotKeysByCount[K], 0, capacity),
+	}
+
+	return h
+}
+
+// Record counts an access of the specified key.
+func (h *hotKeys[K]) Record(key K) {
+	h.Lock()
+	defer h.Unlock()
+
+	if counter, found := h.counters[key]; found {
+		counter.count++
+		heap.Fix(&h.heap, counter.index)
+		return
+	}
+
+	if len(h.heap) < h.capacity {
+		counter := &hotKeyCounter[K]{key: key, count: 1}
+		h.counters[key] = counter
+		heap.Push(&h.heap, counter)
+		return
+	}
+
+	counter := h.heap[0]
+	delete(h.counters, counter.key)
+	counter.key = key
+	counter.error = counter.count
+	counter.count++
+	h.counters[key] = counter
+	heap.Fix(&h.heap, 0)
+}
+
+// Keys returns the tracked keys with their counts.
+func (h *hotKeys[K]) Keys() (keys []HotKey[K]) {
+	h.Lock()
+	defer h.Unlock()
+
+	keys = make([]HotKey[K], 0, len(h.heap))
+	for _, counter := range h.heap {
+		keys = append(keys, HotKey[K]{Key: counter.key, Count: counter.count, Error: counter.error})
+	}
+
+	return keys
+}
+
+// hotKeysByCount is a min-heap of counters, ordered by their count.
+type hotKeysByCount[K IKey] []*hotKeyCounter[K]
+
+// Len returns the number of counters in the heap.
+func (h hotKeysByCount[K]) Len() int {
+	return len(h)
+}
+
+// Less reports whether the counter i has a lower count than the counter j.
+func (h hotKeysByCount[K]) Less(i, j int) bool {
+	return h[i].count < h[j].count
+}
+
+// Swap swaps the counters i and j.
+func (h hotKeysByCount[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+// Push adds a counter to the heap.
+func (h *hotKeysByCount[K]) Push(x any) {
+	counter := x.(*hotKeyCounter[K])
+	counter.index = len(*h)
+	*h = append(*h, counter)
+}
+
+// Pop removes the last counter from the heap.
+func (h *hotKeysByCount[K]) Pop() any {
+	old := *h
+	counter := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return counter
+}
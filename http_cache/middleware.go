@@ -0,0 +1,254 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package http_cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// Handler is a http.Handler middleware that caches the rendered responses (status, headers, body) of GET requests in a
+// LRUCache.
+type Handler struct {
+	cache *sq_cache.LRUCache[string, []byte]
+	next  http.Handler
+
+	keyFn func(r *http.Request) string
+	ttlFn func(r *http.Request) time.Duration
+
+	// OnInvalidate is called for requests that are not cached (e.g. POST, PUT, DELETE). The returned keys are
+	// invalidated, once the next handler responded with a successful status.
+	OnInvalidate func(r *http.Request) (keys []string)
+}
+
+// Middleware creates a Handler, which caches the responses of the next handler.
+//
+// Parameters:
+//   - cache: The cache to store the responses in.
+//   - next: The handler rendering the responses.
+//   - keyFn: The function generating the cache key of a request, DefaultKey is used if nil.
+//   - ttlFn: The function returning the TTL (time to live) of the response of a request, zero or negative means the
+//     response is not cached.
+//
+// Returns:
+//   - handler: The created Handler object.
+//
+// Example Usage:
+//
+//	handler := http_cache.Middleware(cache, mux, nil, http_cache.TTLByPrefix(map[string]time.Duration{
+//	    "/api/products": time.Minute,
+//	    "/static/":      time.Hour,
+//	}))
+func Middleware(
+	cache *sq_cache.LRUCache[string, []byte],
+	next http.Handler,
+	keyFn func(r *http.Request) string,
+	ttlFn func(r *http.Request) time.Duration,
+) (handler *Handler) {
+	if keyFn == nil {
+		keyFn = DefaultKey
+	}
+
+	return &Handler{
+		cache: cache,
+		next:  next,
+		keyFn: keyFn,
+		ttlFn: ttlFn,
+	}
+}
+
+// DefaultKey generates the cache key of a request from its method, host and URL.
+func DefaultKey(r *http.Request) (key string) {
+	return r.Method + " " + r.Host + r.URL.RequestURI()
+}
+
+// TTLByPrefix returns a ttlFn, which selects the TTL (time to live) of the longest path prefix matching the request.
+// Requests matching no prefix are not cached.
+func TTLByPrefix(rules map[string]time.Duration) (ttlFn func(r *http.Request) time.Duration) {
+	return func(r *http.Request) (ttl time.Duration) {
+		longest := -1
+		for prefix, prefixTTL := range rules {
+			if strings.HasPrefix(r.URL.Path, prefix) && len(prefix) > longest {
+				longest = len(prefix)
+				ttl = prefixTTL
+			}
+		}
+
+		return ttl
+	}
+}
+
+// Invalidate removes the cached responses of the specified keys.
+//
+// Parameters:
+//   - keys: The cache keys of the responses to remove.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (handler *Handler) Invalidate(keys ...string) (err error) {
+	for _, key := range keys {
+		if _, err = handler.cache.Remove(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cachedResponse is a rendered response.
+type cachedResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// ServeHTTP serves the request from the cache, if its response is cached, and renders it by the next handler
+// otherwise.
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		handler.serveUncached(w, r)
+		return
+	}
+
+	ttl := handler.ttlFn(r)
+	if ttl <= 0 {
+		handler.next.ServeHTTP(w, r)
+		return
+	}
+
+	key := handler.keyFn(r)
+
+	if value, err := handler.cache.Get(key); err == nil && value != nil {
+		cached := &cachedResponse{}
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(cached); err == nil {
+			for name, values := range cached.Header {
+				w.Header()[name] = values
+			}
+			w.Header().Set(XFromCache, "1")
+			w.WriteHeader(cached.Status)
+			_, _ = w.Write(cached.Body)
+			return
+		}
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	handler.next.ServeHTTP(recorder, r)
+
+	if !recorder.cacheable() {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&cachedResponse{
+		Status: recorder.status,
+		Header: w.Header().Clone(),
+		Body:   recorder.body.Bytes(),
+	}); err != nil {
+		return
+	}
+
+	// The cache rejects responses exceeding its size limit, which are served uncached.
+	_, _ = handler.cache.SetWithTTL(key, buf.Bytes(), ttl)
+}
+
+// serveUncached renders a request by the next handler and invalidates the keys returned by OnInvalidate, if the
+// response was successful.
+func (handler *Handler) serveUncached(w http.ResponseWriter, r *http.Request) {
+	if handler.OnInvalidate == nil {
+		handler.next.ServeHTTP(w, r)
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK, discard: true}
+	handler.next.ServeHTTP(recorder, r)
+
+	if recorder.status < 400 {
+		_ = handler.Invalidate(handler.OnInvalidate(r)...)
+	}
+}
+
+// responseRecorder is a http.ResponseWriter, which records the status and body of a response while writing it.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	discard     bool
+}
+
+// WriteHeader records the status and writes it.
+func (recorder *responseRecorder) WriteHeader(status int) {
+	if recorder.wroteHeader {
+		return
+	}
+	recorder.wroteHeader = true
+	recorder.status = status
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body and writes it.
+func (recorder *responseRecorder) Write(b []byte) (n int, err error) {
+	if !recorder.wroteHeader {
+		recorder.WriteHeader(http.StatusOK)
+	}
+	if !recorder.discard {
+		recorder.body.Write(b)
+	}
+
+	return recorder.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying http.ResponseWriter, e.g. for the http.ResponseController.
+func (recorder *responseRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
+// cacheable reports whether the recorded response may be cached.
+func (recorder *responseRecorder) cacheable() bool {
+	if recorder.status < 200 || recorder.status >= 300 || recorder.status == http.StatusPartialContent {
+		return false
+	}
+
+	header := recorder.Header()
+	if header.Get("Set-Cookie") != "" {
+		return false
+	}
+
+	return !hasDirective(header, "no-store") && !hasDirective(header, "private")
+}
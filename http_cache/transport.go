@@ -0,0 +1,395 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package http_cache provides HTTP caching on top of a sq_cache LRUCache.
+package http_cache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// XFromCache is the header set on responses that were served from the cache.
+	XFromCache = "X-From-Cache"
+)
+
+// Transport is a http.RoundTripper that caches the responses of GET requests in a LRUCache, respecting the
+// Cache-Control, Expires, ETag, Last-Modified and Vary headers. Stale responses with a validator are revalidated with
+// a conditional request (If-None-Match, If-Modified-Since). The variants of a response with a Vary header are cached
+// under their own keys, which include the values of the request headers listed by the Vary header, so that they don't
+// replace each other.
+type Transport struct {
+	// Cache stores the responses. The size limit and admit hook of the cache apply to the responses.
+	Cache *sq_cache.LRUCache[string, []byte]
+	// Transport sends the requests, http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+	// MaxTTL caps the freshness lifetime of the responses, zero means no cap.
+	MaxTTL time.Duration
+	// RevalidateTTL is the duration stale responses with a validator are kept for revalidation.
+	RevalidateTTL time.Duration
+}
+
+// NewTransport creates a Transport, which caches the responses in the specified cache and sends the requests by the
+// specified transport.
+//
+// Parameters:
+//   - cache: The cache to store the responses in.
+//   - transport: The transport to send the requests by, http.DefaultTransport is used if nil.
+//
+// Returns:
+//   - t: The created Transport object.
+//
+// Example Usage:
+//
+//	client := &http.Client{Transport: http_cache.NewTransport(cache, nil)}
+func NewTransport(cache *sq_cache.LRUCache[string, []byte], transport http.RoundTripper) (t *Transport) {
+	return &Transport{
+		Cache:         cache,
+		Transport:     transport,
+		RevalidateTTL: time.Hour,
+	}
+}
+
+// entry is a cached response. A response with a Vary header is cached as a variant, the entry of the request key only
+// lists the names of the Vary headers, which select the variant.
+type entry struct {
+	Response    []byte
+	VaryHeaders map[string]string
+	StoredAt    time.Time
+	Freshness   time.Duration
+
+	// VaryNames are the names of the Vary headers of the variants of the request key.
+	VaryNames []string
+}
+
+// transport returns the transport to send the requests by.
+func (t *Transport) transport() (transport http.RoundTripper) {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+// RoundTrip serves the request from the cache, if a fresh response is cached, and sends the request otherwise.
+func (t *Transport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || hasDirective(req.Header, "no-store") {
+		return t.transport().RoundTrip(req)
+	}
+
+	key := requestKey(req)
+
+	cached, found := t.load(key, req)
+	if found && cached.fresh() && !hasDirective(req.Header, "no-cache") {
+		return cached.response(req)
+	}
+
+	outReq := req
+	if found {
+		outReq = cached.conditionalRequest(req)
+	}
+
+	resp, err = t.transport().RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+
+		cachedResp, err := cached.response(req)
+		if err != nil {
+			return nil, err
+		}
+		cachedResp.Header.Del(XFromCache)
+		for name, values := range resp.Header {
+			cachedResp.Header[name] = values
+		}
+
+		resp, err = t.store(key, req, cachedResp)
+		if err != nil {
+			return nil, err
+		}
+		resp.Header.Set(XFromCache, "1")
+
+		return resp, nil
+	}
+
+	if !cacheable(resp) {
+		return resp, nil
+	}
+
+	return t.store(key, req, resp)
+}
+
+// load retrieves the cached response by the specified key, or its variant selected by the Vary headers of the
+// request, if it matches the Vary headers of the request.
+func (t *Transport) load(key string, req *http.Request) (cached *entry, found bool) {
+	cached, found = t.get(key)
+	if found && len(cached.VaryNames) > 0 {
+		cached, found = t.get(variantKey(key, cached.VaryNames, req))
+	}
+	if !found || cached.Response == nil {
+		return nil, false
+	}
+
+	for name, value := range cached.VaryHeaders {
+		if req.Header.Get(name) != value {
+			return nil, false
+		}
+	}
+
+	return cached, true
+}
+
+// get retrieves the cached entry by the specified key.
+func (t *Transport) get(key string) (cached *entry, found bool) {
+	value, err := t.Cache.Get(key)
+	if err != nil || value == nil {
+		return nil, false
+	}
+
+	cached = &entry{}
+	if err := gob.NewDecoder(bytes.NewReader(value)).Decode(cached); err != nil {
+		return nil, false
+	}
+
+	return cached, true
+}
+
+// set caches the entry by the specified key for the specified TTL (time to live).
+func (t *Transport) set(key string, cached *entry, ttl time.Duration) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cached); err == nil {
+		// The cache rejects responses exceeding its size limit, which are passed through uncached.
+		_, _ = t.Cache.SetWithTTL(key, buf.Bytes(), ttl)
+	}
+}
+
+// store reads the body of the response and caches the response by the specified key. The returned response can be
+// read again by the caller.
+func (t *Transport) store(key string, req *http.Request, resp *http.Response) (storedResp *http.Response, err error) {
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &entry{
+		Response:    dump,
+		VaryHeaders: make(map[string]string),
+		StoredAt:    time.Now(),
+		Freshness:   freshness(resp.Header),
+	}
+	if t.MaxTTL > 0 {
+		cached.Freshness = min(cached.Freshness, t.MaxTTL)
+	}
+	names := varyHeaders(resp.Header)
+	for _, name := range names {
+		cached.VaryHeaders[name] = req.Header.Get(name)
+	}
+
+	ttl := cached.Freshness
+	if hasValidator(resp.Header) {
+		ttl += t.RevalidateTTL
+	}
+
+	if ttl > 0 {
+		if len(names) > 0 {
+			t.set(key, &entry{VaryNames: names}, ttl)
+			key = variantKey(key, names, req)
+		}
+		t.set(key, cached, ttl)
+	}
+
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
+
+// fresh reports whether the cached response is still fresh.
+func (cached *entry) fresh() bool {
+	return time.Since(cached.StoredAt) < cached.Freshness
+}
+
+// response reconstructs the cached response for the specified request.
+func (cached *entry) response(req *http.Request) (resp *http.Response, err error) {
+	resp, err = http.ReadResponse(bufio.NewReader(bytes.NewReader(cached.Response)), req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Set(XFromCache, "1")
+
+	return resp, nil
+}
+
+// conditionalRequest returns a copy of the request with the validators of the cached response.
+func (cached *entry) conditionalRequest(req *http.Request) (condReq *http.Request) {
+	resp, err := cached.response(req)
+	if err != nil {
+		return req
+	}
+	_ = resp.Body.Close()
+
+	if !hasValidator(resp.Header) {
+		return req
+	}
+
+	condReq = req.Clone(req.Context())
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		condReq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		condReq.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	return condReq
+}
+
+// requestKey returns the cache key of the request.
+func requestKey(req *http.Request) (key string) {
+	return req.Method + " " + req.URL.String()
+}
+
+// variantKey returns the cache key of the variant of the request key, which is selected by the values of the
+// specified Vary headers of the request.
+func variantKey(key string, names []string, req *http.Request) (variant string) {
+	var b strings.Builder
+
+	b.WriteString(key)
+	for _, name := range names {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(req.Header.Get(name)))
+	}
+
+	return b.String()
+}
+
+// cacheable reports whether the response may be cached.
+func cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if hasDirective(resp.Header, "no-store") {
+		return false
+	}
+	for _, name := range varyHeaders(resp.Header) {
+		if name == "*" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasValidator reports whether the response has a validator for conditional requests.
+func hasValidator(header http.Header) bool {
+	return header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+}
+
+// varyHeaders returns the canonical names of the headers listed by the Vary header.
+func varyHeaders(header http.Header) (names []string) {
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return names
+}
+
+// cacheControl parses the Cache-Control header into its directives.
+func cacheControl(header http.Header) (directives map[string]string) {
+	directives = make(map[string]string)
+
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name != "" {
+				directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+			}
+		}
+	}
+
+	return directives
+}
+
+// hasDirective reports whether the Cache-Control header contains the specified directive.
+func hasDirective(header http.Header, directive string) bool {
+	_, found := cacheControl(header)[directive]
+
+	return found
+}
+
+// freshness returns the freshness lifetime of a response, based on the Cache-Control max-age directive or the Expires
+// header. Responses with the no-cache directive are never fresh.
+func freshness(header http.Header) (lifetime time.Duration) {
+	directives := cacheControl(header)
+
+	if _, found := directives["no-cache"]; found {
+		return 0
+	}
+
+	if maxAge, found := directives["max-age"]; found {
+		seconds, err := strconv.ParseInt(maxAge, 10, 64)
+		if err != nil || seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = time.Now()
+		}
+		return max(expiresAt.Sub(date), 0)
+	}
+
+	return 0
+}
+
+// Ensure Transport implements the http.RoundTripper interface.
+var _ http.RoundTripper = (*Transport)(nil)
@@ -0,0 +1,185 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package http_cache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rommarius/sq_cache"
+)
+
+// roundTripFunc is a http.RoundTripper calling the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// newTestTransport creates a Transport with a small cache, which sends the requests to the specified handler and
+// counts them.
+func newTestTransport(t *testing.T, handler func(req *http.Request) (status int, header http.Header, body string)) (
+	transport *Transport, requests *int,
+) {
+	t.Helper()
+
+	cache, err := sq_cache.NewLRUCache[string, []byte](context.Background(), &sq_cache.Config[string, []byte]{
+		MaxShards:       4,
+		MaxItems:        100,
+		LoggingDisabled: true,
+	})
+	if err != nil {
+		t.Fatalf("NewLRUCache() error = %v", err)
+	}
+	t.Cleanup(cache.Close)
+
+	requests = new(int)
+	transport = NewTransport(cache, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*requests++
+		status, header, body := handler(req)
+		return &http.Response{
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}))
+
+	return transport, requests
+}
+
+// get sends a GET request with the specified headers by the transport and returns the response body and whether it
+// was served from the cache.
+func get(t *testing.T, transport *Transport, header http.Header) (body string, fromCache bool) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/resource", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	return string(b), resp.Header.Get(XFromCache) == "1"
+}
+
+func TestTransportCacheControl(t *testing.T) {
+	tests := map[string]struct {
+		cacheControl string
+		wantCached   bool
+	}{
+		"MaxAge":  {cacheControl: "max-age=60", wantCached: true},
+		"NoStore": {cacheControl: "max-age=60, no-store", wantCached: false},
+		"NoCache": {cacheControl: "no-cache", wantCached: false},
+		"None":    {cacheControl: "", wantCached: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			transport, requests := newTestTransport(t, func(req *http.Request) (int, http.Header, string) {
+				return http.StatusOK, http.Header{"Cache-Control": {test.cacheControl}}, "body"
+			})
+
+			get(t, transport, nil)
+			body, fromCache := get(t, transport, nil)
+
+			if body != "body" || fromCache != test.wantCached {
+				t.Errorf("RoundTrip() = %q, from cache %t, want %q, %t", body, fromCache, "body", test.wantCached)
+			}
+			if wantRequests := map[bool]int{true: 1, false: 2}[test.wantCached]; *requests != wantRequests {
+				t.Errorf("RoundTrip() sent %d requests, want %d", *requests, wantRequests)
+			}
+		})
+	}
+}
+
+func TestTransportRevalidatesETag(t *testing.T) {
+	transport, requests := newTestTransport(t, func(req *http.Request) (int, http.Header, string) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return http.StatusNotModified, http.Header{"Etag": {`"v1"`}}, ""
+		}
+		return http.StatusOK, http.Header{"Etag": {`"v1"`}, "Cache-Control": {"no-cache"}}, "body"
+	})
+
+	if body, fromCache := get(t, transport, nil); body != "body" || fromCache {
+		t.Fatalf("RoundTrip() = %q, from cache %t, want %q, false", body, fromCache, "body")
+	}
+	if body, fromCache := get(t, transport, nil); body != "body" || !fromCache {
+		t.Errorf("RoundTrip() = %q, from cache %t after a 304, want %q, true", body, fromCache, "body")
+	}
+	if *requests != 2 {
+		t.Errorf("RoundTrip() sent %d requests, want 2", *requests)
+	}
+}
+
+func TestTransportCachesVaryVariants(t *testing.T) {
+	transport, requests := newTestTransport(t, func(req *http.Request) (int, http.Header, string) {
+		header := http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Language"}}
+		return http.StatusOK, header, "body-" + req.Header.Get("Accept-Language")
+	})
+
+	for range 2 {
+		for _, language := range []string{"en", "de"} {
+			get(t, transport, http.Header{"Accept-Language": {language}})
+		}
+	}
+
+	for _, language := range []string{"en", "de"} {
+		body, fromCache := get(t, transport, http.Header{"Accept-Language": {language}})
+		if want := "body-" + language; body != want || !fromCache {
+			t.Errorf("RoundTrip(%s) = %q, from cache %t, want %q, true", language, body, fromCache, want)
+		}
+	}
+	if *requests != 2 {
+		t.Errorf("RoundTrip() sent %d requests, want 2", *requests)
+	}
+}
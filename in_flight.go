@@ -0,0 +1,91 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// inFlightCounter counts the running operations of the cache and wakes the waiters once none is running anymore, e.g.
+// Close, which waits for the running operations before it releases anything. The operations only pay for an atomic add,
+// the mutex is only taken once someone waited, which is set by the first Wait and stays set.
+type inFlightCounter struct {
+	count   atomic.Int64
+	waiting atomic.Bool
+
+	mutex sync.Mutex
+	idle  chan struct{}
+}
+
+// Add adds the delta to the number of running operations, +1 when an operation starts and -1 when it returns.
+func (counter *inFlightCounter) Add(delta int64) {
+	if counter.count.Add(delta) != 0 || !counter.waiting.Load() {
+		return
+	}
+
+	counter.mutex.Lock()
+	if counter.idle != nil && counter.count.Load() == 0 {
+		close(counter.idle)
+		counter.idle = nil
+	}
+	counter.mutex.Unlock()
+}
+
+// Load returns the number of running operations.
+func (counter *inFlightCounter) Load() (count int64) {
+	return counter.count.Load()
+}
+
+// Wait waits until no operation is running anymore, or until the context is done.
+func (counter *inFlightCounter) Wait(ctx context.Context) (err error) {
+	for {
+		counter.mutex.Lock()
+		counter.waiting.Store(true)
+		if counter.count.Load() == 0 {
+			counter.mutex.Unlock()
+			return nil
+		}
+		if counter.idle == nil {
+			counter.idle = make(chan struct{})
+		}
+		idle := counter.idle
+		counter.mutex.Unlock()
+
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,396 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package jwks provides a cache of JSON Web Key Sets (JWKS) and their parsed public keys on top of a sq_cache LRUCache,
+// with refresh-ahead of expiring key sets and negative caching of failed fetches.
+package jwks
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// maxDocumentBytes is the maximum size of a fetched JWKS document.
+	maxDocumentBytes = 1 << 20
+
+	// documentPrefix and failurePrefix are the key prefixes of the cached documents and fetch failures.
+	documentPrefix = "jwks:"
+	failurePrefix  = "jwks-failure:"
+)
+
+var (
+	// ErrKeyNotFound is returned when the key set doesn't contain a key with the requested key id.
+	ErrKeyNotFound = errors.New("jwks: key not found")
+
+	// ErrFetchFailed is returned when the key set couldn't be fetched, or a previous fetch failed recently.
+	ErrFetchFailed = errors.New("jwks: fetch failed")
+)
+
+// keySet is a parsed JWKS document.
+type keySet struct {
+	document []byte
+	keys     map[string]crypto.PublicKey
+}
+
+// Cache caches JWKS documents by their URL and keeps the parsed public keys of the cached documents. A document is
+// refreshed in the background, once its remaining TTL (time to live) falls below RefreshAhead. Failed fetches are
+// cached for NegativeTTL, so that an unavailable issuer isn't hammered by every verification.
+type Cache struct {
+	cache  *sq_cache.LRUCache[string, []byte]
+	client *http.Client
+
+	// TTL is the time to live of the fetched documents.
+	TTL time.Duration
+	// RefreshAhead is the remaining TTL, below which a document is refreshed in the background.
+	RefreshAhead time.Duration
+	// NegativeTTL is the time a failed fetch is cached.
+	NegativeTTL time.Duration
+	// MinRefreshInterval is the minimum time between two fetches of a key set caused by an unknown key id.
+	MinRefreshInterval time.Duration
+
+	mutex      sync.Mutex
+	keySets    map[string]*keySet
+	fetchedAt  map[string]time.Time
+	refreshing map[string]bool
+}
+
+// NewCache creates a Cache, which stores the JWKS documents in the specified cache and fetches them by the specified
+// client.
+//
+// Parameters:
+//   - cache: The cache to store the documents in.
+//   - client: The client to fetch the documents by, http.DefaultClient is used if nil.
+//
+// Returns:
+//   - c: The created Cache object.
+//
+// Example Usage:
+//
+//	keys := jwks.NewCache(cache, nil)
+//	publicKey, err := keys.PublicKey(ctx, "https://issuer.example.com/.well-known/jwks.json", kid)
+//	if err != nil {
+//	    panic(err)
+//	}
+func NewCache(cache *sq_cache.LRUCache[string, []byte], client *http.Client) (c *Cache) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Cache{
+		cache:  cache,
+		client: client,
+
+		TTL:          time.Hour,
+		RefreshAhead: time.Minute * 5,
+		NegativeTTL:  time.Second * 30,
+
+		MinRefreshInterval: time.Minute,
+
+		keySets:    make(map[string]*keySet),
+		fetchedAt:  make(map[string]time.Time),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// PublicKey returns the public key with the specified key id of the key set at the specified URL.
+// If the key set doesn't contain the key id, e.g. after a key rotation, the key set is fetched again, unless it was
+// fetched within MinRefreshInterval.
+//
+// Parameters:
+//   - ctx: The context of the fetch.
+//   - url: The URL of the JWKS document.
+//   - kid: The key id.
+//
+// Returns:
+//   - key: The public key (*rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey).
+//   - err: ErrKeyNotFound if the key set doesn't contain the key id, ErrFetchFailed if the key set couldn't be fetched,
+//     or any other issue.
+func (c *Cache) PublicKey(ctx context.Context, url, kid string) (key crypto.PublicKey, err error) {
+	keys, err := c.KeySet(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, found := keys[kid]; found {
+		return key, nil
+	}
+
+	c.mutex.Lock()
+	fetchedAt := c.fetchedAt[url]
+	c.mutex.Unlock()
+	if time.Since(fetchedAt) < c.MinRefreshInterval {
+		return nil, ErrKeyNotFound
+	}
+
+	keys, err = c.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, found := keys[kid]; found {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// KeySet returns the public keys of the key set at the specified URL by their key id.
+//
+// Parameters:
+//   - ctx: The context of the fetch.
+//   - url: The URL of the JWKS document.
+//
+// Returns:
+//   - keys: The public keys by their key id.
+//   - err: ErrFetchFailed if the key set couldn't be fetched, or any other issue.
+func (c *Cache) KeySet(ctx context.Context, url string) (keys map[string]crypto.PublicKey, err error) {
+	document, ttl, found, err := c.cache.GetWithTTL(documentPrefix + url)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return c.fetch(ctx, url)
+	}
+
+	if ttl != sq_cache.NoExpiry && ttl < c.RefreshAhead {
+		c.refresh(url)
+	}
+
+	return c.parse(url, document)
+}
+
+// refresh fetches the key set at the specified URL in the background, unless a refresh is already running.
+func (c *Cache) refresh(url string) {
+	c.mutex.Lock()
+	if c.refreshing[url] {
+		c.mutex.Unlock()
+		return
+	}
+	c.refreshing[url] = true
+	c.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			c.mutex.Lock()
+			delete(c.refreshing, url)
+			c.mutex.Unlock()
+		}()
+
+		_, _ = c.fetch(context.Background(), url)
+	}()
+}
+
+// fetch fetches the key set at the specified URL and caches it, unless a previous fetch failed within NegativeTTL.
+func (c *Cache) fetch(ctx context.Context, url string) (keys map[string]crypto.PublicKey, err error) {
+	if found, _ := c.cache.Contains(failurePrefix + url); found {
+		return nil, ErrFetchFailed
+	}
+
+	document, err := c.download(ctx, url)
+	if err == nil {
+		keys, err = c.parse(url, document)
+	}
+	if err != nil {
+		if c.NegativeTTL > 0 {
+			_, _ = c.cache.SetWithTTL(failurePrefix+url, []byte(err.Error()), c.NegativeTTL)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+
+	c.mutex.Lock()
+	c.fetchedAt[url] = time.Now()
+	c.mutex.Unlock()
+
+	if _, err = c.cache.SetWithTTL(documentPrefix+url, document, c.TTL); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// download downloads the JWKS document at the specified URL.
+func (c *Cache) download(ctx context.Context, url string) (document []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes))
+}
+
+// parse returns the public keys of the JWKS document, reusing the parsed keys if the document didn't change.
+func (c *Cache) parse(url string, document []byte) (keys map[string]crypto.PublicKey, err error) {
+	c.mutex.Lock()
+	set, found := c.keySets[url]
+	c.mutex.Unlock()
+	if found && bytes.Equal(set.document, document) {
+		return set.keys, nil
+	}
+
+	keys, err = parseDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.keySets[url] = &keySet{document: document, keys: keys}
+	c.mutex.Unlock()
+
+	return keys, nil
+}
+
+// jsonWebKey is a JSON Web Key, as defined by RFC 7517.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseDocument parses the RSA, EC and Ed25519 public keys of a JWKS document. Keys of other types are skipped.
+func parseDocument(document []byte) (keys map[string]crypto.PublicKey, err error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = json.Unmarshal(document, &set); err != nil {
+		return nil, err
+	}
+
+	keys = make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+// publicKey returns the public key of the JSON Web Key, or nil if the key type is not supported.
+func (jwk *jsonWebKey) publicKey() (key crypto.PublicKey, err error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid EC point")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer.
+func decodeBigInt(s string) (i *big.Int, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,363 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// loaderCall represents an in-flight or completed load of a single key.
+type loaderCall[V IValue] struct {
+	// done is closed, once the value and the error of the load are set.
+	done chan struct{}
+	// cancel cancels the context of the load, which is detached from the contexts of the callers.
+	cancel context.CancelFunc
+
+	// callers is the number of Load calls waiting for the load, including the call which started it. The load is
+	// abandoned and cancelled, once all of them returned because their contexts are done.
+	callers   int64
+	abandoned bool
+
+	value V
+	err   error
+}
+
+// loadLimiter represents a semaphore, which limits the number of concurrent calls of the origin.
+type loadLimiter chan struct{}
+
+// newLoadLimiter creates a loadLimiter with the specified number of slots, or nil if the number isn't positive.
+func newLoadLimiter(slots int64) (limiter loadLimiter) {
+	if slots <= 0 {
+		return nil
+	}
+
+	return make(loadLimiter, slots)
+}
+
+// acquire waits for a free slot of the limiter, or until the context is done. A nil limiter never waits.
+func (limiter loadLimiter) acquire(ctx context.Context) (err error) {
+	if limiter == nil {
+		return nil
+	}
+
+	select {
+	case limiter <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot acquired by acquire.
+func (limiter loadLimiter) release() {
+	if limiter == nil {
+		return
+	}
+
+	<-limiter
+}
+
+// loaderCircuit represents the circuit breaker state of a single key.
+type loaderCircuit struct {
+	failures    int64
+	openUntil   time.Time
+	lastFailure time.Time
+}
+
+// loaderCircuitsPruneMin is the minimum number of circuits, before the stale circuits are pruned.
+const loaderCircuitsPruneMin = 1024
+
+// loader represents a thread-safe loader, which loads missing cache items from the origin. Concurrent loads of the same
+// key are coalesced into a single call of the load function (singleflight). If a bulk loader is configured, it is used
+// as load function instead. The number of concurrent calls of the origin is limited by the limiter, if any.
+type loader[K IKey, V IValue] struct {
+	sync.Mutex
+
+	load       func(ctx context.Context, key K) (value V, err error)
+	bulkLoader *bulkLoader[K, V]
+
+	timeout              time.Duration
+	retries              int64
+	retryBackoff         time.Duration
+	failureThreshold     int64
+	circuitBreakDuration time.Duration
+
+	limiter        loadLimiter
+	maxLoadsPerKey int64
+
+	telemetryOn bool
+	telemetry   *telemetry
+
+	calls    map[K]*loaderCall[V]
+	circuits map[K]*loaderCircuit
+	// circuitsPruneAt is the number of circuits, at which the stale circuits are pruned next, so that the circuits of
+	// many distinct failing keys don't accumulate.
+	circuitsPruneAt int
+}
+
+// newLoader initializes and returns a new loader instance with user-configured settings.
+func newLoader[K IKey, V IValue](config *Config[K, V]) (l *loader[K, V]) {
+	l = &loader[K, V]{
+		load: config.Loader,
+
+		timeout:              config.LoadTimeout,
+		retries:              config.LoadRetries,
+		retryBackoff:         config.LoadRetryBackoff,
+		failureThreshold:     config.LoadFailureThreshold,
+		circuitBreakDuration: config.LoadCircuitBreakDuration,
+
+		limiter:        newLoadLimiter(config.MaxConcurrentLoads),
+		maxLoadsPerKey: config.MaxLoadsPerKey,
+
+		telemetryOn: !config.TelemetryDisabled,
+		telemetry:   newTelemetry(),
+
+		calls:           make(map[K]*loaderCall[V]),
+		circuits:        make(map[K]*loaderCircuit),
+		circuitsPruneAt: loaderCircuitsPruneMin,
+	}
+
+	if config.BulkLoader != nil {
+		l.bulkLoader = newBulkLoader[K, V](config, l.limiter)
+		l.load = l.bulkLoader.Load
+	}
+
+	return l
+}
+
+// Load loads the value of the specified key from the origin. If a load of the same key is already in-flight, it waits
+// for its result instead of calling the origin again. If the circuit of the key is open, or if the in-flight load of the
+// key is already shared by the maximum number of calls per key, it fails immediately.
+// The load runs on a context, which carries the values of the context of the call that started it, but isn't cancelled
+// with it, as the load is shared by the waiting calls. Every call stops waiting once its own context is done, and the
+// load is cancelled once no call waits for it anymore.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (value V, err error) {
+	if l.load == nil {
+		return value, ErrLoaderNotConfigured
+	}
+
+	l.Lock()
+	if circuit, found := l.circuits[key]; found && time.Now().Before(circuit.openUntil) {
+		l.Unlock()
+		return value, ErrLoaderCircuitOpen
+	}
+	if call, found := l.calls[key]; found {
+		if l.maxLoadsPerKey > 0 && call.callers >= l.maxLoadsPerKey {
+			l.Unlock()
+			return value, ErrLoaderBusy
+		}
+		call.callers++
+		l.Unlock()
+		if l.telemetryOn {
+			l.telemetry.incrementCounter(LoadCoalesced)
+		}
+		return l.wait(ctx, key, call)
+	}
+	loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	call := &loaderCall[V]{done: make(chan struct{}), cancel: cancel, callers: 1}
+	l.calls[key] = call
+	l.Unlock()
+
+	go l.run(loadCtx, key, call)
+
+	return l.wait(ctx, key, call)
+}
+
+// wait waits for the result of the specified load, or until the context is done. The last waiting call, which returns
+// early, abandons the load and cancels it.
+func (l *loader[K, V]) wait(ctx context.Context, key K, call *loaderCall[V]) (value V, err error) {
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+	}
+
+	l.Lock()
+	call.callers--
+	if call.callers == 0 {
+		call.abandoned = true
+		if l.calls[key] == call {
+			delete(l.calls, key)
+		}
+		call.cancel()
+	}
+	l.Unlock()
+
+	return value, ctx.Err()
+}
+
+// run loads the value of the specified key for the waiting calls and updates the circuit of the key. An abandoned load
+// doesn't count as a failure of the origin.
+func (l *loader[K, V]) run(ctx context.Context, key K, call *loaderCall[V]) {
+	defer call.cancel()
+
+	value, err := l.loadWithRetry(ctx, key)
+
+	l.Lock()
+	call.value, call.err = value, err
+	if l.calls[key] == call {
+		delete(l.calls, key)
+	}
+	if !call.abandoned {
+		l.updateCircuit(key, err)
+	}
+	l.Unlock()
+
+	close(call.done)
+}
+
+// loadWithRetry calls the load function with a per-load timeout and retries failed loads with an exponential backoff.
+func (l *loader[K, V]) loadWithRetry(ctx context.Context, key K) (value V, err error) {
+	for attempt := int64(0); attempt <= l.retries; attempt++ {
+		if attempt > 0 {
+			backoff := l.retryBackoff << (attempt - 1)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return value, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		value, err = l.loadOnce(ctx, key)
+		if err == nil {
+			return value, nil
+		}
+		if ctx.Err() != nil {
+			return value, err
+		}
+	}
+
+	return value, err
+}
+
+// loadOnce calls the load function once, bounded by the configured timeout, and updates the loader telemetry. The call
+// waits for a free slot of the limiter, the bulk loader acquires the slots for its batches itself.
+func (l *loader[K, V]) loadOnce(ctx context.Context, key K) (value V, err error) {
+	if l.bulkLoader == nil {
+		if err = l.limiter.acquire(ctx); err != nil {
+			return value, err
+		}
+		defer l.limiter.release()
+	}
+
+	loadCtx, cancel := ctx, context.CancelFunc(func() {})
+	if l.timeout > 0 {
+		loadCtx, cancel = context.WithTimeout(ctx, l.timeout)
+	}
+	defer cancel()
+
+	if err = faultLoadError(); err == nil {
+		value, err = l.load(loadCtx, key)
+	}
+
+	if l.telemetryOn {
+		switch {
+		case err == nil:
+			l.telemetry.incrementCounter(LoadSuccess)
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(loadCtx.Err(), context.DeadlineExceeded):
+			l.telemetry.incrementCounter(LoadTimeout)
+		default:
+			l.telemetry.incrementCounter(LoadFailure)
+		}
+	}
+
+	return value, err
+}
+
+// updateCircuit updates the circuit breaker state of a key after a load. The circuit opens for the configured duration
+// once the number of consecutive failed loads reaches the configured threshold. Must be called with the lock held.
+func (l *loader[K, V]) updateCircuit(key K, err error) {
+	if err == nil {
+		delete(l.circuits, key)
+		return
+	}
+
+	now := time.Now()
+
+	circuit, found := l.circuits[key]
+	if !found {
+		if len(l.circuits) >= l.circuitsPruneAt {
+			l.pruneCircuits(now)
+		}
+		circuit = &loaderCircuit{}
+		l.circuits[key] = circuit
+	}
+
+	circuit.failures++
+	circuit.lastFailure = now
+	if circuit.failures >= l.failureThreshold {
+		circuit.failures = 0
+		circuit.openUntil = now.Add(l.circuitBreakDuration)
+	}
+}
+
+// pruneCircuits removes the stale circuits, which aren't open anymore and whose last failure is older than the circuit
+// break duration, and doubles the number of circuits for the next pruning, so that the pruning is amortized. A key of
+// a pruned circuit starts over with no failures. Must be called with the lock held.
+func (l *loader[K, V]) pruneCircuits(now time.Time) {
+	for key, circuit := range l.circuits {
+		if !now.Before(circuit.openUntil) && now.Sub(circuit.lastFailure) >= l.circuitBreakDuration {
+			delete(l.circuits, key)
+		}
+	}
+
+	l.circuitsPruneAt = max(len(l.circuits)*2, loaderCircuitsPruneMin)
+}
+
+// Telemetry returns the loader's telemetry (load success, failure, timeout counters).
+func (l *loader[K, V]) Telemetry() (telemetry *telemetry) {
+	return l.telemetry
+}
+
+// TelemetryReset resets the loader's telemetry counters (load success, failure, timeout) to zero.
+func (l *loader[K, V]) TelemetryReset() {
+	l.telemetry.reset()
+}
+
+// close cancels the running loads and closes the bulk loader, if any, failing its pending batch and cancelling its
+// running loads.
+func (l *loader[K, V]) close() {
+	l.Lock()
+	for _, call := range l.calls {
+		call.cancel()
+	}
+	l.Unlock()
+
+	if l.bulkLoader != nil {
+		l.bulkLoader.close()
+	}
+}
@@ -0,0 +1,208 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newTestLoader creates a loader with the specified load function, which fails the circuit after two failures.
+func newTestLoader(load func(ctx context.Context, key string) ([]byte, error)) (l *loader[string, []byte]) {
+	return newLoader(&Config[string, []byte]{
+		Loader:                   load,
+		LoadFailureThreshold:     2,
+		LoadCircuitBreakDuration: time.Minute,
+	})
+}
+
+func TestLoaderWaiterCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	l := newTestLoader(func(ctx context.Context, key string) ([]byte, error) {
+		<-release
+		return []byte(key), nil
+	})
+
+	go l.Load(context.Background(), "a")
+	waitFor(t, func() bool { return l.callers("a") == 1 })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := l.Load(ctx, "a")
+		errs <- err
+	}()
+	waitFor(t, func() bool { return l.callers("a") == 2 })
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Load() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Load() of a cancelled waiter didn't return before the load finished")
+	}
+}
+
+func TestLoaderFirstCallerCancelled(t *testing.T) {
+	release := make(chan struct{})
+	l := newTestLoader(func(ctx context.Context, key string) ([]byte, error) {
+		select {
+		case <-release:
+			return []byte(key), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := l.Load(ctx, "a")
+		first <- err
+	}()
+	waitFor(t, func() bool { return l.callers("a") == 1 })
+
+	type result struct {
+		value []byte
+		err   error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		value, err := l.Load(context.Background(), "a")
+		waiter <- result{value, err}
+	}()
+	waitFor(t, func() bool { return l.callers("a") == 2 })
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() of the first caller error = %v, want %v", err, context.Canceled)
+	}
+	close(release)
+
+	if r := <-waiter; r.err != nil || string(r.value) != "a" {
+		t.Errorf("Load() of the waiter = %q, %v, want %q, nil", r.value, r.err, "a")
+	}
+}
+
+func TestLoaderAbandonedLoadCancelled(t *testing.T) {
+	cancelled := make(chan struct{})
+	l := newTestLoader(func(ctx context.Context, key string) ([]byte, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Load(ctx, "a"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Load() error = %v, want %v", err, context.Canceled)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("load wasn't cancelled after all callers returned")
+	}
+	waitFor(t, func() bool { return l.circuitsLen() == 0 && l.callers("a") == 0 })
+}
+
+func TestLoaderCircuitOpen(t *testing.T) {
+	l := newTestLoader(func(ctx context.Context, key string) ([]byte, error) {
+		return nil, errors.New("origin down")
+	})
+
+	for range 2 {
+		if _, err := l.Load(context.Background(), "a"); err == nil {
+			t.Fatal("Load() error = nil, want the error of the origin")
+		}
+	}
+	if _, err := l.Load(context.Background(), "a"); !errors.Is(err, ErrLoaderCircuitOpen) {
+		t.Errorf("Load() error = %v, want %v", err, ErrLoaderCircuitOpen)
+	}
+}
+
+func TestLoaderCircuitsPruned(t *testing.T) {
+	l := newTestLoader(func(ctx context.Context, key string) ([]byte, error) {
+		return nil, errors.New("origin down")
+	})
+	l.circuitBreakDuration = 0
+
+	for i := range loaderCircuitsPruneMin * 4 {
+		if _, err := l.Load(context.Background(), fmt.Sprintf("key-%d", i)); err == nil {
+			t.Fatal("Load() error = nil, want the error of the origin")
+		}
+	}
+
+	if n := l.circuitsLen(); n > loaderCircuitsPruneMin {
+		t.Errorf("len(circuits) = %d, want the stale circuits pruned to at most %d", n, loaderCircuitsPruneMin)
+	}
+}
+
+// callers returns the number of calls waiting for the in-flight load of the key.
+func (l *loader[K, V]) callers(key K) (callers int64) {
+	l.Lock()
+	defer l.Unlock()
+
+	if call, found := l.calls[key]; found {
+		return call.callers
+	}
+
+	return 0
+}
+
+// circuitsLen returns the number of circuits of the loader.
+func (l *loader[K, V]) circuitsLen() (n int) {
+	l.Lock()
+	defer l.Unlock()
+
+	return len(l.circuits)
+}
+
+func TestNewLRUCacheRejectsNegativeLoadRetries(t *testing.T) {
+	cache, err := NewLRUCache[string, []byte](context.Background(), &Config[string, []byte]{
+		MaxShards:       4,
+		MaxItems:        100,
+		LoggingDisabled: true,
+		LoadRetries:     -1,
+	})
+	if err == nil {
+		cache.Close()
+		t.Fatal("NewLRUCache() error = nil, want an error for the negative LoadRetries")
+	}
+}
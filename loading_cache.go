@@ -0,0 +1,194 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+)
+
+// LoadingCache represents a read-through cache with a mandatory loader, like the LoadingCache of Guava and Caffeine:
+// its Get never misses, the value of a missing key is loaded (with the coalescing, timeout, retries and circuit
+// breaking of GetOrLoad) and cached.
+type LoadingCache[K IKey, V IValue] struct {
+	cache *LRUCache[K, V]
+}
+
+// NewLoadingCache initializes and returns a new LoadingCache with user-configured settings and the specified loader,
+// which replaces the Loader of the configuration.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the cache.
+//   - userConfig: A user-defined configuration for customizing the cache's behavior.
+//   - loader: The function loading the values of the missing keys.
+//
+// Returns:
+//   - cache: The created LoadingCache object.
+//   - err: An error if no loader was specified, or if any other issue occurs during initialization.
+//
+// Example Usage:
+//
+//	users, err := sq_cache.NewLoadingCache(ctx, &sq_cache.Config[string, []byte]{
+//	    DefaultTTL: time.Minute,
+//	}, func(ctx context.Context, id string) ([]byte, error) {
+//	    return db.LoadUser(ctx, id)
+//	})
+//
+//	user, err := users.Get(ctx, "42")
+func NewLoadingCache[K IKey, V IValue](
+	ctx context.Context,
+	userConfig *Config[K, V],
+	loader func(ctx context.Context, key K) (value V, err error),
+) (cache *LoadingCache[K, V], err error) {
+	if loader == nil {
+		return nil, errors.New("loading cache requires a loader")
+	}
+
+	config := *userConfig
+	config.Loader = loader
+
+	lruCache, err := NewLRUCache(ctx, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadingCache[K, V]{cache: lruCache}, nil
+}
+
+// Cache returns the underlying LRUCache, e.g. to read its telemetry or to close it.
+func (cache *LoadingCache[K, V]) Cache() (lruCache *LRUCache[K, V]) {
+	return cache.cache
+}
+
+// Get retrieves a value by the specified key, loading and caching it, if the key is missing.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the load.
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key, either found or loaded.
+//   - err: An error if the cache is stopped or closed, if the load failed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) Get(ctx context.Context, key K) (value V, err error) {
+	return cache.cache.GetOrLoad(ctx, key)
+}
+
+// GetAll retrieves the values of the specified keys, loading and caching the missing ones.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the loads.
+//   - keys: The keys associated with the values to retrieve.
+//
+// Returns:
+//   - values: The values associated with the keys.
+//   - err: An error if the cache is stopped or closed, if a load failed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) GetAll(ctx context.Context, keys []K) (values map[K]V, err error) {
+	values = make(map[K]V, len(keys))
+	for _, key := range keys {
+		if values[key], err = cache.cache.GetOrLoad(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// GetIfPresent retrieves a value by the specified key without loading it.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - found: A boolean indicating whether the key was found.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) GetIfPresent(key K) (value V, found bool, err error) {
+	value, _, found, err = cache.cache.GetWithTTL(key)
+
+	return value, found, err
+}
+
+// Put adds a key-value pair to the cache with the default TTL (time to live), replacing a cached or loaded value.
+//
+// Parameters:
+//   - key: The key to associate with the value.
+//   - value: The value to store in the cache.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) Put(key K, value V) (err error) {
+	_, err = cache.cache.SetWithTTL(key, value, 0)
+
+	return err
+}
+
+// Refresh loads the value of the specified key and replaces the cached value, e.g. after the source changed.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the load.
+//   - key: The key associated with the value to refresh.
+//
+// Returns:
+//   - value: The loaded value.
+//   - err: An error if the cache is stopped or closed, if the load failed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) Refresh(ctx context.Context, key K) (value V, err error) {
+	if _, err = cache.cache.Remove(key); err != nil {
+		return value, err
+	}
+
+	return cache.cache.GetOrLoad(ctx, key)
+}
+
+// Invalidate removes the cached value of the specified key, the next Get loads it again.
+//
+// Parameters:
+//   - key: The key to remove.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (cache *LoadingCache[K, V]) Invalidate(key K) (err error) {
+	_, err = cache.cache.Remove(key)
+
+	return err
+}
+
+// Len returns the number of cache items.
+func (cache *LoadingCache[K, V]) Len() (len int64) {
+	return cache.cache.Len()
+}
+
+// Close closes the cache, releasing any resources.
+func (cache *LoadingCache[K, V]) Close() {
+	cache.cache.Close()
+}
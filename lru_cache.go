@@ -32,8 +32,11 @@
 package sq_cache
 
 import (
+	"container/heap"
 	"context"
 	"errors"
+	"fmt"
+	"hash/maphash"
 	"log"
 	"sync"
 	"sync/atomic"
@@ -53,28 +56,137 @@ const (
 	Closed
 )
 
+// String returns the name of the cache status.
+func (status CacheStatus) String() string {
+	switch status {
+	case Opened:
+		return "opened"
+	case Started:
+		return "started"
+	case Stopped:
+		return "stopped"
+	case Closed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
 // LRUCache represents an a thread-safe LRU (Least Recently Used) cache.
 type LRUCache[K IKey, V IValue] struct {
 	ctx context.Context
 
 	maxShards int64
-	maxItems  int64
+	maxItems  atomic.Int64
 	len       atomic.Int64
+	clock     atomic.Int64
 
 	loggingOn   bool
 	telemetryOn bool
+	callbacksOn bool
+
+	onShardPurge func(loggingOn bool, shardId int64, removed int64)
+
+	defaultTTL       time.Duration
+	cleanupInterval  time.Duration
+	expiryResolution time.Duration
 
-	expiryDurationInSeconds  int64
-	cleanupDurationInSeconds int64
+	// cleanupPacing paces the cleanup, nil if it isn't paced. cleanupLimiter limits the rate of its removals, nil if
+	// the rate isn't limited.
+	cleanupPacing  *CleanupPacing
+	cleanupLimiter *removalLimiter
+
+	maxValueBytes        int64
+	oversizedPassThrough bool
+
+	copyOnRead  bool
+	copyOnWrite bool
+	clone       func(value V) V
+
+	exportBatchSize int64
 
 	generateKey     func(value V) K
-	generateShardId func(key K, maxItems int64) int64
+	generateShardId func(key K, maxShards int64) int64
+
+	admit func(key K, value V) bool
+
+	ttlFunc func(key K, value V) time.Duration
+
+	normalize func(key K) K
+
+	// namespaces maps the keys to their namespaces with quotas, nil if no namespace is configured.
+	namespaces *namespaces[K]
+
+	shardTuningOn bool
+	debugChecks   bool
 
-	status                CacheStatus
-	isCleanupActive       chan bool
-	isCleanupTickerActive bool
+	evictionPolicy EvictionPolicy
+
+	// config is the effective configuration, merged from the defaults and the user configuration, see Describe.
+	config        Config[K, V]
+	customShardId bool
+
+	// status holds the CacheStatus, closing reports whether Close was called, inFlight counts the running operations,
+	// which Close waits for.
+	status   atomic.Int64
+	closing  atomic.Bool
+	inFlight inFlightCounter
+
+	cleanupMutex   sync.Mutex
+	cleanupCancel  context.CancelFunc
+	cleanupDone    chan struct{}
+	cleanupRunning atomic.Bool
 
 	shards []*lruCacheShard[K, V]
+
+	setCoalescers []*setCoalescer[K, V]
+
+	writeBuffers              []*writeBuffer[K, V]
+	writeBufferReadYourWrites bool
+	writeSignal               chan struct{}
+	writeApplierCancel        context.CancelFunc
+	writeApplierDone          chan struct{}
+
+	snapshotPolicy *SnapshotPolicy
+	snapshotCancel context.CancelFunc
+	snapshotDone   chan struct{}
+	snapshotTime   atomic.Int64
+	snapshotBytes  atomic.Int64
+	snapshotItems  atomic.Int64
+
+	wal       *writeAheadLog
+	walCancel context.CancelFunc
+	walDone   chan struct{}
+
+	expiredKeys chan K
+
+	keyLocks    []sync.Mutex
+	keyLockSeed maphash.Seed
+
+	// dependencies holds the dependencies between the cache items declared by DependOn.
+	dependencies *dependencyGraph[K]
+
+	// tombstoneTTL is the duration of the tombstones of the removed keys, zero if the tombstones are disabled.
+	tombstoneTTL time.Duration
+
+	// resolver resolves the conflicts of the imported entries, nil if every entry is imported. hybridClock stamps the
+	// creation times of the metadata.
+	resolver    func(key K, current, incoming Entry[K, V]) (useIncoming bool)
+	hybridClock *hybridClock
+
+	// victims is the victim cache of the recently evicted cache items, shared by all shards, nil if it is disabled.
+	victims *victimCache[K, V]
+	// ghosts is the ghost list of the recently evicted keys, shared by all shards, nil if it is disabled.
+	ghosts *ghostList
+
+	// auditLog delivers the audit events to the AuditSink, nil if the audit log is disabled.
+	auditLog *auditLog[K]
+
+	loader *loader[K, V]
+
+	telemetry *telemetry
+
+	tracer atomic.Pointer[tracer]
 }
 
 // NewLRUCache initializes and returns a new LRUCache instance with user-configured settings.
@@ -91,73 +203,392 @@ func NewLRUCache[K IKey, V IValue](ctx context.Context, userConfig *Config[K, V]
 		MaxShards: 256,
 		MaxItems:  1000000,
 
-		LoggingOn:   true,
-		TelemetryOn: true,
+		CleanupInterval: time.Minute * 5,
+
+		LoadTimeout:              time.Second * 30,
+		LoadRetryBackoff:         time.Millisecond * 100,
+		LoadFailureThreshold:     5,
+		LoadCircuitBreakDuration: time.Second * 30,
+
+		BulkLoadWindow:  time.Millisecond * 10,
+		BulkLoadMaxKeys: 100,
+
+		VirtualNodes: 128,
+
+		EvictBatchSize: 64,
+
+		EvictionSamples: 5,
+
+		ExpiredKeysBuffer: 1024,
+
+		KeyLockStripes: 1024,
 
-		ExpiryDurationInSeconds:  60 * 60 * 24,
-		CleanupDurationInSeconds: 60 * 5,
+		ExportBatchSize: 1000,
+		AuditQueueSize:  1024,
 
 		GenerateKey:     generateKey[K, V],
 		GenerateShardId: generateShardId[K],
+		Clone:           cloneValue[V],
 
 		OnAdd:    onAdd[K, V],
 		OnUpdate: onUpdate[K, V],
 		OnHit:    onHit[K, V],
 		OnMiss:   onMiss[K, V],
 		OnEvict:  onEvict[K, V],
+		OnRemove: onRemove[K, V],
+
+		OnShardPurge: onShardPurge,
+
+		FairnessBucket: PrefixBucket[K](":"),
+
+		MaxDependencyDepth: 16,
+	}
+
+	// The merge writes into the user configuration, so a copy is merged, which keeps the configuration of the caller
+	// unchanged, e.g. to create further caches from it.
+	mergedConfig := *userConfig
+	if mergedConfig.DefaultTTL == 0 && mergedConfig.ExpiryDurationInSeconds > 0 {
+		mergedConfig.DefaultTTL = time.Duration(mergedConfig.ExpiryDurationInSeconds) * time.Second
+	}
+	if mergedConfig.CleanupInterval == 0 && mergedConfig.CleanupDurationInSeconds > 0 {
+		mergedConfig.CleanupInterval = time.Duration(mergedConfig.CleanupDurationInSeconds) * time.Second
 	}
+	mergedConfig.LoggingDisabled = mergedConfig.LoggingDisabled && !mergedConfig.LoggingOn
+	mergedConfig.TelemetryDisabled = mergedConfig.TelemetryDisabled && !mergedConfig.TelemetryOn
+	mergedConfig.CallbacksDisabled = mergedConfig.CallbacksDisabled && !mergedConfig.CallbacksOn
 
-	sq, err := sq_config_combine.New[Config[K, V]](defaultConfig, userConfig)
-	config := sq.Combine()
+	customShardId := userConfig.GenerateShardId != nil
+
+	sq, err := sq_config_combine.New[Config[K, V]](defaultConfig, &mergedConfig)
+	if err != nil {
+		return nil, err
+	}
+	config, err := sq.Combine()
 	if err != nil {
 		return nil, err
 	}
 
+	if config.BloomFalsePositiveRate < 0 || config.BloomFalsePositiveRate >= 1 {
+		return nil, errors.New("bloom filter false positive rate must be between 0 and 1")
+	}
+
+	if config.EvictionPolicy == NoEviction && (config.WriteBufferSize > 0 || config.SetCoalesceWindow > 0) {
+		return nil, errors.New("write buffers and set coalescing are not supported by the NoEviction policy")
+	}
+
+	if pacing := config.CleanupPacing; pacing != nil &&
+		(pacing.MaxRemovalsPerCycle < 0 || pacing.MaxRemovalsPerSecond < 0 || pacing.MaxLockHold < 0) {
+		return nil, errors.New("cleanup pacing must not be negative")
+	}
+
+	if config.CleanupDisabled && config.ExpiryResolution > 0 {
+		return nil, errors.New("expiry resolution requires the periodic cleanup, which is disabled")
+	}
+
+	for _, quota := range config.Namespaces {
+		if quota.MaxItems < 0 || quota.MaxBytes < 0 {
+			return nil, errors.New("namespace quotas must not be negative")
+		}
+	}
+	if config.EvictionPolicy == NoEviction && len(config.Namespaces) > 0 {
+		return nil, errors.New("namespace quotas are not supported by the NoEviction policy")
+	}
+
+	if config.LoadRetries < 0 {
+		return nil, errors.New("load retries must not be negative")
+	}
+
+	if config.MaxDependencyDepth < 0 {
+		return nil, errors.New("max dependency depth must not be negative")
+	}
+
+	if config.TombstoneTTL < 0 {
+		return nil, errors.New("tombstone ttl must not be negative")
+	}
+
+	if config.AuditQueueSize < 0 {
+		return nil, errors.New("audit queue size must not be negative")
+	}
+	if config.AuditSink == nil {
+		config.AuditQueueSize = 0
+	}
+
+	if config.MaxCost < 0 {
+		return nil, errors.New("max cost must not be negative")
+	}
+	if config.EvictionPolicy == NoEviction && config.MaxCost > 0 {
+		return nil, errors.New("max cost is not supported by the NoEviction policy")
+	}
+	if config.MaxMemory < 0 {
+		return nil, errors.New("max memory must not be negative")
+	}
+	if config.MaxMemory > 0 && config.MaxCost > 0 {
+		return nil, errors.New("max memory and max cost must not be specified both")
+	}
+	if config.EvictionPolicy == NoEviction && config.MaxMemory > 0 {
+		return nil, errors.New("max memory is not supported by the NoEviction policy")
+	}
+	if config.VictimRatio < 0 || config.VictimRatio >= 1 {
+		return nil, errors.New("victim ratio must be at least 0 and less than 1")
+	}
+	if config.EvictionPolicy == NoEviction && config.VictimRatio > 0 {
+		return nil, errors.New("victim ratio is not supported by the NoEviction policy")
+	}
+	if config.GhostRatio < 0 || config.GhostRatio > 1 {
+		return nil, errors.New("ghost ratio must be at least 0 and at most 1")
+	}
+	if config.EvictionPolicy == NoEviction && config.GhostRatio > 0 {
+		return nil, errors.New("ghost ratio is not supported by the NoEviction policy")
+	}
+
+	if !customShardId && config.ShardStrategy == ConsistentHashSharding {
+		config.GenerateShardId = NewConsistentHashShardId[K](config.MaxShards, config.VirtualNodes)
+	}
+	if customShardId {
+		config.GenerateShardId = boundShardId(config.GenerateShardId)
+	}
+	if config.NormalizeKey != nil {
+		config.GenerateKey = normalizedGenerateKey(config.GenerateKey, config.NormalizeKey)
+	}
+
 	cache = &LRUCache[K, V]{
 		ctx: ctx,
 
 		maxShards: config.MaxShards,
-		maxItems:  config.MaxItems,
 
-		loggingOn: config.LoggingOn,
+		loggingOn:   !config.LoggingDisabled,
+		telemetryOn: !config.TelemetryDisabled,
+		callbacksOn: !config.CallbacksDisabled,
+
+		onShardPurge: config.OnShardPurge,
+
+		defaultTTL:       config.DefaultTTL,
+		cleanupInterval:  config.CleanupInterval,
+		expiryResolution: config.ExpiryResolution,
+
+		cleanupPacing: config.CleanupPacing,
 
-		expiryDurationInSeconds:  config.ExpiryDurationInSeconds,
-		cleanupDurationInSeconds: config.CleanupDurationInSeconds,
+		maxValueBytes:        config.MaxValueBytes,
+		oversizedPassThrough: config.OversizedPassThrough,
+
+		copyOnRead:  config.CopyOnRead,
+		copyOnWrite: config.CopyOnWrite,
+		clone:       config.Clone,
+
+		exportBatchSize: max(config.ExportBatchSize, 1),
+
+		snapshotPolicy: config.Snapshot,
 
 		generateKey:     config.GenerateKey,
 		generateShardId: config.GenerateShardId,
 
-		status:                Opened,
-		isCleanupTickerActive: true,
-		isCleanupActive:       make(chan bool),
+		admit: config.Admit,
+
+		ttlFunc: config.TTLFunc,
+
+		normalize: config.NormalizeKey,
+
+		namespaces: newNamespaces(config.Namespaces, config.MaxShards),
+
+		shardTuningOn: config.ShardTuningOn,
+		debugChecks:   config.DebugChecks,
+
+		evictionPolicy: config.EvictionPolicy,
+
+		config:        *config,
+		customShardId: customShardId,
 
 		shards: make([]*lruCacheShard[K, V], config.MaxShards),
+
+		expiredKeys: make(chan K, max(config.ExpiredKeysBuffer, 0)),
+
+		keyLocks:    make([]sync.Mutex, max(config.KeyLockStripes, 1)),
+		keyLockSeed: maphash.MakeSeed(),
+
+		dependencies: newDependencyGraph[K](config.MaxDependencyDepth),
+
+		tombstoneTTL: config.TombstoneTTL,
+
+		resolver:    config.Resolver,
+		hybridClock: &hybridClock{},
+
+		loader: newLoader[K, V](config),
+
+		telemetry: newTelemetry(),
+	}
+
+	cache.maxItems.Store(config.MaxItems)
+
+	if config.AuditSink != nil {
+		cache.auditLog = newAuditLog(config.AuditSink, config.AuditQueueSize)
+	}
+
+	if config.VictimRatio > 0 {
+		cache.victims = newVictimCache[K, V](config.VictimRatio, &cache.maxItems)
+	}
+	if config.GhostRatio > 0 {
+		cache.ghosts = newGhostList(config.GhostRatio, &cache.maxItems)
+	}
+
+	if config.CleanupPacing != nil && config.CleanupPacing.MaxRemovalsPerSecond > 0 {
+		cache.cleanupLimiter = newRemovalLimiter(config.CleanupPacing.MaxRemovalsPerSecond)
 	}
 
 	for shardId := range cache.shards {
-		cache.shards[shardId] = newLRUCacheShard[K, V](config, int64(shardId))
+		cache.shards[shardId] = newLRUCacheShard[K, V](config, int64(shardId), &cache.clock)
+		cache.shards[shardId].expiredKeys = cache.expiredKeys
+		cache.shards[shardId].dependencies = cache.dependencies
+		if cache.resolver != nil {
+			cache.shards[shardId].hybridClock = cache.hybridClock
+		}
+		cache.shards[shardId].victims = cache.victims
+		cache.shards[shardId].ghosts = cache.ghosts
+		if cache.namespaces != nil {
+			cache.shards[shardId].namespaces = cache.namespaces
+			cache.shards[shardId].namespaceUsage = make([]namespaceUsage, len(cache.namespaces.list))
+		}
+	}
+
+	if config.SetCoalesceWindow > 0 {
+		cache.setCoalescers = make([]*setCoalescer[K, V], config.MaxShards)
+		for shardId := range cache.setCoalescers {
+			cache.setCoalescers[shardId] = newSetCoalescer(config.SetCoalesceWindow, cache.shards[shardId],
+				func(key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource) {
+					cache.inFlight.Add(1)
+					defer cache.inFlight.Add(-1)
+
+					if cache.Status() == Started || cache.closing.Load() {
+						ctx := WithSource(context.Background(), source)
+						if _, err := cache.storeLocked(ctx, int64(shardId), key, value, ttl, meta); err == nil {
+							cache.trace(TraceSet, key, len(value))
+						}
+					}
+				},
+				cache.evictOverflow,
+			)
+		}
+	}
+
+	cache.writeBufferReadYourWrites = config.WriteBufferReadYourWrites
+	if config.WriteBufferSize > 0 {
+		cache.writeBuffers = make([]*writeBuffer[K, V], config.MaxShards)
+		for shardId := range cache.writeBuffers {
+			cache.writeBuffers[shardId] = newWriteBuffer[K, V](config.WriteBufferSize)
+		}
+		cache.writeSignal = make(chan struct{}, 1)
+
+		var applierCtx context.Context
+		applierCtx, cache.writeApplierCancel = context.WithCancel(ctx)
+		cache.writeApplierDone = make(chan struct{})
+		go cache.writeApplier(applierCtx, cache.writeApplierDone)
 	}
 
 	cache.Start()
 
-	go cache.cleanupTicker()
+	if !config.CleanupDisabled {
+		cache.StartCleanup()
+	}
+
+	if policy := cache.snapshotPolicy; policy != nil && policy.Store != nil {
+		if policy.RestoreOnStart {
+			if count, err := cache.restoreSnapshot(); err != nil && cache.loggingOn {
+				log.Printf("%s: restoreSnapshot - %v", LibraryName, err)
+			} else if count > 0 && cache.loggingOn {
+				log.Printf("cache restored %d items from the latest snapshot.", count)
+			}
+		}
+		if policy.Interval > 0 {
+			var snapshotCtx context.Context
+			snapshotCtx, cache.snapshotCancel = context.WithCancel(ctx)
+			cache.snapshotDone = make(chan struct{})
+			go cache.snapshotTicker(snapshotCtx, cache.snapshotDone)
+		}
+	}
+
+	if policy := config.WAL; policy != nil {
+		count, last, err := cache.replayWAL(policy.Dir)
+		if err != nil {
+			cache.Close()
+			return nil, err
+		}
+		if count > 0 && cache.loggingOn {
+			log.Printf("cache replayed %d records of the write-ahead log.", count)
+		}
+
+		if cache.wal, err = openWAL(policy.Dir, last+1); err != nil {
+			cache.Close()
+			return nil, err
+		}
+		if count > 0 {
+			if err = cache.CompactWAL(); err != nil && cache.loggingOn {
+				log.Printf("%s: compactWAL - %v", LibraryName, err)
+			}
+		}
+
+		var walCtx context.Context
+		walCtx, cache.walCancel = context.WithCancel(ctx)
+		cache.walDone = make(chan struct{})
+		go cache.walTicker(walCtx, cache.walDone, policy.syncInterval(), policy.CompactInterval)
+	}
 
 	return cache, nil
 }
 
-// cleanupStart activates the cache cleanup process.
-func (cache *LRUCache[K, V]) cleanupStart() {
-	cache.isCleanupActive <- true
+// StartCleanup starts the periodic cleanup of the expired cache items, if it is not running yet and the cache isn't
+// closed. The cleanup is started by NewLRUCache, unless CleanupDisabled is set, and stops once the context of the cache
+// is done or the cache is closed.
+func (cache *LRUCache[K, V]) StartCleanup() {
+	cache.cleanupMutex.Lock()
+	defer cache.cleanupMutex.Unlock()
+
+	if cache.Status() == Closed || (cache.cleanupCancel != nil && cache.cleanupRunning.Load()) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(cache.ctx)
+	cache.cleanupCancel = cancel
+	cache.cleanupDone = make(chan struct{})
+
+	cache.cleanupRunning.Store(true)
+	go cache.cleanupTicker(ctx, cache.cleanupDone)
 }
 
-// cleanupStop deactivates the cache cleanup process.
-func (cache *LRUCache[K, V]) cleanupStop() {
-	cache.isCleanupActive <- false
+// StopCleanup stops the periodic cleanup of the expired cache items and waits for a running cleanup to finish. It can
+// be started again by StartCleanup.
+func (cache *LRUCache[K, V]) StopCleanup() {
+	cache.cleanupMutex.Lock()
+	cancel, done := cache.cleanupCancel, cache.cleanupDone
+	cache.cleanupCancel, cache.cleanupDone = nil, nil
+	cache.cleanupMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// IsCleanupRunning checks if the periodic cleanup of the expired cache items is running.
+//
+// Returns:
+//   - running: A boolean indicating whether the cleanup is running.
+func (cache *LRUCache[K, V]) IsCleanupRunning() (running bool) {
+	return cache.cleanupRunning.Load()
 }
 
-// cleanupTicker handles the periodic cleanup of the cache.
-func (cache *LRUCache[K, V]) cleanupTicker() {
-	ticker := time.NewTicker(time.Second * time.Duration(cache.cleanupDurationInSeconds))
+// cleanupTicker handles the periodic cleanup of the cache, until the specified context is done.
+func (cache *LRUCache[K, V]) cleanupTicker(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer cache.cleanupRunning.Store(false)
+
+	ticker := time.NewTicker(cache.cleanupInterval)
+	defer ticker.Stop()
+
+	var wheelTick <-chan time.Time
+	if cache.expiryResolution > 0 {
+		wheelTicker := time.NewTicker(cache.expiryResolution)
+		defer wheelTicker.Stop()
+		wheelTick = wheelTicker.C
+	}
 
 	if cache.loggingOn {
 		log.Println("cache started the cleanup process.")
@@ -165,17 +596,24 @@ func (cache *LRUCache[K, V]) cleanupTicker() {
 
 	for {
 		select {
-		case active := <-cache.isCleanupActive:
-			cache.isCleanupTickerActive = active
+		case now := <-wheelTick:
+			cache.advanceWheels(now)
 		case <-ticker.C:
-			if cache.isCleanupTickerActive {
-				cache.cleanupShards()
-				if cache.loggingOn {
-					log.Println("cache shards are cleaned up.")
+			if cache.expiryResolution <= 0 {
+				_, _ = cache.cleanupShards(ctx)
+			}
+			if cache.debugChecks {
+				if err := cache.CheckInvariants(); err != nil {
+					panic(err.Error())
+				}
+			}
+			if cache.loggingOn {
+				log.Println("cache shards are cleaned up.")
+				if cache.shardTuningOn {
+					cache.logShardRecommendation()
 				}
 			}
-		case <-cache.ctx.Done():
-			ticker.Stop()
+		case <-ctx.Done():
 			if cache.loggingOn {
 				log.Println("cache stopped the cleanup process.")
 			}
@@ -184,9 +622,19 @@ func (cache *LRUCache[K, V]) cleanupTicker() {
 	}
 }
 
-// cleanupShards handles the periodic cleanup of the cache shards.
-func (cache *LRUCache[K, V]) cleanupShards() {
+// cleanupShards handles the periodic cleanup of the cache shards, until the context is done. The shards are cleaned up
+// concurrently, a shard is skipped once the context is done. With the cleanup pacing the shards are swept in steps
+// within the budget of the pacing.
+func (cache *LRUCache[K, V]) cleanupShards(ctx context.Context) (removed int64, err error) {
 	var wg sync.WaitGroup
+	var evicted atomic.Int64
+	var skipped atomic.Bool
+
+	var budget *atomic.Int64
+	if cache.cleanupPacing != nil && cache.cleanupPacing.MaxRemovalsPerCycle > 0 {
+		budget = &atomic.Int64{}
+		budget.Store(cache.cleanupPacing.MaxRemovalsPerCycle)
+	}
 
 	for shardId := range cache.shards {
 		wg.Add(1)
@@ -194,15 +642,77 @@ func (cache *LRUCache[K, V]) cleanupShards() {
 		go func(shardId int) {
 			defer wg.Done()
 
+			if ctx.Err() != nil {
+				skipped.Store(true)
+				return
+			}
+
+			if cache.cleanupPacing != nil {
+				evictCount, err := cache.sweepShard(ctx, cache.shards[shardId], budget)
+				if err != nil {
+					skipped.Store(true)
+				}
+				evicted.Add(evictCount)
+				return
+			}
+
 			cache.shards[shardId].Lock()
 			evictCount := cache.shards[shardId].CleanupShard()
+			cache.len.Add(-evictCount)
 			cache.shards[shardId].Unlock()
 
-			cache.len.Add(evictCount)
+			evicted.Add(evictCount)
 		}(shardId)
 	}
 
 	wg.Wait()
+
+	if skipped.Load() {
+		return evicted.Load(), ctx.Err()
+	}
+
+	return evicted.Load(), nil
+}
+
+// advanceWheels advances the timing wheels of all shards to the specified time and removes the expired cache items.
+func (cache *LRUCache[K, V]) advanceWheels(now time.Time) {
+	for _, shard := range cache.shards {
+		shard.Lock()
+		cache.len.Add(-shard.AdvanceWheel(now))
+		shard.Unlock()
+	}
+}
+
+// ExpiredKeys returns a channel, which receives the keys of the cache items removed because their TTL (time to live)
+// expired. Evicted, deleted or replaced cache items are not reported. The channel is bounded by ExpiredKeysBuffer; if it
+// is full, the oldest key is dropped, so it is safe to leave the channel unconsumed. The keys are reported, once the
+// cleanup or the timing wheel removes the expired cache items.
+//
+// Returns:
+//   - keys: The channel receiving the expired keys.
+//
+// Example Usage:
+//
+//	go func() {
+//	    for key := range cache.ExpiredKeys() {
+//	        recompute(key)
+//	    }
+//	}()
+func (cache *LRUCache[K, V]) ExpiredKeys() (keys <-chan K) {
+	return cache.expiredKeys
+}
+
+// ExpiryAccuracy returns the maximum time an expired cache item stays in the cache until it is removed, i.e. the expiry
+// resolution if the timing wheels are enabled, otherwise the cleanup interval. Expired cache items are never served.
+//
+// Returns:
+//   - accuracy: The maximum delay of the removal of an expired cache item.
+func (cache *LRUCache[K, V]) ExpiryAccuracy() (accuracy time.Duration) {
+	if cache.expiryResolution > 0 {
+		return cache.expiryResolution
+	}
+
+	return cache.cleanupInterval
 }
 
 // Status returns the current status of the cache (Opened, Started, Stopped, Closed).
@@ -210,12 +720,14 @@ func (cache *LRUCache[K, V]) cleanupShards() {
 // Returns:
 //   - status: The current status of the cache.
 func (cache *LRUCache[K, V]) Status() (status CacheStatus) {
-	return cache.status
+	return CacheStatus(cache.status.Load())
 }
 
-// Start activates the cache, allowing operations to proceed.
+// Start activates the cache, allowing operations to proceed. A closed cache can't be started again.
 func (cache *LRUCache[K, V]) Start() {
-	cache.status = Started
+	if !cache.setStatus(Started) {
+		return
+	}
 	if cache.loggingOn {
 		log.Println("cache is started.")
 	}
@@ -223,12 +735,27 @@ func (cache *LRUCache[K, V]) Start() {
 
 // Stop deactivates the cache, disallowing operations to proceed.
 func (cache *LRUCache[K, V]) Stop() {
-	cache.status = Stopped
+	if !cache.setStatus(Stopped) {
+		return
+	}
 	if cache.loggingOn {
 		log.Println("cache is stopped.")
 	}
 }
 
+// setStatus sets the status of the cache and reports whether it was set, which it isn't, if the cache is closed.
+func (cache *LRUCache[K, V]) setStatus(status CacheStatus) (set bool) {
+	for {
+		current := cache.status.Load()
+		if CacheStatus(current) == Closed {
+			return false
+		}
+		if cache.status.CompareAndSwap(current, int64(status)) {
+			return true
+		}
+	}
+}
+
 // MaxShards returns the configured maximum number of shards in the cache.
 //
 // Returns:
@@ -242,19 +769,70 @@ func (cache *LRUCache[K, V]) MaxShards() (shards int64) {
 // Returns:
 //   - items: The configured maximum number of items that the cache can hold.
 func (cache *LRUCache[K, V]) MaxItems() (items int64) {
-	return cache.maxItems
+	return cache.maxItems.Load()
+}
+
+// Resize changes the maximum number of items that can be stored in the cache. If the cache holds more items than the
+// new maximum, the oldest (least recently used) cache items are evicted right away.
+//
+// Parameters:
+//   - maxItems: The new maximum number of items that the cache can hold.
+//
+// Returns:
+//   - evicted: The number of cache items that were evicted.
+//   - err: An error if the cache is stopped or closed, if the maximum number of items is not positive, or if any other
+//     issue occurs.
+//
+// Example Usage:
+//
+//	evicted, err := cache.Resize(500000)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Resize(maxItems int64) (evicted int, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method Resize()")
+	}
+
+	if maxItems < 1 {
+		return 0, errors.New("cache maximum number of items must be positive")
+	}
+
+	cache.maxItems.Store(maxItems)
+
+	for shardId := range cache.shards {
+		cache.shards[shardId].Lock()
+		cache.shards[shardId].maxItems = maxItems
+		cache.shards[shardId].Unlock()
+	}
+
+	if excess := cache.len.Load() - maxItems; excess > 0 {
+		evicted = len(cache.removeOldest(int(excess)))
+	}
+
+	return evicted, nil
 }
 
-// Len returns the current number of cache items in the cache.
+// Len returns the current number of cache items in the cache. The overflow of a Set, which is applied concurrently, is
+// evicted first, so that the number never exceeds the capacity of the cache, see evictOverflow.
 //
 // Returns:
 //   - len: The current number of cache items in the cache.
 func (cache *LRUCache[K, V]) Len() (len int64) {
+	cache.evictOverflow()
+
 	return cache.len.Load()
 }
 
 // Set adds a key-value pair to the cache.
 // If the key wasn't specified, it is generated automatically based on the specified value.
+// The cache item expires after the duration derived by the TTLFunc or after the default duration time, if any.
 // This operation does updates the recent-ness of the cache item.
 //
 // Parameters:
@@ -263,7 +841,8 @@ func (cache *LRUCache[K, V]) Len() (len int64) {
 //
 // Returns:
 //   - returnKey: The key that was used for the cache item.
-//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//   - err: An error if the cache is stopped or closed, ErrCacheFull if the cache is full with the NoEviction policy, or
+//     if any other issue occurs.
 //
 // Example Usage:
 //
@@ -279,131 +858,555 @@ func (cache *LRUCache[K, V]) Len() (len int64) {
 func (cache *LRUCache[K, V]) Set(key K, value V) (returnKey K, err error) {
 	var k K
 
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return k, errors.New("cache is closed")
+		return k, ErrClosed
 	case Stopped:
 		return k, errors.New("cache is stopped, must be started before calling method Set()")
 	}
 
-	if key == "" {
-		key = cache.generateKey(value)
-	}
-
-	var ttl time.Time
-
-	shardId := cache.generateShardId(key, cache.maxItems)
+	key = cache.normalizeKey(key)
 
-	cache.shards[shardId].Lock()
-	evicted, _ := cache.shards[shardId].Set(cache.len.Load(), key, value, ttl)
-	cache.shards[shardId].Unlock()
-    if evicted {
-        cache.len.Add(-1)
-    }
-	cache.len.Add(1)
+	returnKey, _, err = cache.set(key, value, cache.expiresAt(key, value, 0), nil)
 
-	return key, nil
+	return returnKey, err
 }
 
 // SetWithTTL adds a key-value pair to the cache with a specific TTL (time to live).
 // If the key wasn't specified, it is generated automatically based on the specified value.
-// If the duration wasn't specified, it uses the default duration time.
+// If the duration wasn't specified, it uses the default duration time. If the duration is NoExpiry, or if it wasn't
+// specified and the default duration time is zero or negative, the cache item never expires.
+// The duration is a time.Duration, not a number of seconds like before: SetWithTTL(k, v, 30) means 30 nanoseconds, so
+// positive durations below MinTTL are rejected with ErrTTLTooShort. Use SetWithTTLInSeconds for a number of seconds.
 // This operation does updates the recent-ness of the cache item.
 //
 // Parameters:
 //   - key: The key to associate with the value.
 //   - value: The value to store in the cache.
-//   - duration: The time-to-live (TTL) for the cache entry in seconds.
+//   - duration: The time-to-live (TTL) for the cache entry.
 //
 // Returns:
 //   - returnKey: The key that was used for the cache item.
-//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//   - err: An error if the cache is stopped or closed, ErrTTLTooShort if the duration is below MinTTL, ErrCacheFull if
+//     the cache is full with the NoEviction policy, or if any other issue occurs.
 //
 // Example Usage:
 //
-//	_, err := cache.SetWithTTL("my-key", []byte("my-value"), 3600)
+//	_, err := cache.SetWithTTL("my-key", []byte("my-value"), time.Hour)
 //	if err != nil {
 //	    panic(err)
 //	}
 //
-//	key, err := cache.SetWithTTL("", []byte("my-value"), 3600)
+//	key, err := cache.SetWithTTL("", []byte("my-value"), time.Millisecond*500)
 //	if err != nil {
 //	    panic(err)
 //	}
-func (cache *LRUCache[K, V]) SetWithTTL(key K, value V, duration uint) (returnKey K, err error) {
+//
+//	_, err := cache.SetWithTTL("my-key", []byte("my-value"), sq_cache.NoExpiry)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error) {
 	var k K
 
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return k, errors.New("cache is closed")
+		return k, ErrClosed
 	case Stopped:
 		return k, errors.New("cache is stopped, must be started before calling method SetWithTTL()")
 	}
 
-	if key == "" {
-		key = cache.generateKey(value)
-	}
-
-	var ttl time.Time
-	now := time.Now()
-	if duration > 0 {
-		ttl = now.Add(time.Duration(duration) * time.Second)
-	} else {
-		ttl = now.Add(time.Duration(cache.expiryDurationInSeconds) * time.Second)
+	if err = checkTTL(duration); err != nil {
+		return k, err
 	}
 
-	shardId := cache.generateShardId(key, cache.maxItems)
+	key = cache.normalizeKey(key)
 
-	cache.shards[shardId].Lock()
-	evicted, _ := cache.shards[shardId].Set(cache.len.Load(), key, value, ttl)
-	cache.shards[shardId].Unlock()
-    if evicted {
-        cache.len.Add(-1)
-    }
-	cache.len.Add(1)
+	returnKey, _, err = cache.set(key, value, cache.expiresAt(key, value, duration), nil)
 
-	return key, nil
+	return returnKey, err
 }
 
-// Get retrieves a value by the specified key from the cache.
-// This operation does updates the recent-ness of the cache item.
+// SetContext adds a key-value pair like SetWithTTL, and attributes the Set to the actor and the origin of the context
+// in the audit log (see WithActor).
 //
 // Parameters:
-//   - key: The key associated with the value to retrieve.
+//   - ctx: The context of the Set.
+//   - key: The key under which the value is stored, a key is generated from the value if empty.
+//   - value: The value to store.
+//   - duration: The time to live of the value, zero falls back to the TTLFunc or the default TTL.
 //
 // Returns:
-//   - value: The value associated with the key if found.
-//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//   - returnKey: The key of the cache item.
+//   - err: An error if the cache is stopped or closed, ErrTTLTooShort if the duration is below MinTTL, or if any other
+//     issue occurs.
 //
 // Example Usage:
 //
-//	value, err := cache.Get("my-key")
-//	if err != nil {
-//	    panic(err)
-//	}
-func (cache *LRUCache[K, V]) Get(key K) (value V, err error) {
-	var v V
+//	_, err := cache.SetContext(sq_cache.WithActor(ctx, "billing-service"), "invoice:42", invoice, time.Hour)
+func (cache *LRUCache[K, V]) SetContext(
+	ctx context.Context, key K, value V, duration time.Duration,
+) (returnKey K, err error) {
+	var k K
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
 
 	switch cache.Status() {
 	case Closed:
-		return v, errors.New("cache is closed")
+		return k, ErrClosed
 	case Stopped:
-		return v, errors.New("cache is stopped, must be started before calling method Get()")
+		return k, errors.New("cache is stopped, must be started before calling method SetContext()")
+	}
+
+	if err = checkTTL(duration); err != nil {
+		return k, err
 	}
 
-	shardId := cache.generateShardId(key, cache.maxItems)
+	key = cache.normalizeKey(key)
 
-	cache.shards[shardId].Lock()
-	defer cache.shards[shardId].Unlock()
-	value, _ = cache.shards[shardId].Get(key)
+	returnKey, _, err = cache.setContext(ctx, key, value, cache.expiresAt(key, value, duration), nil)
 
-	return value, nil
+	return returnKey, err
 }
 
-// Contains checks if a specified key exists in the cache.
-// This operation doesn't updates the recent-ness of the cache item.
-//
-// Parameters:
+// normalizeKey returns the canonical form of the specified key, if a NormalizeKey function was specified. Empty keys
+// are returned as is, as they are generated from the values.
+func (cache *LRUCache[K, V]) normalizeKey(key K) K {
+	if cache.normalize == nil || key == "" {
+		return key
+	}
+
+	return cache.normalize(key)
+}
+
+// expiresAt returns the expiry time for the specified duration. If the duration wasn't specified, it falls back to the
+// duration derived by the TTLFunc and then to the default duration. A zero time is returned if the cache item never
+// expires.
+func (cache *LRUCache[K, V]) expiresAt(key K, value V, duration time.Duration) (ttl time.Time) {
+	if duration == 0 && cache.ttlFunc != nil {
+		if key == "" {
+			key = cache.generateKey(value)
+		}
+		duration = cache.ttlFunc(key, value)
+	}
+	if duration == 0 {
+		duration = cache.defaultTTL
+	}
+	if duration <= 0 {
+		return ttl
+	}
+
+	return timeNow().Add(duration)
+}
+
+// checkTTL checks that the specified duration is either not specified, NoExpiry (or any negative duration), or at least
+// MinTTL, as the Sets with a duration took seconds before and a number of seconds passed as a time.Duration is a few
+// nanoseconds.
+func checkTTL(duration time.Duration) (err error) {
+	if duration > 0 && duration < MinTTL {
+		return fmt.Errorf("%w: %v", ErrTTLTooShort, duration)
+	}
+
+	return nil
+}
+
+// SetWithTTLInSeconds adds a key-value pair to the cache with a specific TTL (time to live) in seconds.
+//
+// Deprecated: Use SetWithTTL with a time.Duration instead.
+func (cache *LRUCache[K, V]) SetWithTTLInSeconds(key K, value V, duration uint) (returnKey K, err error) {
+	return cache.SetWithTTL(key, value, time.Duration(duration)*time.Second)
+}
+
+// set adds a key-value pair with a specific TTL (time to live) and metadata to the cache, after the value passed the size
+// limit and was admitted by the admit hook. Values which are vetoed by the admit hook, or which exceed the size limit in
+// the pass-through mode, are silently not cached, and the cached value of the key is removed, so that it isn't read
+// stale. If the key wasn't specified, it is generated automatically based on the specified value. The metadata of an
+// existing cache item is kept, if no metadata was specified.
+func (cache *LRUCache[K, V]) set(key K, value V, ttl time.Time, meta *EntryMeta) (returnKey K, evicted bool, err error) {
+	return cache.setContext(context.Background(), key, value, ttl, meta)
+}
+
+// setContext adds a key-value pair like set, and audits the Set with the actor and the origin of the context.
+func (cache *LRUCache[K, V]) setContext(
+	ctx context.Context, key K, value V, ttl time.Time, meta *EntryMeta,
+) (returnKey K, evicted bool, err error) {
+	var k K
+
+	if key == "" {
+		key = cache.generateKey(value)
+	}
+
+	cacheable, err := cache.cacheable(key, value)
+	if err != nil {
+		return k, false, err
+	}
+	if !cacheable {
+		cache.remove(ctx, key)
+		return key, false, nil
+	}
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	value = cache.writeValue(value)
+
+	if cache.setCoalescers != nil {
+		evicted, err = cache.coalesce(ctx, shardId, key, value, ttl, meta)
+	} else {
+		evicted, err = cache.store(ctx, shardId, key, value, ttl, meta)
+	}
+	if err != nil {
+		return k, false, err
+	}
+	cache.audit(ctx, AuditSet, key)
+
+	return key, evicted, nil
+}
+
+// store adds a key-value pair with a specific TTL (time to live) and metadata to the specified shard, and reports
+// whether any cache item was evicted. If the write buffers are enabled, the key-value pair is buffered instead, as long
+// as the buffer of the shard isn't full. The context is passed to the context callbacks of the shard.
+func (cache *LRUCache[K, V]) store(
+	ctx context.Context, shardId int64, key K, value V, ttl time.Time, meta *EntryMeta,
+) (evicted bool, err error) {
+	source := sourceFrom(ctx)
+
+	if cache.writeBuffers != nil {
+		if cache.writeBuffers[shardId].push(key, value, ttl, meta, source) {
+			cache.signalWrites()
+			return false, nil
+		}
+	}
+
+	cache.shards[shardId].LockMeasured()
+	evictCount, err := cache.storeLocked(ctx, shardId, key, value, ttl, meta)
+	cache.shards[shardId].Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	cache.evictOverflow()
+	cache.trace(TraceSet, key, len(value))
+
+	return evictCount > 0, nil
+}
+
+// coalesce adds a key-value pair like store, unless the Set is coalesced into the open window of the key. The Set,
+// which opens the window, is applied right away with the lock of the shard held, so that the window can't be flushed
+// before. The write buffers are bypassed.
+func (cache *LRUCache[K, V]) coalesce(
+	ctx context.Context, shardId int64, key K, value V, ttl time.Time, meta *EntryMeta,
+) (evicted bool, err error) {
+	cache.shards[shardId].LockMeasured()
+	if cache.setCoalescers[shardId].hold(key, value, ttl, meta, sourceFrom(ctx)) {
+		cache.shards[shardId].Unlock()
+		return false, nil
+	}
+	evictCount, err := cache.storeLocked(ctx, shardId, key, value, ttl, meta)
+	if err != nil {
+		cache.setCoalescers[shardId].discard(key)
+	}
+	cache.shards[shardId].Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	cache.evictOverflow()
+	cache.trace(TraceSet, key, len(value))
+
+	return evictCount > 0, nil
+}
+
+// storeLocked adds a key-value pair with a specific TTL (time to live) and metadata to the specified shard, after
+// applying the buffered Sets of the shard, and returns the number of the evicted cache items. Must be called with the
+// lock of the shard held.
+func (cache *LRUCache[K, V]) storeLocked(
+	ctx context.Context, shardId int64, key K, value V, ttl time.Time, meta *EntryMeta,
+) (evictCount int64, err error) {
+	if cache.writeBuffers != nil {
+		evictCount = cache.applyWrites(shardId)
+	}
+	reserved, err := cache.reserve(cache.shards[shardId], key)
+	if err == nil && faultEvictError() != nil && cache.evicts(cache.shards[shardId], key) {
+		err = faultEvictError()
+	}
+	if err != nil {
+		return evictCount, err
+	}
+	cache.shards[shardId].opCtx, cache.shards[shardId].opSource = ctx, sourceFrom(ctx)
+	setEvictCount, added := cache.shards[shardId].Set(cache.len.Load(), key, value, ttl, meta)
+	cache.shards[shardId].opCtx, cache.shards[shardId].opSource = nil, SetSource
+	cache.walSet(key, value, ttl, meta)
+	if added && !reserved {
+		cache.len.Add(1)
+	}
+	cache.len.Add(-setEvictCount)
+
+	return evictCount + setEvictCount, nil
+}
+
+// reserve reserves the capacity for a new key of the shard with the NoEviction policy, and reports whether the length
+// of the cache was incremented for the key. Must be called with the lock of the shard held.
+func (cache *LRUCache[K, V]) reserve(shard *lruCacheShard[K, V], key K) (reserved bool, err error) {
+	if cache.evictionPolicy != NoEviction {
+		return false, nil
+	}
+	if _, found := shard.nodes.Get(key); found {
+		return false, nil
+	}
+
+	for {
+		len := cache.len.Load()
+		if len >= cache.maxItems.Load() {
+			return false, ErrCacheFull
+		}
+		if cache.len.CompareAndSwap(len, len+1) {
+			return true, nil
+		}
+	}
+}
+
+// evicts reports whether a Set of the specified key would evict cache items, as the key is new and the cache is at its
+// capacity. Must be called with the lock of the shard held.
+func (cache *LRUCache[K, V]) evicts(shard *lruCacheShard[K, V], key K) (evicts bool) {
+	if _, found := shard.nodes.Get(key); found {
+		return false
+	}
+
+	return cache.len.Load() >= cache.maxItems.Load()
+}
+
+// cacheable checks whether a value passes the size limit and is admitted by the admit hook. Oversized values are
+// rejected with ErrValueTooLarge, unless the pass-through mode is enabled.
+func (cache *LRUCache[K, V]) cacheable(key K, value V) (cacheable bool, err error) {
+	if cache.maxValueBytes > 0 && int64(len(value)) > cache.maxValueBytes {
+		if cache.telemetryOn {
+			cache.telemetry.incrementCounter(Oversized)
+		}
+		if cache.oversizedPassThrough {
+			return false, nil
+		}
+		return false, ErrValueTooLarge
+	}
+
+	if cache.admit != nil && !cache.admit(key, value) {
+		if cache.telemetryOn {
+			cache.telemetry.incrementCounter(Rejected)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Get retrieves a value by the specified key from the cache.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - err: ErrCorruptedEntry if the value doesn't match its checksum, an error if the cache is stopped or closed, or if
+//     any other issue occurs.
+//
+// Example Usage:
+//
+//	value, err := cache.Get("my-key")
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Get(key K) (value V, err error) {
+	var v V
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return v, ErrClosed
+	case Stopped:
+		return v, errors.New("cache is stopped, must be started before calling method Get()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+
+	value, _, err = cache.get(shardId, key)
+
+	return value, err
+}
+
+// get retrieves a value by the specified key from the specified shard. The key is looked up under the read lock of the
+// shard, the write lock is only taken if the recency of the cache item must be updated or its value is corrupted.
+func (cache *LRUCache[K, V]) get(shardId int64, key K) (value V, found bool, err error) {
+	shard := cache.shards[shardId]
+
+	cache.readYourWrites(shardId)
+
+	var corrupted bool
+	shard.RLockMeasured()
+	value, found, done := shard.GetRecent(key)
+	shard.RUnlock()
+	if !done {
+		shard.LockMeasured()
+		value, found, corrupted = shard.Get(key)
+		shard.Unlock()
+	}
+
+	if !found && !corrupted && cache.victims != nil {
+		value, _, found = cache.rescue(shardId, key)
+	}
+	if !found && !corrupted && cache.ghosts != nil {
+		cache.ghostMiss(key)
+	}
+
+	cache.trace(TraceGet, key, len(value))
+
+	if corrupted {
+		cache.len.Add(-1)
+		return value, false, ErrCorruptedEntry
+	}
+
+	return cache.readValue(value), found, nil
+}
+
+// GetWithTTL retrieves a value and its remaining TTL (time to live) by the specified key from the cache. The remaining
+// TTL is NoExpiry, if the cache item never expires.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - ttl: The remaining time-to-live (TTL) of the cache item if found.
+//   - found: A boolean indicating whether the key exists in the cache and is not expired.
+//   - err: ErrCorruptedEntry if the value doesn't match its checksum, an error if the cache is stopped or closed, or if
+//     any other issue occurs.
+//
+// Example Usage:
+//
+//	value, ttl, found, err := cache.GetWithTTL("my-key")
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error) {
+	var v V
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return v, 0, false, ErrClosed
+	case Stopped:
+		return v, 0, false, errors.New("cache is stopped, must be started before calling method GetWithTTL()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.readYourWrites(shardId)
+
+	cache.shards[shardId].LockMeasured()
+	value, expiresAt, found, corrupted := cache.shards[shardId].GetWithTTL(key)
+	cache.shards[shardId].Unlock()
+	if corrupted {
+		cache.len.Add(-1)
+		return v, 0, false, ErrCorruptedEntry
+	}
+	if !found && cache.victims != nil {
+		value, expiresAt, found = cache.rescue(shardId, key)
+	}
+	if !found && cache.ghosts != nil {
+		cache.ghostMiss(key)
+	}
+	if !found {
+		return v, 0, false, nil
+	}
+
+	if expiresAt.IsZero() {
+		return cache.readValue(value), NoExpiry, true, nil
+	}
+
+	ttl = time.Until(expiresAt)
+	if ttl <= 0 {
+		return v, 0, false, nil
+	}
+
+	return cache.readValue(value), ttl, true, nil
+}
+
+// GetOrLoad retrieves a value by the specified key from the cache. If the key is not found, the value is loaded by the
+// configured loader and added to the cache with the default TTL (time to live), if any. If a bulk loader is configured, the
+// concurrent loads within a short window are coalesced into a single batched load.
+// Concurrent loads of the same key are coalesced into a single load, each load is bounded by the configured timeout
+// and failed loads are retried with an exponential backoff. Once the loads of a key failed too often, further loads
+// of the key fail immediately for the configured circuit break duration. A corrupted cache item is evicted and loaded
+// again. The loaded cache items are attributed to the LoaderSource, the coalesced loads are counted by the
+// LoadCoalesced counter of the telemetry.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the load.
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key, either found or loaded.
+//   - err: An error if the cache is stopped or closed, if the load failed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	value, err := cache.GetOrLoad(ctx, "my-key")
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	var v V
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return v, ErrClosed
+	case Stopped:
+		return v, errors.New("cache is stopped, must be started before calling method GetOrLoad()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+
+	value, found, _ := cache.get(shardId, key)
+	if found {
+		return value, nil
+	}
+
+	value, err = cache.loader.Load(ctx, key)
+	if err != nil {
+		return v, err
+	}
+
+	ctx = WithSource(ctx, LoaderSource)
+	if _, _, err = cache.setContext(ctx, key, value, cache.expiresAt(key, value, 0), nil); err != nil {
+		return v, err
+	}
+
+	return value, nil
+}
+
+// Contains checks if a specified key exists in the cache.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Parameters:
 //   - key: The key to check for existence in the cache.
 //
 // Returns:
@@ -417,16 +1420,22 @@ func (cache *LRUCache[K, V]) Get(key K) (value V, err error) {
 //	    panic(err)
 //	}
 func (cache *LRUCache[K, V]) Contains(key K) (found bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return false, errors.New("cache is closed")
+		return false, ErrClosed
 	case Stopped:
 		return false, errors.New("cache is stopped, must be started before calling method Contains()")
 	}
 
-	shardId := cache.generateShardId(key, cache.maxItems)
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.readYourWrites(shardId)
 
-	cache.shards[shardId].RLock()
+	cache.shards[shardId].RLockMeasured()
 	defer cache.shards[shardId].RUnlock()
 	found = cache.shards[shardId].Contains(key)
 
@@ -452,28 +1461,148 @@ func (cache *LRUCache[K, V]) Contains(key K) (found bool, err error) {
 func (cache *LRUCache[K, V]) Peek(key K) (value V, err error) {
 	var v V
 
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return v, errors.New("cache is closed")
+		return v, ErrClosed
 	case Stopped:
 		return v, errors.New("cache is stopped, must be started before calling method Peek()")
 	}
 
-	shardId := cache.generateShardId(key, cache.maxItems)
+	key = cache.normalizeKey(key)
 
-	cache.shards[shardId].RLock()
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.readYourWrites(shardId)
+
+	cache.shards[shardId].RLockMeasured()
 	defer cache.shards[shardId].RUnlock()
 	value, _ = cache.shards[shardId].Peek(key)
 
-	return value, nil
+	return cache.readValue(value), nil
 }
 
-// Remove removes a key-value pair from the cache.
+// ContainsOrAdd checks if a specified key exists in the cache, and adds the key-value pair if it doesn't exist, under a
+// single lock of the shard.
+// This operation doesn't updates the recent-ness of an existing cache item.
+//
+// Parameters:
+//   - key: The key to check for existence in the cache.
+//   - value: The value to store in the cache, if the key doesn't exist.
+//
+// Returns:
+//   - existed: A boolean indicating whether the key existed in the cache.
+//   - evicted: A boolean indicating whether adding the key-value pair evicted any cache item.
+//   - err: An error if the cache is stopped or closed, if the value is too large, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	existed, evicted, err := cache.ContainsOrAdd("my-key", []byte("my-value"))
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) ContainsOrAdd(key K, value V) (existed, evicted bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return false, false, ErrClosed
+	case Stopped:
+		return false, false, errors.New("cache is stopped, must be started before calling method ContainsOrAdd()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	_, existed, evicted, err = cache.peekOrAdd(key, value)
+
+	return existed, evicted, err
+}
+
+// PeekOrAdd retrieves a value by the specified key from the cache, and adds the key-value pair if the key doesn't
+// exist, under a single lock of the shard.
+// This operation doesn't updates the recent-ness of an existing cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//   - value: The value to store in the cache, if the key doesn't exist.
+//
+// Returns:
+//   - previous: The value associated with the key, if the key existed.
+//   - existed: A boolean indicating whether the key existed in the cache.
+//   - err: An error if the cache is stopped or closed, if the value is too large, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	previous, existed, err := cache.PeekOrAdd("my-key", []byte("my-value"))
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) PeekOrAdd(key K, value V) (previous V, existed bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return previous, false, ErrClosed
+	case Stopped:
+		return previous, false, errors.New("cache is stopped, must be started before calling method PeekOrAdd()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	previous, existed, _, err = cache.peekOrAdd(key, value)
+
+	return cache.readValue(previous), existed, err
+}
+
+// peekOrAdd retrieves a value by the specified key from the cache, and adds the key-value pair if the key doesn't exist
+// and the value is cacheable, under a single lock of the shard. The added cache item expires like the ones of Set.
+func (cache *LRUCache[K, V]) peekOrAdd(key K, value V) (previous V, existed, evicted bool, err error) {
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.flushWrites(shardId)
+
+	cache.shards[shardId].RLockMeasured()
+	previous, existed = cache.shards[shardId].Peek(key)
+	cache.shards[shardId].RUnlock()
+	if existed {
+		return previous, true, false, nil
+	}
+
+	cacheable, err := cache.cacheable(key, value)
+	if err != nil || !cacheable {
+		return previous, false, false, err
+	}
+
+	cache.shards[shardId].LockMeasured()
+	reserved, err := cache.reserve(cache.shards[shardId], key)
+	if err != nil {
+		cache.shards[shardId].Unlock()
+		return previous, false, false, err
+	}
+	ttl := cache.expiresAt(key, value, 0)
+	value = cache.writeValue(value)
+	previous, existed, evictCount, added := cache.shards[shardId].PeekOrAdd(cache.len.Load(), key, value, ttl)
+	if added {
+		if !reserved {
+			cache.len.Add(1)
+		}
+		cache.walSet(key, value, ttl, nil)
+	}
+	cache.len.Add(-evictCount)
+	cache.shards[shardId].Unlock()
+
+	return previous, existed, evictCount > 0, nil
+}
+
+// Remove removes a key-value pair from the cache, together with the cache items depending on it (see DependOn).
 //
 // Parameters:
 //   - key: The key to remove from the cache.
 //
 // Returns:
+//   - removed: A boolean indicating whether the key was removed.
 //   - err: An error if the cache is stopped or closed, or if any other issue occurs.
 //
 // Example Usage:
@@ -483,22 +1612,214 @@ func (cache *LRUCache[K, V]) Peek(key K) (value V, err error) {
 //	    panic(err)
 //	}
 func (cache *LRUCache[K, V]) Remove(key K) (removed bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return removed, errors.New("cache is closed")
+		return removed, ErrClosed
 	case Stopped:
 		return removed, errors.New("cache is stopped, must be started before calling method Remove()")
 	}
 
-	shardId := cache.generateShardId(key, cache.maxItems)
+	return cache.removeWithDependents(context.Background(), key), nil
+}
+
+// RemoveContext removes a key-value pair like Remove, and attributes the removal to the actor and the origin of the
+// context in the audit log (see WithActor).
+//
+// Parameters:
+//   - ctx: The context of the removal.
+//   - key: The key to remove from the cache.
+//
+// Returns:
+//   - removed: A boolean indicating whether the key was removed.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	removed, err := cache.RemoveContext(sq_cache.WithActor(ctx, "billing-service"), "invoice:42")
+func (cache *LRUCache[K, V]) RemoveContext(ctx context.Context, key K) (removed bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return removed, ErrClosed
+	case Stopped:
+		return removed, errors.New("cache is stopped, must be started before calling method RemoveContext()")
+	}
+
+	return cache.removeWithDependents(ctx, key), nil
+}
+
+// removeWithDependents removes a key-value pair together with the cache items depending on it.
+func (cache *LRUCache[K, V]) removeWithDependents(ctx context.Context, key K) (removed bool) {
+	key = cache.normalizeKey(key)
+
+	dependents := cache.dependencies.dependentsOf(key)
+	removed = cache.remove(ctx, key)
+	for _, dependent := range dependents {
+		cache.remove(ctx, dependent)
+	}
+
+	return removed
+}
 
-	cache.shards[shardId].Lock()
+// remove removes a key-value pair from the cache, including its coalesced and buffered Sets, and audits the removal.
+func (cache *LRUCache[K, V]) remove(ctx context.Context, key K) (removed bool) {
+	shardId := cache.generateShardId(key, cache.maxShards)
+	if cache.setCoalescers != nil {
+		cache.setCoalescers[shardId].discard(key)
+	}
+	cache.flushWrites(shardId)
+
+	cache.shards[shardId].LockMeasured()
+	cache.shards[shardId].opCtx = ctx
 	removed = cache.shards[shardId].Remove(key)
+	cache.shards[shardId].opCtx = nil
+	if removed {
+		cache.len.Add(-1)
+		cache.walRemove(key)
+	}
 	cache.shards[shardId].Unlock()
 
-	cache.len.Add(-1)
+	cache.trace(TraceRemove, key, 0)
+	if removed {
+		cache.audit(ctx, AuditRemove, key)
+	}
 
-	return removed, nil
+	return removed
+}
+
+// RemoveOldest evicts the specified number of the oldest (least recently used) cache items from the cache, e.g. to shed
+// memory before running a large batch job. The cache items are evicted across all shards in the order of their last
+// access, while all shards are locked.
+//
+// Parameters:
+//   - n: The number of cache items to evict.
+//
+// Returns:
+//   - removed: The number of cache items that were evicted.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	removed, err := cache.RemoveOldest(1000)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) RemoveOldest(n int) (removed int, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method RemoveOldest()")
+	}
+
+	return len(cache.removeOldest(n)), nil
+}
+
+// removeOldest evicts up to the specified number of the oldest cache items across all shards, while all shards are
+// locked, and returns the evicted cache items from the oldest to the newest.
+func (cache *LRUCache[K, V]) removeOldest(n int) (items []*lruListNode[K, V]) {
+	return cache.removeOldestFunc(func() int {
+		return n
+	})
+}
+
+// evictOverflow evicts the oldest cache items across all shards, while the cache is over its capacity. A Set only
+// evicts the items of its own shard and never the item it added, so the cache overflows, if the shard holds too few
+// items to make room, e.g. if the cache has more shards than its capacity.
+func (cache *LRUCache[K, V]) evictOverflow() {
+	if cache.evictionPolicy == NoEviction || cache.len.Load() <= cache.maxItems.Load() {
+		return
+	}
+
+	cache.removeOldestFunc(func() int {
+		return int(cache.len.Load() - cache.maxItems.Load())
+	})
+}
+
+// removeOldestFunc evicts up to the number of the oldest cache items returned by the specified function, which is
+// called once all shards are locked, and returns the evicted cache items from the oldest to the newest.
+func (cache *LRUCache[K, V]) removeOldestFunc(count func() int) (items []*lruListNode[K, V]) {
+	for shardId := range cache.shards {
+		cache.shards[shardId].LockMeasured()
+	}
+	defer func() {
+		for shardId := range cache.shards {
+			cache.shards[shardId].Unlock()
+		}
+	}()
+
+	n := count()
+
+	oldest := make(shardsByOldest[K, V], 0, len(cache.shards))
+	for _, shard := range cache.shards {
+		if shard.Oldest() != nil {
+			oldest = append(oldest, shard)
+		}
+	}
+	heap.Init(&oldest)
+
+	for len(items) < n && oldest.Len() > 0 {
+		shard := oldest[0]
+		item := shard.Oldest()
+		shard.removeItem(item, Evicted)
+		items = append(items, item)
+
+		if shard.Oldest() != nil {
+			heap.Fix(&oldest, 0)
+		} else {
+			heap.Pop(&oldest)
+		}
+	}
+
+	cache.len.Add(-int64(len(items)))
+
+	if cache.debugChecks {
+		if err := cache.checkInvariants(); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return items
+}
+
+// shardsByOldest is a min-heap of shards, ordered by the last access of their oldest cache item.
+type shardsByOldest[K IKey, V IValue] []*lruCacheShard[K, V]
+
+// Len returns the number of shards in the heap.
+func (h shardsByOldest[K, V]) Len() int {
+	return len(h)
+}
+
+// Less reports whether the oldest cache item of the shard i was accessed before the one of the shard j.
+func (h shardsByOldest[K, V]) Less(i, j int) bool {
+	return h[i].Oldest().accessedAt.Load() < h[j].Oldest().accessedAt.Load()
+}
+
+// Swap swaps the shards i and j.
+func (h shardsByOldest[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+// Push adds a shard to the heap.
+func (h *shardsByOldest[K, V]) Push(x any) {
+	*h = append(*h, x.(*lruCacheShard[K, V]))
+}
+
+// Pop removes the last shard from the heap.
+func (h *shardsByOldest[K, V]) Pop() any {
+	old := *h
+	shard := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return shard
 }
 
 // Purge clears all items in the cache.
@@ -513,70 +1834,210 @@ func (cache *LRUCache[K, V]) Remove(key K) (removed bool, err error) {
 //	    panic(err)
 //	}
 func (cache *LRUCache[K, V]) Purge() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return errors.New("cache is closed")
+		return ErrClosed
 	case Started:
 		return errors.New("cache is started, must be stopped before calling method Purge()")
 	}
 
-	for shardId := range cache.shards {
-		cache.shards[shardId].Lock()
-		cache.shards[shardId].Purge()
-		cache.shards[shardId].Unlock()
+	_, err = cache.purge(context.Background())
+
+	return err
+}
+
+// PurgeContext clears all items in the cache shard by shard, until the context is done. An interrupted purge leaves
+// the cache items of the remaining shards in the cache, e.g. to bound the time a huge cache is stopped for a purge.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the purge.
+//
+// Returns:
+//   - removed: The number of removed cache items, also if the purge was interrupted.
+//   - err: The error of the context if the purge was interrupted, an error if the cache is started or closed, or if any
+//     other issue occurs.
+//
+// Example Usage:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//	removed, err := cache.PurgeContext(ctx)
+func (cache *LRUCache[K, V]) PurgeContext(ctx context.Context) (removed int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Started:
+		return 0, errors.New("cache is started, must be stopped before calling method PurgeContext()")
+	}
+
+	return cache.purge(ctx)
+}
+
+// purge clears all items of all shards and records the purge in the write-ahead log. An interrupted purge records the
+// removal of the purged keys instead, as the keys of the remaining shards must survive a replay.
+func (cache *LRUCache[K, V]) purge(ctx context.Context) (removed int64, err error) {
+	var k K
+	cache.audit(ctx, AuditPurge, k)
+
+	removed, purgedKeys, err := cache.purgeShards(ctx)
+	if err != nil {
+		for _, key := range purgedKeys {
+			cache.walRemove(key)
+		}
+		return removed, err
+	}
+
+	cache.walPurge()
+
+	return removed, nil
+}
+
+// purgeShards clears all items of all shards, including the coalesced and buffered Sets, until the context is done.
+// The keys of the purged shards are returned, if the write-ahead log is enabled.
+func (cache *LRUCache[K, V]) purgeShards(ctx context.Context) (removed int64, purgedKeys []K, err error) {
+	for shardId, shard := range cache.shards {
+		if err = ctx.Err(); err != nil {
+			return removed, purgedKeys, err
+		}
+
+		if cache.setCoalescers != nil {
+			cache.setCoalescers[shardId].discardAll()
+		}
+		cache.flushWrites(int64(shardId))
+
+		shard.Lock()
+		if cache.wal != nil {
+			for key := range shard.nodes.All() {
+				purgedKeys = append(purgedKeys, key)
+			}
+		}
+		len := shard.Len()
+		cache.len.Add(-len)
+		removed += len
+		shard.opCtx = ctx
+		shard.Purge()
+		shard.opCtx = nil
+		shard.Unlock()
+
+		cache.shardPurged(int64(shardId), len)
+	}
+
+	return removed, purgedKeys, nil
+}
+
+// Cleanup removes all expired items from the cache immediately, without waiting for the cleanup interval.
+//
+// Returns:
+//   - err: An error if the cache is closed, or if any other issue occurs.
+func (cache *LRUCache[K, V]) Cleanup() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
 	}
 
+	cache.cleanupShards(context.Background())
+
 	return nil
 }
 
-// Telemetry returns the cache's aggregated telemetry (add, update, hit, miss, evict counters).
+// CleanupContext removes all expired items from the cache immediately shard by shard, until the context is done. With
+// the CleanupPacing of the configuration it removes the expired items within the budget of the pacing and may wait for
+// the rate of the removals.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the cleanup.
+//
+// Returns:
+//   - removed: The number of removed cache items, also if the cleanup was interrupted.
+//   - err: The error of the context if the cleanup was interrupted, an error if the cache is closed, or if any other
+//     issue occurs.
+func (cache *LRUCache[K, V]) CleanupContext(ctx context.Context) (removed int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	}
+
+	return cache.cleanupShards(ctx)
+}
+
+// Telemetry returns the cache's telemetry (add, update, hit, miss, evict, load, oversized, rejected, lock counters)
+// aggregated over all shards. If the shard tuning is enabled, it also holds the recommended shard count. The histograms
+// of the value sizes and of the number of cache items per shard describe the current contents of the cache.
 //
 // Returns:
 //   - telemetry: A pointer to the aggregated cache telemetry.
 //   - err: An error if the cache is closed, or if any other issue occurs.
 func (cache *LRUCache[K, V]) Telemetry() (telemetry *telemetry, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return nil, errors.New("cache is closed")
+		return nil, ErrClosed
 	}
 
 	if !cache.telemetryOn {
 		return nil, errors.New("cache telemetry is disabled")
 	}
 
+	telemetry = newTelemetry()
+
 	for shardId := range cache.shards {
 		cache.shards[shardId].RLock()
-		shardTelemetry := cache.shards[shardId].Telemetry()
+		telemetry.merge(cache.shards[shardId].Telemetry())
+		telemetry.valueSizes.merge(&cache.shards[shardId].valueSizes)
+		telemetry.shardItems.add(cache.shards[shardId].Len(), 1)
 		cache.shards[shardId].RUnlock()
+	}
 
-		telemetry.SetHitCounter(
-			shardTelemetry.GetHitCounter(),
-		)
-		telemetry.SetMissCounter(
-			shardTelemetry.GetMissCounter(),
-		)
-		telemetry.SetAddCounter(
-			shardTelemetry.GetAddCounter(),
-		)
-		telemetry.SetUpdateCounter(
-			shardTelemetry.GetUpdateCounter(),
-		)
-		telemetry.SetEvictCounter(
-			shardTelemetry.GetEvictCounter(),
-		)
+	telemetry.merge(cache.loader.Telemetry())
+	telemetry.merge(cache.telemetry)
+
+	if cache.shardTuningOn {
+		telemetry.SetRecommendedShardsCounter(cache.recommendShardCount())
+	}
+	if cache.snapshotPolicy != nil {
+		telemetry.SetSnapshotTimeCounter(cache.snapshotTime.Load())
+		telemetry.SetSnapshotBytesCounter(cache.snapshotBytes.Load())
+		telemetry.SetSnapshotItemsCounter(cache.snapshotItems.Load())
+	}
+	if cache.auditLog != nil {
+		telemetry.SetAuditDroppedCounter(cache.auditLog.dropped.Load())
+	}
+	if cache.wal != nil {
+		telemetry.SetWALDroppedCounter(cache.wal.dropped.Load())
+	}
+	if cache.ghosts != nil {
+		telemetry.missesSaved = cache.ghosts.missesSaved()
 	}
 
 	return telemetry, nil
 }
 
-// TelemetryReset resets the cache's telemetry counters (add, update, hit, miss, evict) to zero.
+// TelemetryReset resets the cache's telemetry counters (add, update, hit, miss, evict, load, oversized, rejected, lock)
+// to zero.
 //
 // Returns:
 //   - err: An error if the cache is closed, or if any other issue occurs.
 func (cache *LRUCache[K, V]) TelemetryReset() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
 	switch cache.Status() {
 	case Closed:
-		return errors.New("cache is closed")
+		return ErrClosed
 	}
 
 	if !cache.telemetryOn {
@@ -589,17 +2050,109 @@ func (cache *LRUCache[K, V]) TelemetryReset() (err error) {
 		cache.shards[shardId].Unlock()
 	}
 
+	cache.loader.TelemetryReset()
+	cache.telemetry.reset()
+	if cache.ghosts != nil {
+		cache.ghosts.reset()
+	}
+
 	return nil
 }
 
-// Close closes the cache, releasing any resources.
+// Close closes the cache, releasing any resources. The goroutines of the cache are terminated, even if the context of
+// the cache is never done: the coalesced Sets are applied, the write buffers are flushed, the pending bulk loads fail
+// and the running loads are cancelled. The cache is marked closed first, so that no further operations are started,
+// and Close waits for the running operations to return, before it releases anything. Close is final: further calls of
+// Close are no-ops, a closed cache can't be started again, and its methods fail with ErrClosed.
+// Close waits for the running operations without a bound, see CloseContext to bound the wait.
 func (cache *LRUCache[K, V]) Close() {
-	cache.Stop()
+	_ = cache.CloseContext(context.Background())
+}
+
+// CloseContext closes the cache like Close, but waits for the running operations only until the context is done. If
+// the context is done first, the cache stays closed and its resources are released in the background, once the
+// running operations returned, e.g. if a stuck callback blocks an operation, so that the caller isn't blocked by it.
+//
+// Parameters:
+//   - ctx: The context to bound the wait for the running operations.
+//
+// Returns:
+//   - err: The error of the context, if it was done before the running operations returned.
+//
+// Example Usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+//	defer cancel()
+//	if err := cache.CloseContext(ctx); err != nil {
+//	    log.Printf("cache is closed in the background: %v", err)
+//	}
+func (cache *LRUCache[K, V]) CloseContext(ctx context.Context) (err error) {
+	if !cache.closing.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	cache.status.Store(int64(Closed))
+	cache.loader.close()
+
+	if err = cache.inFlight.Wait(ctx); err != nil {
+		go func() {
+			cache.drain()
+			cache.release()
+		}()
+		return err
+	}
+
+	cache.release()
+
+	return nil
+}
+
+// release releases the resources of a closed cache, once its running operations returned, and terminates its
+// goroutines.
+func (cache *LRUCache[K, V]) release() {
+	if cache.snapshotCancel != nil {
+		cache.snapshotCancel()
+		<-cache.snapshotDone
+	}
+	if policy := cache.snapshotPolicy; policy != nil && policy.Store != nil && policy.OnClose {
+		if _, err := cache.snapshot(cache.exportEntries); err != nil && cache.loggingOn {
+			log.Printf("%s: snapshot - %v", LibraryName, err)
+		}
+	}
+
+	for _, coalescer := range cache.setCoalescers {
+		coalescer.flushAll()
+	}
+	cache.drain()
+
+	cache.StopCleanup()
+
+	if cache.writeBuffers != nil {
+		cache.writeApplierCancel()
+		<-cache.writeApplierDone
+		for shardId := range cache.writeBuffers {
+			cache.flushWrites(int64(shardId))
+		}
+	}
 
-	cache.shards = nil
+	if cache.wal != nil {
+		cache.walCancel()
+		<-cache.walDone
+		if err := cache.wal.close(); err != nil && cache.loggingOn {
+			log.Printf("%s: closeWAL - %v", LibraryName, err)
+		}
+	}
+
+	if cache.auditLog != nil {
+		cache.auditLog.close()
+	}
 
-	cache.status = Closed
 	if cache.loggingOn {
 		log.Println("cache is closed.")
 	}
 }
+
+// drain waits until the running operations of the cache returned, e.g. when the cache is closed.
+func (cache *LRUCache[K, V]) drain() {
+	_ = cache.inFlight.Wait(context.Background())
+}
@@ -0,0 +1,66 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"hash/crc32"
+	"log"
+)
+
+// checksumTable is the CRC-32 table of the checksums of the values, the Castagnoli polynomial is hardware accelerated on
+// most platforms.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// seal sets the checksum of the value of the cache item, if the checksums are enabled. Must be called with the lock
+// held, after the value of the cache item was set.
+func (shard *lruCacheShard[K, V]) seal(item *lruListNode[K, V]) {
+	if shard.checksumOn {
+		item.checksum = crc32.Checksum(item.Value, checksumTable)
+	}
+}
+
+// intact reports whether the value of the cache item matches its checksum, always true if the checksums are disabled.
+func (shard *lruCacheShard[K, V]) intact(item *lruListNode[K, V]) bool {
+	return !shard.checksumOn || item.checksum == crc32.Checksum(item.Value, checksumTable)
+}
+
+// removeCorrupted evicts the cache item, whose value doesn't match its checksum, and records the lookup as a miss. Must
+// be called with the lock held.
+func (shard *lruCacheShard[K, V]) removeCorrupted(item *lruListNode[K, V]) {
+	if shard.loggingOn {
+		log.Printf("%s: shard %d - key %v - %v", LibraryName, shard.id, item.Key, ErrCorruptedEntry)
+	}
+
+	key := item.Key
+	shard.removeItem(item, Corrupted)
+	shard.recordMiss(key)
+}
@@ -0,0 +1,74 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+	"github.com/rommarius/sq_cache/cachetest"
+)
+
+// TestLRUCacheContract runs the cachetest contract suite against LRUCache, with the default configuration and with the
+// write buffers and the set coalescing, which must read their own writes. Run it with -race.
+func TestLRUCacheContract(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(capacity int64) *sq_cache.Config[string, []byte]
+	}{
+		{
+			name: "Default",
+			config: func(capacity int64) *sq_cache.Config[string, []byte] {
+				return &sq_cache.Config[string, []byte]{MaxItems: capacity, LoggingDisabled: true}
+			},
+		},
+		{
+			name: "ReadYourWrites",
+			config: func(capacity int64) *sq_cache.Config[string, []byte] {
+				return &sq_cache.Config[string, []byte]{
+					MaxItems:                  capacity,
+					LoggingDisabled:           true,
+					WriteBufferSize:           16,
+					WriteBufferReadYourWrites: true,
+					SetCoalesceWindow:         time.Millisecond,
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cachetest.Run(t, cachetest.LRUCacheFactory(test.config), cachetest.Options{})
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+)
+
+// SetWithCost adds a key-value pair with a specific cost to the cache. The cost is charged against the MaxCost of the
+// cache and weighed by the CostEviction policy, e.g. the time it takes to recompute the value. It is attached as the
+// metadata of the cache item, replacing any attached metadata; use SetWithMeta with EntryMeta.Cost to attach a TTL
+// (time to live) or further metadata with the cost. A cost of zero falls back to the Cost function of the
+// configuration.
+// If the key wasn't specified, it is generated automatically based on the specified value.
+// The cache item expires like the ones of Set, after the duration derived by the TTLFunc or the default duration time.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key to associate with the value.
+//   - value: The value to store in the cache.
+//   - cost: The cost of the cache item.
+//
+// Returns:
+//   - returnKey: The key that was used for the cache item.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	started := time.Now()
+//	report := renderReport()
+//	_, err := cache.SetWithCost("report", report, time.Since(started).Milliseconds())
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) SetWithCost(key K, value V, cost int64) (returnKey K, err error) {
+	var k K
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return k, ErrClosed
+	case Stopped:
+		return k, errors.New("cache is stopped, must be started before calling method SetWithCost()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	if cost < 0 {
+		return k, errors.New("cost must not be negative")
+	}
+
+	meta := &EntryMeta{CreatedAt: cache.hybridClock.Now(), Cost: cost}
+	returnKey, _, err = cache.set(key, value, cache.expiresAt(key, value, 0), meta)
+
+	return returnKey, err
+}
+
+// Cost returns the total cost of the cache items, see MaxCost.
+//
+// Returns:
+//   - cost: The total cost of the cache items.
+//
+// Example Usage:
+//
+//	log.Printf("cache holds %d of %d cost units", cache.Cost(), cache.Config().MaxCost)
+func (cache *LRUCache[K, V]) Cost() (cost int64) {
+	for _, shard := range cache.shards {
+		shard.RLockMeasured()
+		cost += shard.cost
+		shard.RUnlock()
+	}
+
+	return cost
+}
@@ -0,0 +1,90 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"fmt"
+)
+
+// CheckInvariants verifies the internal invariants of the cache while all shards are locked: the list and the index of
+// every shard hold the same cache items, the nodes of the lists are linked consistently and belong to their list, and
+// the length counter of the cache matches the sum of the shard lengths. If the debug checks are enabled, the invariants
+// are also verified after the operations and a violation panics.
+//
+// Returns:
+//   - err: An error with the diagnostics of the first violated invariant, or if the cache is closed.
+//
+// Example Usage:
+//
+//	err := cache.CheckInvariants()
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+func (cache *LRUCache[K, V]) CheckInvariants() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	}
+
+	for shardId := range cache.shards {
+		cache.shards[shardId].Lock()
+	}
+	defer func() {
+		for shardId := range cache.shards {
+			cache.shards[shardId].Unlock()
+		}
+	}()
+
+	return cache.checkInvariants()
+}
+
+// checkInvariants verifies the internal invariants of the cache. Must be called with the locks of all shards held, as
+// the length counter is only updated under the lock of the changed shard.
+func (cache *LRUCache[K, V]) checkInvariants() (err error) {
+	var shardsLen int64
+
+	for _, shard := range cache.shards {
+		if err = shard.checkInvariants(); err != nil {
+			return err
+		}
+		shardsLen += shard.Len()
+	}
+
+	if cacheLen := cache.len.Load(); cacheLen != shardsLen {
+		return fmt.Errorf("sq_cache: cache length %d != sum of the shard lengths %d", cacheLen, shardsLen)
+	}
+
+	return nil
+}
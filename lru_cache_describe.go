@@ -0,0 +1,356 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config returns a read-only copy of the effective configuration of the cache, merged from the defaults and the user
+// configuration and validated, e.g. to log or assert what the cache is actually running with.
+//
+// Returns:
+//   - config: The copy of the effective configuration.
+//
+// Example Usage:
+//
+//	if config := cache.Config(); config.MaxItems != expectedItems {
+//	    log.Printf("cache runs with %d items", config.MaxItems)
+//	}
+func (cache *LRUCache[K, V]) Config() (config ConfigSnapshot) {
+	c := &cache.config
+
+	config = ConfigSnapshot{
+		LoggingOn:   !c.LoggingDisabled,
+		TelemetryOn: !c.TelemetryDisabled,
+		CallbacksOn: !c.CallbacksDisabled,
+
+		MaxShards: cache.maxShards,
+		MaxItems:  cache.MaxItems(),
+
+		EvictBatchSize: c.EvictBatchSize,
+
+		ShardStrategy: c.ShardStrategy,
+		VirtualNodes:  c.VirtualNodes,
+		CustomShardId: cache.customShardId,
+
+		HasNormalizeKey: c.NormalizeKey != nil,
+		HasResolver:     c.Resolver != nil,
+
+		ShardIndex: c.ShardIndex,
+
+		EvictionPolicy:  c.EvictionPolicy,
+		EvictionSamples: max(c.EvictionSamples, 2),
+
+		MaxCost:   c.MaxCost,
+		HasCost:   c.Cost != nil,
+		MaxMemory: c.MaxMemory,
+
+		VictimRatio: c.VictimRatio,
+		GhostRatio:  c.GhostRatio,
+
+		KeyLockStripes: int64(len(cache.keyLocks)),
+
+		BloomFalsePositiveRate: c.BloomFalsePositiveRate,
+		HotKeysCapacity:        c.HotKeysCapacity,
+		AccessStatsOn:          c.AccessStatsOn,
+		ChecksumOn:             c.ChecksumOn,
+
+		ShardTuningOn: c.ShardTuningOn,
+		DebugChecks:   c.DebugChecks,
+
+		SetCoalesceWindow: c.SetCoalesceWindow,
+
+		WriteBufferSize:           c.WriteBufferSize,
+		WriteBufferReadYourWrites: c.WriteBufferReadYourWrites,
+
+		ExportBatchSize: cache.exportBatchSize,
+
+		DefaultTTL:        c.DefaultTTL,
+		HasTTLFunc:        c.TTLFunc != nil,
+		CleanupInterval:   c.CleanupInterval,
+		CleanupDisabled:   c.CleanupDisabled,
+		ExpiryResolution:  c.ExpiryResolution,
+		ExpiredKeysBuffer: c.ExpiredKeysBuffer,
+
+		CopyOnRead:  c.CopyOnRead,
+		CopyOnWrite: c.CopyOnWrite,
+
+		MaxValueBytes:        c.MaxValueBytes,
+		OversizedPassThrough: c.OversizedPassThrough,
+
+		MaxDependencyDepth: c.MaxDependencyDepth,
+		TombstoneTTL:       c.TombstoneTTL,
+
+		AuditQueueSize: c.AuditQueueSize,
+
+		HasAdmit:      c.Admit != nil,
+		HasLoader:     c.Loader != nil,
+		HasBulkLoader: c.BulkLoader != nil,
+
+		LoadTimeout:              c.LoadTimeout,
+		LoadRetries:              c.LoadRetries,
+		LoadRetryBackoff:         c.LoadRetryBackoff,
+		LoadFailureThreshold:     c.LoadFailureThreshold,
+		LoadCircuitBreakDuration: c.LoadCircuitBreakDuration,
+
+		MaxConcurrentLoads: c.MaxConcurrentLoads,
+		MaxLoadsPerKey:     c.MaxLoadsPerKey,
+
+		BulkLoadWindow:  c.BulkLoadWindow,
+		BulkLoadMaxKeys: c.BulkLoadMaxKeys,
+	}
+
+	if c.Snapshot != nil && c.Snapshot.Store != nil {
+		snapshot := *c.Snapshot
+		config.Snapshot = &snapshot
+	}
+	if c.CleanupPacing != nil {
+		pacing := *c.CleanupPacing
+		config.CleanupPacing = &pacing
+	}
+	if c.WAL != nil {
+		wal := *c.WAL
+		wal.SyncInterval = wal.syncInterval()
+		config.WAL = &wal
+	}
+	if len(c.Namespaces) > 0 {
+		config.Namespaces = make(map[string]NamespaceQuota, len(c.Namespaces))
+		for prefix, quota := range c.Namespaces {
+			config.Namespaces[string(prefix)] = quota
+		}
+	}
+
+	return config
+}
+
+// String returns a one-line summary of the cache, e.g. for log messages.
+//
+// Returns:
+//   - s: The summary of the status, length, capacity and shards of the cache.
+//
+// Example Usage:
+//
+//	log.Printf("serving from %v", cache)
+func (cache *LRUCache[K, V]) String() (s string) {
+	return fmt.Sprintf("%s[status=%s len=%d maxItems=%d shards=%d]", LibraryName, cache.Status(), cache.Len(),
+		cache.MaxItems(), cache.maxShards)
+}
+
+// Describe returns a multi-line description of the effective configuration of the cache, merged from the defaults and
+// the user configuration, and its live stats, e.g. to log it at startup or to attach it to bug reports.
+//
+// Returns:
+//   - description: The description of the configuration and the stats of the cache.
+//
+// Example Usage:
+//
+//	log.Println(cache.Describe())
+func (cache *LRUCache[K, V]) Describe() (description string) {
+	config := cache.Config()
+
+	var b strings.Builder
+	line := func(name, format string, args ...any) {
+		fmt.Fprintf(&b, "  %-20s "+format+"\n", append([]any{name + ":"}, args...)...)
+	}
+
+	fmt.Fprintf(&b, "%s\n", cache)
+
+	b.WriteString("config:\n")
+	switch {
+	case config.CustomShardId:
+		line("shards", "%d (custom shard id, %s index)", cache.maxShards, config.ShardIndex)
+	case config.ShardStrategy == ConsistentHashSharding:
+		line("shards", "%d (%s, %d virtual nodes, %s index)", cache.maxShards, config.ShardStrategy,
+			config.VirtualNodes, config.ShardIndex)
+	default:
+		line("shards", "%d (%s, %s index)", cache.maxShards, config.ShardStrategy, config.ShardIndex)
+	}
+	if config.EvictionPolicy == SampledEviction || config.EvictionPolicy == TTLEviction ||
+		config.EvictionPolicy == CostEviction {
+		line("capacity", "%d items, %s eviction of %d samples, evict batch %d", config.MaxItems, config.EvictionPolicy,
+			config.EvictionSamples, config.EvictBatchSize)
+	} else {
+		line("capacity", "%d items, %s eviction, evict batch %d", config.MaxItems, config.EvictionPolicy,
+			config.EvictBatchSize)
+	}
+	if config.MaxMemory > 0 {
+		line("memory", "capacity %s, cost function %s, estimated %d bytes", describeCapacity(config.MaxMemory, "bytes"),
+			describeSet(config.HasCost), cache.Cost())
+	} else {
+		line("cost", "capacity %s, cost function %s, total %d", describeCapacity(config.MaxCost, "units"),
+			describeSet(config.HasCost), cache.Cost())
+	}
+	if cache.victims != nil {
+		line("victim cache", "%d of %d items (%g of capacity)", cache.victims.Len(), cache.victims.capacity(),
+			config.VictimRatio)
+	} else {
+		line("victim cache", "off")
+	}
+	if config.GhostRatio > 0 {
+		line("ghost list", "%g of capacity", config.GhostRatio)
+	} else {
+		line("ghost list", "off")
+	}
+	cleanup := config.CleanupInterval.String()
+	if config.CleanupDisabled {
+		cleanup = "disabled"
+	}
+	line("ttl", "default %s, ttl function %s, cleanup %s, expiry resolution %s", describeDuration(config.DefaultTTL),
+		describeSet(config.HasTTLFunc), cleanup, describeDuration(config.ExpiryResolution))
+	if pacing := config.CleanupPacing; pacing != nil {
+		line("cleanup pacing", "%s per cycle, %s per second, lock hold %s",
+			describeLimit(pacing.MaxRemovalsPerCycle, "removals"), describeLimit(pacing.MaxRemovalsPerSecond, "removals"),
+			describeDuration(pacing.MaxLockHold))
+	} else {
+		line("cleanup pacing", "off")
+	}
+	line("values", "size limit %s, oversized pass-through %t, copy on read %t, copy on write %t",
+		describeLimit(config.MaxValueBytes, "bytes"), config.OversizedPassThrough, config.CopyOnRead, config.CopyOnWrite)
+	line("admit", "%s", describeSet(config.HasAdmit))
+	line("namespaces", "%s", describeCapacity(int64(len(config.Namespaces)), "quotas"))
+	line("normalize key", "%s", describeSet(config.HasNormalizeKey))
+	line("tombstones", "%s", describeDuration(config.TombstoneTTL))
+	line("resolver", "%s", describeSet(config.HasResolver))
+	line("audit log", "%s", describeCapacity(config.AuditQueueSize, "queued events"))
+	line("bloom filter", "%s", describeRate(config.BloomFalsePositiveRate))
+	line("hot keys", "%s", describeCapacity(config.HotKeysCapacity, "counters"))
+	line("access stats", "%s", describeSet(config.AccessStatsOn))
+	line("checksums", "%s", describeSet(config.ChecksumOn))
+	line("key locks", "%d stripes", config.KeyLockStripes)
+	line("set coalescing", "%s", describeDuration(config.SetCoalesceWindow))
+	line("write buffer", "%s, read your writes %t", describeCapacity(config.WriteBufferSize, "writes per shard"),
+		config.WriteBufferReadYourWrites)
+	if config.HasLoader {
+		line("loader", "timeout %s, %d retries, backoff %s, circuit %d failures for %s", config.LoadTimeout,
+			config.LoadRetries, config.LoadRetryBackoff, config.LoadFailureThreshold, config.LoadCircuitBreakDuration)
+	} else {
+		line("loader", "off")
+	}
+	if config.HasBulkLoader {
+		line("bulk loader", "window %s, max %d keys", config.BulkLoadWindow, config.BulkLoadMaxKeys)
+	} else {
+		line("bulk loader", "off")
+	}
+	line("load limits", "%s, %s per key", describeLimit(config.MaxConcurrentLoads, "concurrent loads"),
+		describeLimit(config.MaxLoadsPerKey, "callers"))
+	if policy := config.Snapshot; policy != nil {
+		line("snapshots", "%T, interval %s, on close %t, retain %s, restore on start %t", policy.Store,
+			describeDuration(policy.Interval), policy.OnClose, describeLimit(int64(policy.MaxSnapshots), "snapshots"),
+			policy.RestoreOnStart)
+	} else {
+		line("snapshots", "off")
+	}
+	if policy := config.WAL; policy != nil {
+		line("write-ahead log", "%s, sync %s, compact %s", policy.Dir, policy.SyncInterval,
+			describeDuration(policy.CompactInterval))
+	} else {
+		line("write-ahead log", "off")
+	}
+	line("flags", "logging %t, telemetry %t, callbacks %t, shard tuning %t, debug checks %t", config.LoggingOn,
+		config.TelemetryOn, config.CallbacksOn, config.ShardTuningOn, config.DebugChecks)
+
+	b.WriteString("stats:\n")
+	line("status", "%s, cleanup running %t", cache.Status(), cache.IsCleanupRunning())
+	line("len", "%d of %d items", cache.Len(), cache.MaxItems())
+	if telemetry, err := cache.Telemetry(); err == nil {
+		hits, misses := telemetry.GetHitCounter(), telemetry.GetMissCounter()
+		var ratio float64
+		if hits+misses > 0 {
+			ratio = float64(hits) / float64(hits+misses)
+		}
+		line("hits", "%d hits, %d misses, %.2f%% hit ratio", hits, misses, ratio*100)
+		line("writes", "%d adds, %d updates, %d evictions", telemetry.GetAddCounter(), telemetry.GetUpdateCounter(),
+			telemetry.GetEvictCounter())
+		line("loads", "%d successes, %d failures, %d timeouts", telemetry.GetLoadSuccessCounter(),
+			telemetry.GetLoadFailureCounter(), telemetry.GetLoadTimeoutCounter())
+		line("rejections", "%d oversized, %d rejected", telemetry.GetOversizedCounter(), telemetry.GetRejectedCounter())
+		if saved := telemetry.GetMissesSaved(); len(saved) > 0 {
+			steps := make([]string, len(saved))
+			for step, n := range saved {
+				steps[step] = fmt.Sprintf("+%d%% capacity %d", (step+1)*10, n)
+			}
+			line("misses saved", "%s", strings.Join(steps, ", "))
+		}
+	} else {
+		line("telemetry", "%v", err)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// describeDuration describes a duration, which disables a feature if it is zero or negative.
+func describeDuration(duration time.Duration) (s string) {
+	if duration <= 0 {
+		return "off"
+	}
+
+	return duration.String()
+}
+
+// describeLimit describes a limit, which is unlimited if it is zero or negative.
+func describeLimit(limit int64, unit string) (s string) {
+	if limit <= 0 {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d %s", limit, unit)
+}
+
+// describeCapacity describes a capacity, which disables a feature if it is zero or negative.
+func describeCapacity(capacity int64, unit string) (s string) {
+	if capacity <= 0 {
+		return "off"
+	}
+
+	return fmt.Sprintf("%d %s", capacity, unit)
+}
+
+// describeRate describes a rate, which disables a feature if it is zero.
+func describeRate(rate float64) (s string) {
+	if rate <= 0 {
+		return "off"
+	}
+
+	return fmt.Sprintf("%g false positive rate", rate)
+}
+
+// describeSet describes whether an optional function is set.
+func describeSet(set bool) (s string) {
+	if !set {
+		return "off"
+	}
+
+	return "on"
+}
@@ -0,0 +1,253 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+	"time"
+)
+
+// EntryMeta holds small metadata attached to a cache item, e.g. for audit tooling or cost-aware eviction. It is
+// available to the callbacks by the Meta field of the node.
+type EntryMeta struct {
+	// CreatedAt is the time the metadata was attached, set by SetWithMeta if it wasn't specified.
+	CreatedAt time.Time
+	// Source describes the origin of the value, e.g. the name of the loading service.
+	Source string
+	// Tags holds arbitrary user tags. The map must not be modified after it was attached.
+	Tags map[string]string
+	// Cost is the cost of the cache item, charged against the MaxCost of the cache and weighed by the CostEviction
+	// policy. Zero falls back to the Cost function of the configuration, see SetWithCost.
+	Cost int64
+}
+
+// isZero reports whether no metadata is set, e.g. to restore the cache items of an export without metadata.
+func (meta *EntryMeta) isZero() bool {
+	return meta.CreatedAt.IsZero() && meta.Source == "" && meta.Tags == nil && meta.Cost == 0
+}
+
+// Entry represents a cache item with its metadata.
+type Entry[K IKey, V IValue] struct {
+	Key   K
+	Value V
+	// TTL is the expiry time of the cache item, zero if the cache item never expires.
+	TTL  time.Time
+	Meta EntryMeta
+	// Version increases with every write of the cache item, see GetIfChanged.
+	Version uint64
+	// Hits is the number of hits of the cache item and LastAccess the time of its last hit or its addition, both are
+	// zero unless AccessStatsOn is enabled. They are exported with the cache item, but not imported.
+	Hits       int64
+	LastAccess time.Time
+	// Source is the path, which populated the value of the cache item, e.g. the loader or a peer. Unlike the Source of
+	// the metadata, it is tracked by the cache itself. It is exported with the cache item, but not imported, the
+	// imported cache items are attributed by the context of the import (see WithSource).
+	Source EntrySource
+}
+
+// SetWithMeta adds a key-value pair with a specific TTL (time to live) and metadata to the cache. The TTL is handled as
+// by SetWithTTL. A later Set or SetWithTTL of the key keeps the metadata, a later SetWithMeta replaces it.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key to associate with the value.
+//   - value: The value to store in the cache.
+//   - duration: The time-to-live (TTL) for the cache entry.
+//   - meta: The metadata to attach to the cache item.
+//
+// Returns:
+//   - returnKey: The key that was used for the cache item.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	_, err := cache.SetWithMeta("my-key", []byte("my-value"), time.Hour, sq_cache.EntryMeta{
+//	    Source: "catalog-service",
+//	    Tags:   map[string]string{"tenant": "acme"},
+//	})
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) SetWithMeta(key K, value V, duration time.Duration, meta EntryMeta) (returnKey K, err error) {
+	var k K
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return k, ErrClosed
+	case Stopped:
+		return k, errors.New("cache is stopped, must be started before calling method SetWithMeta()")
+	}
+
+	if err = checkTTL(duration); err != nil {
+		return k, err
+	}
+
+	key = cache.normalizeKey(key)
+
+	if meta.CreatedAt.IsZero() {
+		meta.CreatedAt = cache.hybridClock.Now()
+	}
+
+	returnKey, _, err = cache.set(key, value, cache.expiresAt(key, value, duration), &meta)
+
+	return returnKey, err
+}
+
+// GetEntry retrieves the cache item of the specified key with its TTL (time to live) and metadata. The metadata is
+// zero, if none was attached.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the cache item to retrieve.
+//
+// Returns:
+//   - entry: The cache item with its metadata if found.
+//   - err: ErrKeyNotFound if the key doesn't exist, an error if the cache is stopped or closed, or if any other issue
+//     occurs.
+//
+// Example Usage:
+//
+//	entry, err := cache.GetEntry("my-key")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	fmt.Println(entry.Source, entry.Meta.Source, entry.Meta.Tags["tenant"])
+func (cache *LRUCache[K, V]) GetEntry(key K) (entry Entry[K, V], err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return entry, ErrClosed
+	case Stopped:
+		return entry, errors.New("cache is stopped, must be started before calling method GetEntry()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.readYourWrites(shardId)
+
+	cache.shards[shardId].RLockMeasured()
+	defer cache.shards[shardId].RUnlock()
+
+	item, found := cache.shards[shardId].Entry(key)
+	if !found {
+		return entry, ErrKeyNotFound
+	}
+
+	return cache.entry(item), nil
+}
+
+// entry returns the cache item as Entry, with a copy of its value if the copy on read is enabled. Must be called with
+// the lock of the shard held.
+func (cache *LRUCache[K, V]) entry(item *lruListNode[K, V]) (entry Entry[K, V]) {
+	entry = Entry[K, V]{
+		Key:   item.Key,
+		Value: cache.readValue(item.Value),
+		TTL:   item.TTL,
+
+		Version: item.Version,
+
+		Hits:       item.hits.Load(),
+		LastAccess: fromUnixNano(item.lastAccessAt.Load()),
+
+		Source: item.source,
+	}
+	if item.Meta != nil {
+		entry.Meta = *item.Meta
+	}
+
+	return entry
+}
+
+// GetIfChanged retrieves a value by the specified key from the cache, if it was changed since the specified version,
+// e.g. to answer conditional HTTP requests with the version as ETag. The version increases with every write of the key
+// and never repeats, even if the key was removed and added again.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//   - sinceVersion: The version known by the caller, zero to retrieve the value unconditionally.
+//
+// Returns:
+//   - value: The value associated with the key if found and changed.
+//   - version: The current version of the cache item if found.
+//   - err: ErrNotModified if the cache item wasn't changed since the version, ErrKeyNotFound if the key doesn't exist,
+//     ErrCorruptedEntry if the value doesn't match its checksum, an error if the cache is stopped or closed, or if any
+//     other issue occurs.
+//
+// Example Usage:
+//
+//	value, version, err := cache.GetIfChanged("my-key", etagVersion)
+//	if errors.Is(err, sq_cache.ErrNotModified) {
+//	    w.WriteHeader(http.StatusNotModified)
+//	    return
+//	}
+func (cache *LRUCache[K, V]) GetIfChanged(key K, sinceVersion uint64) (value V, version uint64, err error) {
+	var v V
+
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return v, 0, ErrClosed
+	case Stopped:
+		return v, 0, errors.New("cache is stopped, must be started before calling method GetIfChanged()")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.readYourWrites(shardId)
+
+	cache.shards[shardId].LockMeasured()
+	defer cache.shards[shardId].Unlock()
+
+	item, found, corrupted := cache.shards[shardId].GetItem(key)
+	if corrupted {
+		cache.len.Add(-1)
+		return v, 0, ErrCorruptedEntry
+	}
+	if !found {
+		return v, 0, ErrKeyNotFound
+	}
+	if item.Version == sinceVersion {
+		return v, item.Version, ErrNotModified
+	}
+
+	return cache.readValue(item.Value), item.Version, nil
+}
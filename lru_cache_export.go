@@ -0,0 +1,320 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"iter"
+	"slices"
+)
+
+// ExportEntries streams the cache items to the specified function in batches of up to ExportBatchSize entries, e.g. to
+// migrate the contents of a live cache to another instance or to a storage. The shards are exported one after another,
+// only the shard being read is locked, so the cache keeps serving while it is exported. The cache items of a shard are
+// exported from the oldest to the newest, so an import restores their recent-ness within the shard. Expired cache items
+// are not exported.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the export.
+//   - fn: The function receiving the batches. The batch is reused after fn returned and must not be retained.
+//
+// Returns:
+//   - count: The number of exported entries.
+//   - err: An error if the cache is stopped or closed, if the context is done, the error returned by fn, or if any other
+//     issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.ExportEntries(ctx, func(batch []sq_cache.Entry[string, []byte]) error {
+//	    return encoder.Encode(batch)
+//	})
+func (cache *LRUCache[K, V]) ExportEntries(
+	ctx context.Context, fn func(batch []Entry[K, V]) error,
+) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method ExportEntries()")
+	}
+
+	_ = cache.Flush()
+
+	return cache.exportEntries(ctx, fn)
+}
+
+// exportEntries streams the cache items to the specified function in batches, without checking the status of the
+// cache, e.g. to write the final snapshot while the cache is closed. See ExportEntries.
+func (cache *LRUCache[K, V]) exportEntries(
+	ctx context.Context, fn func(batch []Entry[K, V]) error,
+) (count int64, err error) {
+	batch := make([]Entry[K, V], 0, cache.exportBatchSize)
+	for _, shard := range cache.shards {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		entries := cache.exportShard(shard)
+		for len(entries) > 0 {
+			n := min(len(entries), int(cache.exportBatchSize)-len(batch))
+			batch, entries = append(batch, entries[:n]...), entries[n:]
+
+			if int64(len(batch)) < cache.exportBatchSize {
+				continue
+			}
+			if err = fn(batch); err != nil {
+				return count, err
+			}
+			count += int64(len(batch))
+			batch = batch[:0]
+
+			if err = ctx.Err(); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		if err = fn(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+	}
+
+	return count, nil
+}
+
+// exportShard returns the cache items of the shard, which are not expired, from the oldest to the newest.
+func (cache *LRUCache[K, V]) exportShard(shard *lruCacheShard[K, V]) (entries []Entry[K, V]) {
+	shard.RLockMeasured()
+	defer shard.RUnlock()
+
+	entries = make([]Entry[K, V], 0, shard.Len())
+	now := timeNow()
+	for item := shard.Oldest(); item != nil; item = item.Prev() {
+		if item.expired(now) {
+			continue
+		}
+
+		entries = append(entries, cache.entry(item))
+	}
+
+	return entries
+}
+
+// ExportNewest streams the specified number of the most recently accessed cache items across all shards to the
+// specified function in batches of up to ExportBatchSize entries, e.g. to warm a freshly started instance with the hot
+// cache items of a peer. The cache items are exported from the oldest to the newest, so an import restores their
+// recent-ness. Only the shard being read is locked, expired cache items are not exported.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the export.
+//   - n: The maximum number of exported entries.
+//   - fn: The function receiving the batches. The batch is reused after fn returned and must not be retained.
+//
+// Returns:
+//   - count: The number of exported entries.
+//   - err: An error if the cache is stopped or closed, if the context is done, the error returned by fn, or if any other
+//     issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.ExportNewest(ctx, 10000, func(batch []sq_cache.Entry[string, []byte]) error {
+//	    return encoder.Encode(batch)
+//	})
+func (cache *LRUCache[K, V]) ExportNewest(
+	ctx context.Context, n int64, fn func(batch []Entry[K, V]) error,
+) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method ExportNewest()")
+	}
+
+	if n <= 0 {
+		return 0, nil
+	}
+
+	_ = cache.Flush()
+
+	var items []recentEntry[K, V]
+	for _, shard := range cache.shards {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		items = append(items, cache.exportShardNewest(shard, n)...)
+	}
+
+	slices.SortFunc(items, func(a, b recentEntry[K, V]) int {
+		return cmp.Compare(a.accessedAt, b.accessedAt)
+	})
+	if int64(len(items)) > n {
+		items = items[int64(len(items))-n:]
+	}
+
+	batch := make([]Entry[K, V], 0, cache.exportBatchSize)
+	for _, item := range items {
+		batch = append(batch, item.entry)
+		if int64(len(batch)) < cache.exportBatchSize {
+			continue
+		}
+
+		if err = fn(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+		batch = batch[:0]
+
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+	}
+
+	if len(batch) > 0 {
+		if err = fn(batch); err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+	}
+
+	return count, nil
+}
+
+// recentEntry represents an exported cache item with its last access, used to order the cache items across shards.
+type recentEntry[K IKey, V IValue] struct {
+	entry      Entry[K, V]
+	accessedAt int64
+}
+
+// exportShardNewest returns up to the specified number of the newest cache items of the shard, which are not expired.
+func (cache *LRUCache[K, V]) exportShardNewest(shard *lruCacheShard[K, V], n int64) (items []recentEntry[K, V]) {
+	shard.RLockMeasured()
+	defer shard.RUnlock()
+
+	items = make([]recentEntry[K, V], 0, min(n, shard.Len()))
+	now := timeNow()
+	for item := shard.Newest(); item != nil && int64(len(items)) < n; item = item.Next() {
+		if item.expired(now) {
+			continue
+		}
+
+		items = append(items, recentEntry[K, V]{entry: cache.entry(item), accessedAt: item.accessedAt.Load()})
+	}
+
+	return items
+}
+
+// ImportEntries adds the specified entries to the cache, e.g. the entries exported by ExportEntries of another cache.
+// The entries keep their expiry time and metadata, expired entries are skipped. The versions are not imported, the
+// cache items get new versions of the cache. With the tombstones enabled (see TombstoneTTL), the entries of a removed
+// key are skipped, unless their metadata was created after the removal. With a Resolver, the entries of a cached key
+// are only imported, if the Resolver prefers them over the cached entry. The imports are audited with the actor and the
+// origin of the context.
+// This operation does updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the import.
+//   - entries: The entries to add to the cache.
+//
+// Returns:
+//   - count: The number of imported entries.
+//   - err: An error if the cache is stopped or closed, if the context is done, if a value is too large, or if any other
+//     issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.ImportEntries(ctx, slices.Values(entries))
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) ImportEntries(ctx context.Context, entries iter.Seq[Entry[K, V]]) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method ImportEntries()")
+	}
+
+	now := timeNow()
+	for entry := range entries {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		if !entry.TTL.IsZero() && entry.TTL.Before(now) {
+			continue
+		}
+
+		var meta *EntryMeta
+		if !entry.Meta.isZero() {
+			meta = &entry.Meta
+		}
+
+		key := cache.normalizeKey(entry.Key)
+		if cache.buried(key, entry, now) {
+			continue
+		}
+
+		if cache.resolver != nil {
+			imported, err := cache.importEntry(ctx, key, entry, meta)
+			if err != nil {
+				return count, err
+			}
+			if imported {
+				count++
+			}
+			continue
+		}
+
+		if _, _, err = cache.setContext(ctx, key, entry.Value, entry.TTL, meta); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
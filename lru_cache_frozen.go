@@ -0,0 +1,239 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"iter"
+	"time"
+)
+
+// frozenItem represents a cache item of a FrozenCache.
+type frozenItem[V IValue] struct {
+	value V
+	ttl   time.Time
+}
+
+// expired checks if the cache item has an expiry time, which is before the specified time.
+func (item frozenItem[V]) expired(now time.Time) bool {
+	return !item.ttl.IsZero() && item.ttl.Before(now)
+}
+
+// FrozenCache represents an immutable, read-only snapshot of a LRUCache. As it is never modified, its methods don't
+// take any locks and don't track the recent-ness of the cache items. Cache items expire by their TTL (time to live),
+// but are not removed.
+type FrozenCache[K IKey, V IValue] struct {
+	items map[K]frozenItem[V]
+
+	// clone copies the values returned by the snapshot, if the cache was configured with CopyOnRead.
+	clone func(value V) V
+	// normalize maps the keys to their canonical form, if the cache was configured with NormalizeKey.
+	normalize func(key K) K
+}
+
+// Freeze returns a read-only snapshot of the cache, e.g. to serve a dataset which is rebuilt out-of-band and swapped in
+// atomically. The cache stays writable, changes after the snapshot are not visible in the snapshot. The values are not
+// copied and must not be modified, unless the cache was configured with CopyOnRead.
+//
+// Returns:
+//   - frozen: The read-only snapshot of the cache.
+//   - err: An error if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	frozen, err := cache.Freeze()
+//	if err != nil {
+//	    panic(err)
+//	}
+//	current.Store(frozen)
+func (cache *LRUCache[K, V]) Freeze() (frozen *FrozenCache[K, V], err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, ErrClosed
+	}
+
+	_ = cache.Flush()
+
+	frozen = &FrozenCache[K, V]{
+		items: make(map[K]frozenItem[V], cache.Len()),
+
+		normalize: cache.normalize,
+	}
+	if cache.copyOnRead {
+		frozen.clone = cache.clone
+	}
+
+	now := timeNow()
+	for _, shard := range cache.shards {
+		shard.RLock()
+		for key, item := range shard.nodes.All() {
+			if !item.expired(now) {
+				frozen.items[key] = frozenItem[V]{value: item.Value, ttl: item.TTL}
+			}
+		}
+		shard.RUnlock()
+	}
+
+	return frozen, nil
+}
+
+// SnapshotMap returns a copy of the unexpired cache items of the cache, e.g. for analytics or debug jobs, which walk
+// the data without blocking the traffic while they process it. The shards are read-locked one at a time and only for
+// copying the references of their cache items, the values are copied afterwards by the Clone function of the
+// configuration, so the copy can be modified freely. The copy is consistent per shard, not across the shards.
+//
+// Returns:
+//   - snapshot: The copy of the cache items by their keys.
+//   - err: An error if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	snapshot, err := cache.SnapshotMap()
+//	if err != nil {
+//	    panic(err)
+//	}
+//	for key, value := range snapshot {
+//	    analyze(key, value)
+//	}
+func (cache *LRUCache[K, V]) SnapshotMap() (snapshot map[K]V, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, ErrClosed
+	}
+
+	_ = cache.Flush()
+
+	snapshot = make(map[K]V, cache.Len())
+	now := timeNow()
+	for _, shard := range cache.shards {
+		shard.RLockMeasured()
+		for key, item := range shard.nodes.All() {
+			if !item.expired(now) {
+				snapshot[key] = item.Value
+			}
+		}
+		shard.RUnlock()
+	}
+
+	for key, value := range snapshot {
+		if value != nil {
+			snapshot[key] = cache.clone(value)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// lookup retrieves the cache item of the specified key, expired cache items are not found.
+func (frozen *FrozenCache[K, V]) lookup(key K) (item frozenItem[V], found bool) {
+	if frozen.normalize != nil && key != "" {
+		key = frozen.normalize(key)
+	}
+
+	item, found = frozen.items[key]
+	if found && item.expired(timeNow()) {
+		return item, false
+	}
+
+	return item, found
+}
+
+// readValue returns a copy of the value, if the snapshot copies its values.
+func (frozen *FrozenCache[K, V]) readValue(value V) V {
+	if frozen.clone == nil || value == nil {
+		return value
+	}
+
+	return frozen.clone(value)
+}
+
+// Get retrieves a value by the specified key from the snapshot.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - err: Always nil, for the compatibility with LRUCache.Get.
+func (frozen *FrozenCache[K, V]) Get(key K) (value V, err error) {
+	item, _ := frozen.lookup(key)
+
+	return frozen.readValue(item.value), nil
+}
+
+// Peek retrieves a value by the specified key from the snapshot. It is the same as Get, as the snapshot doesn't track
+// the recent-ness of the cache items.
+func (frozen *FrozenCache[K, V]) Peek(key K) (value V, err error) {
+	return frozen.Get(key)
+}
+
+// Contains checks if a specified key exists in the snapshot.
+func (frozen *FrozenCache[K, V]) Contains(key K) (found bool, err error) {
+	_, found = frozen.lookup(key)
+
+	return found, nil
+}
+
+// Len returns the number of cache items in the snapshot, including expired cache items.
+func (frozen *FrozenCache[K, V]) Len() (items int64) {
+	return int64(len(frozen.items))
+}
+
+// All returns an iterator over the key-value pairs of the snapshot, which are not expired.
+func (frozen *FrozenCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		now := timeNow()
+		for key, item := range frozen.items {
+			if item.expired(now) {
+				continue
+			}
+			if !yield(key, frozen.readValue(item.value)) {
+				return
+			}
+		}
+	}
+}
+
+// Set always fails with ErrReadOnly, as the snapshot can't be modified.
+func (frozen *FrozenCache[K, V]) Set(key K, value V) (returnKey K, err error) {
+	return returnKey, ErrReadOnly
+}
+
+// Remove always fails with ErrReadOnly, as the snapshot can't be modified.
+func (frozen *FrozenCache[K, V]) Remove(key K) (removed bool, err error) {
+	return false, ErrReadOnly
+}
@@ -0,0 +1,175 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+)
+
+// Oldest returns the oldest (least recently used) cache item of the cache, i.e. the cache item that RemoveOldest removes
+// next. The oldest cache items of all shards are compared by their last access. With the LRUEviction policy it is also
+// the next eviction victim of its shard, but the other policies (ClockEviction, SampledEviction, TTLEviction,
+// CostEviction, FairEviction, ...) select their victims by other criteria, so it isn't necessarily evicted next.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Returns:
+//   - key: The key of the oldest cache item.
+//   - value: The value of the oldest cache item.
+//   - found: A boolean indicating whether the cache holds any cache item.
+//   - err: An error if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	key, value, found, err := cache.Oldest()
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Oldest() (key K, value V, found bool, err error) {
+	return cache.inspect(func(shard *lruCacheShard[K, V]) *lruListNode[K, V] {
+		return shard.Oldest()
+	}, func(a, b int64) bool {
+		return a < b
+	})
+}
+
+// Newest returns the newest (most recently used) cache item of the cache.
+// The newest cache items of all shards are compared by their last access.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Returns:
+//   - key: The key of the newest cache item.
+//   - value: The value of the newest cache item.
+//   - found: A boolean indicating whether the cache holds any cache item.
+//   - err: An error if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	key, value, found, err := cache.Newest()
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Newest() (key K, value V, found bool, err error) {
+	return cache.inspect(func(shard *lruCacheShard[K, V]) *lruListNode[K, V] {
+		return shard.Newest()
+	}, func(a, b int64) bool {
+		return a > b
+	})
+}
+
+// OldestInShard returns the oldest (least recently used) cache item of the specified shard.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - shardId: The id of the shard to inspect.
+//
+// Returns:
+//   - key: The key of the oldest cache item.
+//   - value: The value of the oldest cache item.
+//   - found: A boolean indicating whether the shard holds any cache item.
+//   - err: An error if the cache is closed, if the shard doesn't exist, or if any other issue occurs.
+func (cache *LRUCache[K, V]) OldestInShard(shardId int64) (key K, value V, found bool, err error) {
+	return cache.inspectShard(shardId, func(shard *lruCacheShard[K, V]) *lruListNode[K, V] {
+		return shard.Oldest()
+	})
+}
+
+// NewestInShard returns the newest (most recently used) cache item of the specified shard.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - shardId: The id of the shard to inspect.
+//
+// Returns:
+//   - key: The key of the newest cache item.
+//   - value: The value of the newest cache item.
+//   - found: A boolean indicating whether the shard holds any cache item.
+//   - err: An error if the cache is closed, if the shard doesn't exist, or if any other issue occurs.
+func (cache *LRUCache[K, V]) NewestInShard(shardId int64) (key K, value V, found bool, err error) {
+	return cache.inspectShard(shardId, func(shard *lruCacheShard[K, V]) *lruListNode[K, V] {
+		return shard.Newest()
+	})
+}
+
+// inspect returns the cache item selected by pick of all shards, whose last access wins the comparison by before.
+func (cache *LRUCache[K, V]) inspect(
+	pick func(shard *lruCacheShard[K, V]) *lruListNode[K, V],
+	before func(a, b int64) bool,
+) (key K, value V, found bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return key, value, false, ErrClosed
+	}
+
+	var accessedAt int64
+
+	for shardId := range cache.shards {
+		cache.shards[shardId].RLock()
+		if item := pick(cache.shards[shardId]); item != nil {
+			if itemAccessedAt := item.accessedAt.Load(); !found || before(itemAccessedAt, accessedAt) {
+				key, value, found, accessedAt = item.Key, item.Value, true, itemAccessedAt
+			}
+		}
+		cache.shards[shardId].RUnlock()
+	}
+
+	return key, cache.readValue(value), found, nil
+}
+
+// inspectShard returns the cache item selected by pick of the specified shard.
+func (cache *LRUCache[K, V]) inspectShard(
+	shardId int64,
+	pick func(shard *lruCacheShard[K, V]) *lruListNode[K, V],
+) (key K, value V, found bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return key, value, false, ErrClosed
+	}
+
+	if shardId < 0 || shardId >= int64(len(cache.shards)) {
+		return key, value, false, errors.New("shard doesn't exist")
+	}
+
+	cache.shards[shardId].RLock()
+	defer cache.shards[shardId].RUnlock()
+
+	if item := pick(cache.shards[shardId]); item != nil {
+		return item.Key, cache.readValue(item.Value), true, nil
+	}
+
+	return key, value, false, nil
+}
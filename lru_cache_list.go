@@ -0,0 +1,324 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EntryInfo describes a cache item listed by ListEntries, without its value.
+type EntryInfo[K IKey] struct {
+	Key K
+	// Size is the size of the value in bytes.
+	Size int
+	// TTL is the remaining time to live, NoExpiry if the cache item never expires.
+	TTL time.Duration
+	// Version increases with every write of the cache item, see GetIfChanged.
+	Version uint64
+	// Cost is the cost of the cache item, see SetWithCost.
+	Cost int64
+	// Hits is the number of hits of the cache item and LastAccess the time of its last hit or its addition, both are
+	// zero unless AccessStatsOn is enabled.
+	Hits       int64
+	LastAccess time.Time
+}
+
+// entryInfoHeap is a max-heap of entry infos by key, which keeps the smallest keys of a shard for a page.
+type entryInfoHeap[K IKey] []EntryInfo[K]
+
+func (h entryInfoHeap[K]) Len() int           { return len(h) }
+func (h entryInfoHeap[K]) Less(i, j int) bool { return h[i].Key > h[j].Key }
+func (h entryInfoHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *entryInfoHeap[K]) Push(x any)        { *h = append(*h, x.(EntryInfo[K])) }
+func (h *entryInfoHeap[K]) Pop() any {
+	old := *h
+	info := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return info
+}
+
+// ListEntries returns a page of the cache items of the cache with their size, remaining TTL (time to live), version,
+// cost and access stats, but without their values, e.g. for the admin tooling to page through millions of keys. The
+// cache items are listed shard by shard, ordered by key within a shard. The cursor holds the position of the last
+// listed cache item, so a cache item, which exists throughout the listing, is listed exactly once, while the cache items
+// added or removed during the listing may or may not be listed. Expired cache items are not listed.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - cursor: The cursor returned by the previous page, empty for the first page.
+//   - limit: The maximum number of cache items of the page.
+//
+// Returns:
+//   - entries: The cache items of the page.
+//   - nextCursor: The cursor of the next page, empty if the listing is complete.
+//   - err: An error if the cache is stopped or closed, if the cursor is invalid, if the limit isn't positive, or if any
+//     other issue occurs.
+//
+// Example Usage:
+//
+//	var cursor string
+//	for {
+//	    entries, next, err := cache.ListEntries(cursor, 1000)
+//	    if err != nil {
+//	        panic(err)
+//	    }
+//	    for _, entry := range entries {
+//	        fmt.Println(entry.Key, entry.Size, entry.TTL)
+//	    }
+//	    if cursor = next; cursor == "" {
+//	        break
+//	    }
+//	}
+func (cache *LRUCache[K, V]) ListEntries(cursor string, limit int) (entries []EntryInfo[K], nextCursor string, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, "", ErrClosed
+	case Stopped:
+		return nil, "", errors.New("cache is stopped, must be started before calling method ListEntries()")
+	}
+
+	return cache.listEntries(cursor, limit, nil)
+}
+
+// FindKeys returns a page of the keys of the cache, which match the specified glob or regular expression, e.g. to find
+// the cached variants of a key while debugging. A pattern prefixed by "re:" is a regular expression (see package
+// regexp), which matches anywhere in the key unless anchored, otherwise the pattern is a glob matching the whole key:
+// "*" matches any sequence of characters, "?" any single character, "[...]" a character class ("[!...]" negated) and a
+// backslash escapes the next character. The keys are paged like by ListEntries, so a page is bounded by the limit, but
+// scans the shards.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - pattern: The glob, or the regular expression prefixed by "re:".
+//   - cursor: The cursor returned by the previous page, empty for the first page.
+//   - limit: The maximum number of keys of the page.
+//
+// Returns:
+//   - keys: The matching keys of the page.
+//   - nextCursor: The cursor of the next page, empty if the search is complete.
+//   - err: An error if the cache is stopped or closed, if the pattern or the cursor is invalid, if the limit isn't
+//     positive, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	keys, next, err := cache.FindKeys("user:42:*", "", 100)
+//	keys, next, err = cache.FindKeys(`re:^product:\d+:(de|en)$`, "", 100)
+func (cache *LRUCache[K, V]) FindKeys(pattern, cursor string, limit int) (keys []K, nextCursor string, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, "", ErrClosed
+	case Stopped:
+		return nil, "", errors.New("cache is stopped, must be started before calling method FindKeys()")
+	}
+
+	re, err := compileKeyPattern(pattern)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, nextCursor, err := cache.listEntries(cursor, limit, func(key K) bool {
+		return re.MatchString(string(key))
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys = make([]K, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+
+	return keys, nextCursor, nil
+}
+
+// compileKeyPattern compiles the pattern of FindKeys: a regular expression prefixed by "re:", otherwise a glob, which is
+// translated to an anchored regular expression.
+func compileKeyPattern(pattern string) (re *regexp.Regexp, err error) {
+	if expr, found := strings.CutPrefix(pattern, "re:"); found {
+		return regexp.Compile(expr)
+	}
+
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		case '\\':
+			if i++; i == len(pattern) {
+				return nil, errors.New("invalid pattern: trailing escape")
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, errors.New("invalid pattern: unterminated character class")
+			}
+			class := pattern[i+1 : i+1+end]
+			if negated, found := strings.CutPrefix(class, "!"); found {
+				class = "^" + negated
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString(`)$`)
+
+	return regexp.Compile(b.String())
+}
+
+// listEntries returns a page of the cache items, whose keys match the specified function, nil to match all keys.
+func (cache *LRUCache[K, V]) listEntries(
+	cursor string, limit int, match func(key K) bool,
+) (entries []EntryInfo[K], nextCursor string, err error) {
+	if limit < 1 {
+		return nil, "", errors.New("limit must be positive")
+	}
+
+	shardId, after, hasAfter, err := decodeListCursor[K](cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if shardId >= int64(len(cache.shards)) {
+		return nil, "", errors.New("invalid cursor")
+	}
+
+	_ = cache.Flush()
+
+	entries = make([]EntryInfo[K], 0, limit)
+	for ; shardId < int64(len(cache.shards)); shardId++ {
+		need := limit - len(entries)
+		page := listShard(cache.shards[shardId], after, hasAfter, need, match)
+		entries = append(entries, page...)
+		if len(page) == need {
+			return entries, encodeListCursor(shardId, entries[len(entries)-1].Key), nil
+		}
+
+		hasAfter = false
+	}
+
+	return entries, "", nil
+}
+
+// listShard returns the specified number of the cache items of the shard with the smallest matching keys after the
+// specified key, ordered by key. Only the keys of the page are kept, so a page costs the scan of the shard, but not its
+// sorting.
+func listShard[K IKey, V IValue](
+	shard *lruCacheShard[K, V], after K, hasAfter bool, n int, match func(key K) bool,
+) (page []EntryInfo[K]) {
+	shard.RLockMeasured()
+	defer shard.RUnlock()
+
+	h := make(entryInfoHeap[K], 0, min(n, int(shard.Len())))
+	now := timeNow()
+	for key, item := range shard.nodes.All() {
+		if item.expired(now) || (hasAfter && key <= after) || (match != nil && !match(key)) {
+			continue
+		}
+
+		if len(h) < n {
+			heap.Push(&h, entryInfoOf(item, now))
+		} else if key < h[0].Key {
+			h[0] = entryInfoOf(item, now)
+			heap.Fix(&h, 0)
+		}
+	}
+
+	page = h
+	slices.SortFunc(page, func(a, b EntryInfo[K]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return page
+}
+
+// entryInfoOf returns the EntryInfo of the cache item at the specified time. Must be called with the lock held.
+func entryInfoOf[K IKey, V IValue](item *lruListNode[K, V], now time.Time) (info EntryInfo[K]) {
+	info = EntryInfo[K]{
+		Key:        item.Key,
+		Size:       len(item.Value),
+		TTL:        NoExpiry,
+		Version:    item.Version,
+		Cost:       item.cost,
+		Hits:       item.hits.Load(),
+		LastAccess: fromUnixNano(item.lastAccessAt.Load()),
+	}
+	if !item.TTL.IsZero() {
+		info.TTL = item.TTL.Sub(now)
+	}
+
+	return info
+}
+
+// encodeListCursor encodes the shard and the key of the last listed cache item as cursor.
+func encodeListCursor[K IKey](shardId int64, key K) (cursor string) {
+	buf := binary.AppendUvarint(nil, uint64(shardId))
+
+	return base64.RawURLEncoding.EncodeToString(append(buf, key...))
+}
+
+// decodeListCursor decodes the shard and the key of the last listed cache item of a cursor, the first shard without a
+// key for an empty cursor.
+func decodeListCursor[K IKey](cursor string) (shardId int64, key K, hasKey bool, err error) {
+	if cursor == "" {
+		return 0, key, false, nil
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, key, false, errors.New("invalid cursor")
+	}
+	id, n := binary.Uvarint(buf)
+	if n <= 0 || id > uint64(1<<62) {
+		return 0, key, false, errors.New("invalid cursor")
+	}
+
+	return int64(id), K(buf[n:]), true, nil
+}
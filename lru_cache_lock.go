@@ -0,0 +1,85 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// LockKey locks the mutex of the specified key, e.g. to serialize an expensive recomputation of the value in a
+// cache-aside flow without the loader. The keys are striped across KeyLockStripes mutexes, so unrelated keys may share
+// a mutex. The mutex doesn't guard the cache item itself, the other methods of the cache don't take it. LockKey must
+// not be called again for a key of the same stripe, before the mutex was unlocked.
+//
+// Parameters:
+//   - key: The key to lock.
+//
+// Example Usage:
+//
+//	cache.LockKey("my-key")
+//	defer cache.UnlockKey("my-key")
+//
+//	value, err := cache.Get("my-key")
+//	if err == nil && value == nil {
+//	    value = recompute("my-key")
+//	    _, err = cache.Set("my-key", value)
+//	}
+func (cache *LRUCache[K, V]) LockKey(key K) {
+	cache.keyLock(key).Lock()
+}
+
+// TryLockKey tries to lock the mutex of the specified key and reports whether it succeeded.
+//
+// Parameters:
+//   - key: The key to lock.
+//
+// Returns:
+//   - locked: A boolean indicating whether the mutex was locked.
+func (cache *LRUCache[K, V]) TryLockKey(key K) (locked bool) {
+	return cache.keyLock(key).TryLock()
+}
+
+// UnlockKey unlocks the mutex of the specified key, which was locked by LockKey or TryLockKey.
+//
+// Parameters:
+//   - key: The key to unlock.
+func (cache *LRUCache[K, V]) UnlockKey(key K) {
+	cache.keyLock(key).Unlock()
+}
+
+// keyLock returns the mutex of the stripe of the specified key.
+func (cache *LRUCache[K, V]) keyLock(key K) (mutex *sync.Mutex) {
+	key = cache.normalizeKey(key)
+
+	return &cache.keyLocks[maphash.String(cache.keyLockSeed, string(key))%uint64(len(cache.keyLocks))]
+}
@@ -0,0 +1,110 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+)
+
+// Result holds the outcome of the lookup of a key by GetMulti.
+type Result[V IValue] struct {
+	Value  V
+	Status ResultStatus
+}
+
+// GetMulti retrieves the values of the specified keys from the cache. The keys are grouped by their shard, so every
+// shard is locked once. Every result reports whether the key was a hit, a miss, expired or corrupted (see ChecksumOn), so
+// the missing keys can be loaded from the origin by a single query.
+// This operation does updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - keys: The keys associated with the values to retrieve.
+//
+// Returns:
+//   - results: The results by key.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	results, err := cache.GetMulti([]string{"user-1", "user-2", "user-3"})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	for key, result := range results {
+//	    if result.Status != sq_cache.ResultHit {
+//	        missing = append(missing, key)
+//	    }
+//	}
+func (cache *LRUCache[K, V]) GetMulti(keys []K) (results map[K]Result[V], err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return nil, ErrClosed
+	case Stopped:
+		return nil, errors.New("cache is stopped, must be started before calling method GetMulti()")
+	}
+
+	normalizedKeys := make([]K, len(keys))
+	indexesByShard := make(map[int64][]int)
+	for i, key := range keys {
+		normalizedKeys[i] = cache.normalizeKey(key)
+		shardId := cache.generateShardId(normalizedKeys[i], cache.maxShards)
+		indexesByShard[shardId] = append(indexesByShard[shardId], i)
+	}
+
+	results = make(map[K]Result[V], len(keys))
+	now := timeNow()
+
+	for shardId, indexes := range indexesByShard {
+		shard := cache.shards[shardId]
+		cache.readYourWrites(shardId)
+
+		shard.LockMeasured()
+		for _, i := range indexes {
+			value, status := shard.GetStatus(normalizedKeys[i], now)
+			if status == ResultCorrupted {
+				cache.len.Add(-1)
+			}
+			results[keys[i]] = Result[V]{Value: cache.readValue(value), Status: status}
+		}
+		shard.debugCheck()
+		shard.Unlock()
+
+		for _, i := range indexes {
+			cache.trace(TraceGet, normalizedKeys[i], len(results[keys[i]].Value))
+		}
+	}
+
+	return results, nil
+}
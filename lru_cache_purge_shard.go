@@ -0,0 +1,150 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+)
+
+// PurgeShard removes all cache items of the specified shard, including its coalesced and buffered Sets, e.g. after
+// detecting a corruption of the shard. Unlike Purge, the cache keeps serving, only the shard is locked.
+//
+// Parameters:
+//   - shardId: The shard to purge, between 0 and MaxShards() - 1.
+//
+// Returns:
+//   - removed: The number of removed cache items.
+//   - err: An error if the cache is closed, or if the shard doesn't exist.
+//
+// Example Usage:
+//
+//	removed, err := cache.PurgeShard(cache.ShardOf("corrupted-key"))
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) PurgeShard(shardId int64) (removed int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	}
+
+	if shardId < 0 || shardId >= int64(len(cache.shards)) {
+		return 0, errors.New("shard doesn't exist")
+	}
+
+	var k K
+	cache.audit(context.Background(), AuditPurge, k)
+
+	if cache.setCoalescers != nil {
+		cache.setCoalescers[shardId].discardAll()
+	}
+	cache.flushWrites(shardId)
+
+	shard := cache.shards[shardId]
+	shard.LockMeasured()
+	if cache.wal != nil {
+		for key := range shard.nodes.All() {
+			cache.walRemove(key)
+		}
+	}
+	removed = shard.Len()
+	cache.len.Add(-removed)
+	shard.Purge()
+	shard.Unlock()
+
+	cache.shardPurged(shardId, removed)
+
+	return removed, nil
+}
+
+// TrimShard evicts up to the specified number of the least recently used cache items of the specified shard, e.g. for
+// a targeted memory relief of a hot shard.
+//
+// Parameters:
+//   - shardId: The shard to trim, between 0 and MaxShards() - 1.
+//   - n: The maximum number of cache items to evict.
+//
+// Returns:
+//   - removed: The number of evicted cache items.
+//   - err: An error if the cache is stopped or closed, or if the shard doesn't exist.
+//
+// Example Usage:
+//
+//	removed, err := cache.TrimShard(shardId, cache.MaxItems()/cache.MaxShards()/10)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) TrimShard(shardId int64, n int64) (removed int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method TrimShard()")
+	}
+
+	if shardId < 0 || shardId >= int64(len(cache.shards)) {
+		return 0, errors.New("shard doesn't exist")
+	}
+
+	cache.flushWrites(shardId)
+
+	shard := cache.shards[shardId]
+	shard.LockMeasured()
+	for ; removed < n; removed++ {
+		item := shard.Oldest()
+		if item == nil {
+			break
+		}
+		cache.walRemove(item.Key)
+		shard.removeItem(item, Evicted)
+	}
+	cache.len.Add(-removed)
+	shard.Unlock()
+
+	cache.shardPurged(shardId, removed)
+
+	return removed, nil
+}
+
+// shardPurged triggers the OnShardPurge callback for a purged or trimmed shard.
+func (cache *LRUCache[K, V]) shardPurged(shardId int64, removed int64) {
+	if cache.callbacksOn && cache.onShardPurge != nil {
+		cache.onShardPurge(cache.loggingOn, shardId, removed)
+	}
+}
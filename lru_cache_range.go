@@ -0,0 +1,139 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+)
+
+// Range calls the specified function for the cache items of the cache, until it returns false or the context is done.
+// The shards are visited one after another; the cache items of a shard are copied while only the shard is locked, and
+// the function is called without holding any lock, so it may access the cache. The cache items of a shard are visited
+// from the oldest to the newest, expired cache items are skipped.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the iteration.
+//   - fn: The function called for each cache item, returning false stops the iteration.
+//
+// Returns:
+//   - count: The number of visited cache items, also if the iteration was interrupted.
+//   - err: The error of the context if the iteration was interrupted, an error if the cache is stopped or closed, or
+//     if any other issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.Range(ctx, func(key string, value []byte) bool {
+//	    fmt.Println(key, len(value))
+//	    return true
+//	})
+func (cache *LRUCache[K, V]) Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method Range()")
+	}
+
+	return cache.rangeEntries(ctx, func(entry Entry[K, V]) bool {
+		return fn(entry.Key, entry.Value)
+	})
+}
+
+// RangeEntries calls the specified function for the cache items of the cache with their TTL (time to live), metadata
+// and access stats, until it returns false or the context is done. The cache items are visited like by Range.
+// This operation doesn't updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the iteration.
+//   - fn: The function called for each cache item, returning false stops the iteration.
+//
+// Returns:
+//   - count: The number of visited cache items, also if the iteration was interrupted.
+//   - err: The error of the context if the iteration was interrupted, an error if the cache is stopped or closed, or
+//     if any other issue occurs.
+//
+// Example Usage:
+//
+//	var idle int64
+//	_, err := cache.RangeEntries(ctx, func(entry sq_cache.Entry[string, []byte]) bool {
+//	    if entry.Hits == 0 {
+//	        idle += int64(len(entry.Value))
+//	    }
+//	    return true
+//	})
+func (cache *LRUCache[K, V]) RangeEntries(
+	ctx context.Context, fn func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	case Stopped:
+		return 0, errors.New("cache is stopped, must be started before calling method RangeEntries()")
+	}
+
+	return cache.rangeEntries(ctx, fn)
+}
+
+// rangeEntries calls the specified function for the cache items of all shards, until it returns false or the context
+// is done.
+func (cache *LRUCache[K, V]) rangeEntries(
+	ctx context.Context, fn func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	_ = cache.Flush()
+
+	for _, shard := range cache.shards {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		for _, entry := range cache.exportShard(shard) {
+			if err = ctx.Err(); err != nil {
+				return count, err
+			}
+
+			count++
+			if !fn(entry) {
+				return count, nil
+			}
+		}
+	}
+
+	return count, nil
+}
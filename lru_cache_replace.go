@@ -0,0 +1,146 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+)
+
+// ReplaceAll replaces all cache items with the specified entries atomically, e.g. to refresh reference data without
+// serving a half-populated cache or stopping the cache. The new shards are built without holding the locks of the
+// cache; afterwards all shards are locked only to swap their cache items. The entries are added with the default TTL
+// (time to live), if any, and pass the size limit and the admit hook. The replaced cache items are removed with the
+// reason Replaced, if their key is part of the entries, otherwise with the reason Purged. The dependencies between the
+// cache items (see DependOn) are dropped.
+//
+// Parameters:
+//   - entries: The key-value pairs, which replace the cache items.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, if a value is too large, ErrCacheFull if the entries exceed the
+//     capacity with the NoEviction policy, or if any other issue occurs. The cache items are not replaced on an error.
+//
+// Example Usage:
+//
+//	err := cache.ReplaceAll(referenceData)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) ReplaceAll(entries map[K]V) (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	case Stopped:
+		return errors.New("cache is stopped, must be started before calling method ReplaceAll()")
+	}
+
+	shards := make([]*lruCacheShard[K, V], len(cache.shards))
+	for shardId, shard := range cache.shards {
+		shards[shardId] = shard.newEmpty()
+	}
+
+	var len int64
+	for key, value := range entries {
+		key = cache.normalizeKey(key)
+		if key == "" {
+			key = cache.generateKey(value)
+		}
+
+		cacheable, err := cache.cacheable(key, value)
+		if err != nil {
+			return err
+		}
+		if !cacheable {
+			continue
+		}
+
+		ttl := cache.expiresAt(key, value, 0)
+		evictCount, added := shards[cache.generateShardId(key, cache.maxShards)].Set(len, key, cache.writeValue(value), ttl, nil)
+		if added {
+			len++
+		}
+		if cache.evictionPolicy == NoEviction && len > cache.maxItems.Load() {
+			return ErrCacheFull
+		}
+		len -= evictCount
+	}
+
+	for _, shard := range cache.shards {
+		shard.LockMeasured()
+	}
+
+	for shardId, shard := range cache.shards {
+		if cache.setCoalescers != nil {
+			cache.setCoalescers[shardId].discardAll()
+		}
+		if cache.writeBuffers != nil {
+			cache.applyWrites(int64(shardId))
+		}
+		shard.swap(shards[shardId])
+	}
+	cache.len.Store(len)
+	cache.dependencies.clear()
+
+	if cache.wal != nil {
+		cache.walPurge()
+		for _, shard := range cache.shards {
+			for item := shard.Oldest(); item != nil; item = item.Prev() {
+				cache.walSet(item.Key, item.Value, item.TTL, item.Meta)
+			}
+		}
+	}
+
+	if cache.debugChecks {
+		if err := cache.checkInvariants(); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	for _, shard := range cache.shards {
+		shard.Unlock()
+	}
+
+	for _, replaced := range shards {
+		for key, item := range replaced.nodes.All() {
+			reason := Purged
+			if _, found := entries[key]; found {
+				reason = Replaced
+			}
+			replaced.recordRemove(key, item.Value, reason)
+		}
+	}
+
+	return nil
+}
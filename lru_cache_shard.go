@@ -32,7 +32,12 @@
 package sq_cache
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rommarius/generic_syncpool"
@@ -44,58 +49,331 @@ type lruCacheShard[K IKey, V IValue] struct {
 
 	id int64
 
-	maxItems int64
+	maxItems       int64
+	evictBatchSize int64
+	indexType      ShardIndexType
+	evictionPolicy EvictionPolicy
 
-	loggingOn   bool
-	telemetryOn bool
+	// evictionSamples is the number of cache items sampled by the SampledEviction policy, sample is reused for them.
+	evictionSamples int
+	sample          []*lruListNode[K, V]
+
+	// maxCost is the cost capacity of the shard, zero if it is unlimited, cost is the total cost of its cache items.
+	// costOf returns the cost of a cache item without an explicit cost, nil if every cache item costs 1.
+	maxCost int64
+	cost    int64
+	costOf  func(key K, value V) int64
+	// valueSizes is the distribution of the value sizes of the cache items of the shard.
+	valueSizes Histogram
+	// namespaces maps the keys to their namespaces, nil if no namespace is configured. namespaceUsage holds the cache
+	// items of the shard per namespace, in the order of the namespaces.
+	namespaces     *namespaces[K]
+	namespaceUsage []namespaceUsage
+	// bucketOf maps the keys to their buckets, bucketCounts holds the number of cache items per bucket, nil unless the
+	// shard evicts by the FairEviction policy.
+	bucketOf     func(key K) K
+	bucketCounts map[K]int64
+	// inflation is the GreedyDual-Size priority of the last evicted cache item as float64 bits, which ages the
+	// priorities of the CostEviction policy.
+	inflation atomic.Uint64
+
+	loggingOn     bool
+	telemetryOn   bool
+	callbacksOn   bool
+	shardTuningOn bool
+	debugChecks   bool
+	accessStatsOn bool
+	checksumOn    bool
 
 	list      *lruList[K, V]
 	nodesPool *generic_syncpool.Pool[lruListNode[K, V]]
-	nodes     map[K]*lruListNode[K, V]
+	nodes     shardIndex[K, V]
+
+	// wheel schedules the expiry of the cache items, nil if the expiry resolution is not set.
+	wheel            *timingWheel[K, V]
+	expiryResolution time.Duration
+
+	// bloom filters the lookups of keys, which definitely don't exist in the shard.
+	bloom                  *bloomFilter
+	bloomCapacity          int64
+	bloomFalsePositiveRate float64
 
 	telemetry *telemetry
 
+	clock *atomic.Int64
+
+	// hotKeys tracks the most frequently accessed keys of the shard, nil if the tracking is disabled.
+	hotKeys *hotKeys[K]
+
+	// expiredKeys receives the keys of the expired cache items, shared by all shards of the cache.
+	expiredKeys chan K
+
+	// dependencies holds the dependencies between the cache items, shared by all shards of the cache.
+	dependencies *dependencyGraph[K]
+
+	// tombstones holds the removal times of the removed keys for tombstoneTTL, tombstoneQueue the tombstones in the
+	// order of their expiry. Both are empty if the tombstones are disabled.
+	tombstoneTTL   time.Duration
+	tombstones     map[K]time.Time
+	tombstoneQueue []tombstone[K]
+
+	// hybridClock stamps the metadata of the written cache items, shared by all shards of the cache, nil unless a
+	// Resolver is configured.
+	hybridClock *hybridClock
+
+	// victims holds the cache items recently evicted by capacity pressure, shared by all shards of the cache, nil unless
+	// a VictimRatio is configured.
+	victims *victimCache[K, V]
+	// ghosts remembers the keys recently evicted by capacity pressure, shared by all shards of the cache, nil unless a
+	// GhostRatio is configured.
+	ghosts *ghostList
+
 	onAdd    func(loggingOn bool, node *lruListNode[K, V])
 	onUpdate func(loggingOn bool, node *lruListNode[K, V])
 	onHit    func(loggingOn bool, node *lruListNode[K, V])
 	onMiss   func(loggingOn bool, key K)
 	onEvict  func(loggingOn bool, node *lruListNode[K, V])
+	onRemove func(loggingOn bool, key K, value V, reason RemovalReason)
+
+	onAddContext    func(ctx context.Context, node *lruListNode[K, V])
+	onUpdateContext func(ctx context.Context, node *lruListNode[K, V])
+	onEvictContext  func(ctx context.Context, node *lruListNode[K, V])
+	onRemoveContext func(ctx context.Context, key K, value V, reason RemovalReason)
+
+	// opCtx is the context of the mutation holding the lock of the shard, nil if unknown.
+	opCtx context.Context
+	// opSource is the source of the Set holding the lock of the shard, SetSource if unknown.
+	opSource EntrySource
 }
 
 // newLRUCacheShard initializes and returns a new lruCacheShard instance with user-configured settings.
-func newLRUCacheShard[K IKey, V IValue](config *Config[K, V], id int64) (shard *lruCacheShard[K, V]) {
+// The clock is shared by all shards of the cache, to order the accesses across shards.
+func newLRUCacheShard[K IKey, V IValue](config *Config[K, V], id int64, clock *atomic.Int64) (shard *lruCacheShard[K, V]) {
 	shard = &lruCacheShard[K, V]{
 		id: id,
 
-		maxItems: config.MaxItems,
+		maxItems:       config.MaxItems,
+		evictBatchSize: config.EvictBatchSize,
+		indexType:      config.ShardIndex,
+		evictionPolicy: config.EvictionPolicy,
+
+		evictionSamples: int(max(config.EvictionSamples, 2)),
+
+		maxCost: (max(config.MaxCost, config.MaxMemory) + config.MaxShards - 1) / config.MaxShards,
+		costOf:  costFunc(config),
 
-		loggingOn:   config.LoggingOn,
-		telemetryOn: config.TelemetryOn,
+		loggingOn:     !config.LoggingDisabled,
+		telemetryOn:   !config.TelemetryDisabled,
+		callbacksOn:   !config.CallbacksDisabled,
+		shardTuningOn: config.ShardTuningOn,
+		debugChecks:   config.DebugChecks,
+		accessStatsOn: config.AccessStatsOn,
+		checksumOn:    config.ChecksumOn,
 
 		list:      newLRUList[K, V](),
 		nodesPool: generic_syncpool.New[lruListNode[K, V]](),
-		nodes:     make(map[K]*lruListNode[K, V], config.MaxItems),
+		nodes:     newShardIndex[K, V](config.ShardIndex, config.MaxItems),
+
+		expiryResolution: config.ExpiryResolution,
+
+		bloomCapacity:          (config.MaxItems + config.MaxShards - 1) / config.MaxShards,
+		bloomFalsePositiveRate: config.BloomFalsePositiveRate,
 
 		telemetry: newTelemetry(),
 
+		clock: clock,
+
 		onAdd:    config.OnAdd,
 		onUpdate: config.OnUpdate,
 		onHit:    config.OnHit,
 		onMiss:   config.OnMiss,
 		onEvict:  config.OnEvict,
+		onRemove: config.OnRemove,
+
+		onAddContext:    config.OnAddContext,
+		onUpdateContext: config.OnUpdateContext,
+		onEvictContext:  config.OnEvictContext,
+		onRemoveContext: config.OnRemoveContext,
+	}
+
+	if shard.expiryResolution > 0 {
+		shard.wheel = newTimingWheel[K, V](shard.expiryResolution, time.Now())
+	}
+	if shard.bloomFalsePositiveRate > 0 {
+		shard.bloom = newBloomFilter(shard.bloomCapacity, shard.bloomFalsePositiveRate)
+	}
+	if shard.evictionPolicy == FairEviction {
+		shard.bucketOf = config.FairnessBucket
+		shard.bucketCounts = make(map[K]int64)
+	}
+	if config.HotKeysCapacity > 0 {
+		shard.hotKeys = newHotKeys[K](int((config.HotKeysCapacity + config.MaxShards - 1) / config.MaxShards))
+	}
+	if config.TombstoneTTL > 0 {
+		shard.tombstoneTTL = config.TombstoneTTL
+		shard.tombstones = make(map[K]time.Time)
 	}
 
 	return shard
 }
 
+// newEmpty initializes and returns a new, empty lruCacheShard instance with the settings of the shard, e.g. to build the
+// contents of the shard out-of-band.
+func (shard *lruCacheShard[K, V]) newEmpty() (empty *lruCacheShard[K, V]) {
+	empty = &lruCacheShard[K, V]{
+		id: shard.id,
+
+		maxItems:       shard.maxItems,
+		evictBatchSize: shard.evictBatchSize,
+		indexType:      shard.indexType,
+		evictionPolicy: shard.evictionPolicy,
+
+		evictionSamples: shard.evictionSamples,
+
+		maxCost: shard.maxCost,
+		costOf:  shard.costOf,
+
+		namespaces: shard.namespaces,
+
+		bucketOf: shard.bucketOf,
+
+		loggingOn:     shard.loggingOn,
+		telemetryOn:   shard.telemetryOn,
+		callbacksOn:   shard.callbacksOn,
+		shardTuningOn: shard.shardTuningOn,
+		debugChecks:   shard.debugChecks,
+		accessStatsOn: shard.accessStatsOn,
+		checksumOn:    shard.checksumOn,
+
+		list:      newLRUList[K, V](),
+		nodesPool: generic_syncpool.New[lruListNode[K, V]](),
+		nodes:     newShardIndex[K, V](shard.indexType, shard.maxItems),
+
+		expiryResolution: shard.expiryResolution,
+
+		bloomCapacity:          shard.bloomCapacity,
+		bloomFalsePositiveRate: shard.bloomFalsePositiveRate,
+
+		telemetry: newTelemetry(),
+
+		clock: shard.clock,
+
+		hotKeys:     shard.hotKeys,
+		expiredKeys: shard.expiredKeys,
+
+		dependencies: shard.dependencies,
+
+		hybridClock: shard.hybridClock,
+		victims:     shard.victims,
+		ghosts:      shard.ghosts,
+
+		onAdd:    shard.onAdd,
+		onUpdate: shard.onUpdate,
+		onHit:    shard.onHit,
+		onMiss:   shard.onMiss,
+		onEvict:  shard.onEvict,
+		onRemove: shard.onRemove,
+
+		onAddContext:    shard.onAddContext,
+		onUpdateContext: shard.onUpdateContext,
+		onEvictContext:  shard.onEvictContext,
+		onRemoveContext: shard.onRemoveContext,
+	}
+
+	if empty.bucketOf != nil {
+		empty.bucketCounts = make(map[K]int64)
+	}
+	if empty.namespaces != nil {
+		empty.namespaceUsage = make([]namespaceUsage, len(empty.namespaces.list))
+	}
+	if empty.expiryResolution > 0 {
+		empty.wheel = newTimingWheel[K, V](empty.expiryResolution, time.Now())
+	}
+	if empty.bloomFalsePositiveRate > 0 {
+		empty.bloom = newBloomFilter(empty.bloomCapacity, empty.bloomFalsePositiveRate)
+	}
+
+	return empty
+}
+
+// swap exchanges the cache items of the shard with the cache items of the other shard, and merges the telemetry of the
+// other shard. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) swap(other *lruCacheShard[K, V]) {
+	shard.list, other.list = other.list, shard.list
+	shard.nodes, other.nodes = other.nodes, shard.nodes
+	shard.wheel, other.wheel = other.wheel, shard.wheel
+	shard.bloom, other.bloom = other.bloom, shard.bloom
+	shard.cost, other.cost = other.cost, shard.cost
+	shard.valueSizes, other.valueSizes = other.valueSizes, shard.valueSizes
+	shard.namespaceUsage, other.namespaceUsage = other.namespaceUsage, shard.namespaceUsage
+	shard.bucketCounts, other.bucketCounts = other.bucketCounts, shard.bucketCounts
+	if shard.victims != nil {
+		shard.victims.discardShard(shard, nil)
+	}
+	shard.inflation.Store(other.inflation.Swap(shard.inflation.Load()))
+
+	shard.telemetry.merge(other.telemetry)
+	other.telemetry.reset()
+}
+
+// LockMeasured locks the shard for writing. If the shard tuning is enabled, the lock acquisitions, the contended lock
+// acquisitions and the time spent waiting for the lock are counted.
+func (shard *lruCacheShard[K, V]) LockMeasured() {
+	faultLockDelay()
+
+	if !shard.shardTuningOn {
+		shard.Lock()
+		return
+	}
+
+	shard.telemetry.incrementCounter(Lock)
+	if shard.TryLock() {
+		return
+	}
+
+	start := time.Now()
+	shard.Lock()
+	shard.telemetry.incrementCounter(LockContention)
+	shard.telemetry.addCounter(LockWait, int64(time.Since(start)))
+}
+
+// RLockMeasured locks the shard for reading. If the shard tuning is enabled, the lock acquisitions, the contended lock
+// acquisitions and the time spent waiting for the lock are counted.
+func (shard *lruCacheShard[K, V]) RLockMeasured() {
+	faultLockDelay()
+
+	if !shard.shardTuningOn {
+		shard.RLock()
+		return
+	}
+
+	shard.telemetry.incrementCounter(Lock)
+	if shard.TryRLock() {
+		return
+	}
+
+	start := time.Now()
+	shard.RLock()
+	shard.telemetry.incrementCounter(LockContention)
+	shard.telemetry.addCounter(LockWait, int64(time.Since(start)))
+}
+
+// Len returns the number of cache items in the shard.
+func (shard *lruCacheShard[K, V]) Len() (len int64) {
+	return int64(shard.list.Len())
+}
+
 // getItemFromPool retrieves an item from the cache pool.
 // Used for efficient memory management and allocation to minimize overhead and optimize resource usage in caching
 // operations.
-func (shard *lruCacheShard[K, V]) getItemFromPool(key K, value V, ttl time.Time) (item *lruListNode[K, V]) {
+func (shard *lruCacheShard[K, V]) getItemFromPool(
+	key K, value V, ttl time.Time, meta *EntryMeta,
+) (item *lruListNode[K, V]) {
 	item = shard.nodesPool.Get()
 	item.Key = key
 	item.Value = value
 	item.TTL = ttl
+	item.Meta = meta
 
 	return item
 }
@@ -107,105 +385,658 @@ func (shard *lruCacheShard[K, V]) putItemInPool(item *lruListNode[K, V]) {
 	item.Key = ""
 	item.Value = nil
 	item.TTL = time.Time{}
+	item.Meta = nil
+	item.Version = 0
+	item.accessedAt.Store(0)
+	item.referenced.Store(false)
+	item.checksum = 0
+	item.source = SetSource
+	item.cost = 0
+	item.priority.Store(0)
+	item.hits.Store(0)
+	item.lastAccessAt.Store(0)
 	shard.nodesPool.Put(item)
 }
 
-// removeItemOldest removes the oldest (least recently used) item from the shard.
-func (shard *lruCacheShard[K, V]) removeItemOldest() {
-	if item := shard.list.Back(); item != nil {
-		shard.removeItem(item)
+// promote marks the cache item as the most recently used one: the LRUEviction and FairEviction policies move it to the
+// front of the list, the ClockEviction policy sets its reference bit, the CostEviction policy restores its priority,
+// the other policies only stamp it. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) promote(item *lruListNode[K, V]) {
+	switch shard.evictionPolicy {
+	case ClockEviction:
+		item.referenced.Store(true)
+	case CostEviction:
+		shard.prioritize(item)
+	case SampledEviction, FIFOEviction, NoEviction, TTLEviction:
+	default:
+		shard.list.MoveToFront(item)
 	}
+	shard.touch(item)
 }
 
-// removeItem removes a specific item from the shard by reference.
-func (shard *lruCacheShard[K, V]) removeItem(item *lruListNode[K, V]) {
-	shard.list.Remove(item)
-	delete(shard.nodes, item.Key)
+// prioritize sets the GreedyDual-Size priority of the cache item: the inflation of the shard plus the cost of the
+// cache item per byte of its value.
+func (shard *lruCacheShard[K, V]) prioritize(item *lruListNode[K, V]) {
+	priority := math.Float64frombits(shard.inflation.Load()) + float64(item.cost)/float64(max(len(item.Value), 1))
+	item.priority.Store(math.Float64bits(priority))
+}
 
+// charge sets the cost of the cache item, from its metadata, the cost function or 1, and accounts it to the total
+// cost of the shard. Must be called with the lock held, after the value and the metadata of the cache item were set.
+func (shard *lruCacheShard[K, V]) charge(item *lruListNode[K, V]) {
+	cost := int64(1)
+	switch {
+	case item.Meta != nil && item.Meta.Cost > 0:
+		cost = item.Meta.Cost
+	case shard.costOf != nil:
+		cost = max(shard.costOf(item.Key, item.Value), 0)
+	}
+
+	shard.cost += cost - item.cost
+	item.cost = cost
+	if shard.evictionPolicy == CostEviction {
+		shard.prioritize(item)
+	}
+}
+
+// removeItemsCostly removes cache items by the eviction policy, until the total cost of the shard fits its cost
+// capacity, but never the last cache item of the shard.
+func (shard *lruCacheShard[K, V]) removeItemsCostly() (evictCount int64) {
+	for shard.maxCost > 0 && shard.cost > shard.maxCost && shard.list.Len() > 1 {
+		removed := shard.removeItemsOldest(1)
+		if removed == 0 {
+			break
+		}
+		evictCount += removed
+	}
+
+	return evictCount
+}
+
+// touch stamps the cache item with the next tick of the cache clock.
+func (shard *lruCacheShard[K, V]) touch(item *lruListNode[K, V]) {
+	item.accessedAt.Store(shard.clock.Add(1))
+}
+
+// lookup retrieves the cache item of the specified key from the index. Expired cache items are not found, even if the
+// cleanup didn't remove them yet.
+func (shard *lruCacheShard[K, V]) lookup(key K, now time.Time) (item *lruListNode[K, V], found bool) {
+	if shard.bloom != nil && !shard.bloom.mayContain(string(key)) {
+		return nil, false
+	}
+
+	item, found = shard.nodes.Get(key)
+	if found && item.expired(now) {
+		return nil, false
+	}
+
+	return item, found
+}
+
+// recordAdd updates the telemetry and triggers the callback for an added cache item.
+func (shard *lruCacheShard[K, V]) recordAdd(item *lruListNode[K, V]) {
+	if shard.accessStatsOn {
+		item.lastAccessAt.Store(timeNow().UnixNano())
+	}
+	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(Add)
+	}
+	if shard.callbacksOn {
+		shard.onAdd(shard.loggingOn, item)
+		if shard.onAddContext != nil {
+			shard.onAddContext(shard.operationContext(), item)
+		}
+	}
+}
+
+// attribute records the source of the running Set as the source of the written item and counts it by the telemetry.
+// Must be called with the lock of the shard held.
+func (shard *lruCacheShard[K, V]) attribute(item *lruListNode[K, V]) {
+	item.source = shard.opSource
+	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(item.source.counterMode())
+	}
+}
+
+// recordUpdate updates the telemetry and triggers the callback for an updated cache item.
+func (shard *lruCacheShard[K, V]) recordUpdate(item *lruListNode[K, V]) {
+	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(Update)
+	}
+	if shard.callbacksOn {
+		shard.onUpdate(shard.loggingOn, item)
+		if shard.onUpdateContext != nil {
+			shard.onUpdateContext(shard.operationContext(), item)
+		}
+	}
+}
+
+// recordHit updates the telemetry and triggers the callback for a cache item, which was found unexpired at the
+// specified time of its lookup.
+func (shard *lruCacheShard[K, V]) recordHit(item *lruListNode[K, V], now time.Time) {
+	if shard.debugChecks && item.expired(now) {
+		panic(fmt.Sprintf("sq_cache: shard %d served the expired cache item %v (expired at %s)", shard.id, item.Key, item.TTL))
+	}
+	if shard.hotKeys != nil {
+		shard.hotKeys.Record(item.Key)
+	}
+	if shard.accessStatsOn {
+		item.hits.Add(1)
+		item.lastAccessAt.Store(timeNow().UnixNano())
+	}
+	if namespace := shard.namespaceOf(item.Key); namespace != nil {
+		namespace.hits.Add(1)
+	}
 	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(Hit)
+	}
+	if shard.callbacksOn {
+		shard.onHit(shard.loggingOn, item)
+	}
+}
+
+// recordMiss updates the telemetry and triggers the callback for a cache item that was not found.
+func (shard *lruCacheShard[K, V]) recordMiss(key K) {
+	if shard.hotKeys != nil {
+		shard.hotKeys.Record(key)
+	}
+	if namespace := shard.namespaceOf(key); namespace != nil {
+		namespace.misses.Add(1)
+	}
+	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(Miss)
+	}
+	if shard.callbacksOn {
+		shard.onMiss(shard.loggingOn, key)
+	}
+}
+
+// recordEvict updates the telemetry and triggers the callback for an evicted cache item.
+func (shard *lruCacheShard[K, V]) recordEvict(item *lruListNode[K, V]) {
+	if shard.telemetryOn {
+		shard.telemetry.incrementCounter(Evict)
+	}
+	if shard.callbacksOn {
 		shard.onEvict(shard.loggingOn, item)
+		if shard.onEvictContext != nil {
+			shard.onEvictContext(shard.operationContext(), item)
+		}
+	}
+}
+
+// recordRemove triggers the callback for a removed cache item, passing the reason of the removal.
+// Afterwards the value gets closed, if it implements the Closer interface.
+func (shard *lruCacheShard[K, V]) recordRemove(key K, value V, reason RemovalReason) {
+	if shard.callbacksOn {
+		shard.onRemove(shard.loggingOn, key, value, reason)
+		if shard.onRemoveContext != nil {
+			shard.onRemoveContext(shard.operationContext(), key, value, reason)
+		}
+	}
+
+	closeValue(shard.loggingOn, value)
+}
+
+// removeItemsOldest removes up to the specified number of the oldest (least recently used) items from the shard, but
+// never the most recently used item. With the ClockEviction policy the back of the list is the hand of the clock: a
+// referenced item gets a second chance, its reference bit is cleared and it is moved to the front.
+func (shard *lruCacheShard[K, V]) removeItemsOldest(count int64) (evictCount int64) {
+	switch shard.evictionPolicy {
+	case SampledEviction, TTLEviction, CostEviction:
+		return shard.removeItemsSampled(count)
+	case FairEviction:
+		return shard.removeItemsFair(count)
+	case NoEviction:
+		return 0
+	}
+
+	for evictCount < count && shard.list.Len() > 1 {
+		item := shard.list.Back()
+		if shard.evictionPolicy == ClockEviction && item.referenced.Swap(false) {
+			shard.list.MoveToFront(item)
+			continue
+		}
+
+		shard.removeItem(item, Evicted)
+		evictCount++
+	}
+
+	return evictCount
+}
+
+// removeItemsSampled removes up to the specified number of items from the shard by the SampledEviction, TTLEviction or
+// CostEviction policy: each eviction samples evictionSamples random items and removes the least recently used one
+// among them, the one closest to its expiry with TTLEviction, or the one with the lowest priority with CostEviction,
+// whose priority becomes the inflation of the shard. The most recently used item is never removed by SampledEviction,
+// as at least two items are sampled.
+func (shard *lruCacheShard[K, V]) removeItemsSampled(count int64) (evictCount int64) {
+	for ; evictCount < count && shard.list.Len() > 1; evictCount++ {
+		shard.sample = shard.nodes.Sample(shard.evictionSamples, shard.sample[:0])
+
+		victim := shard.sample[0]
+		for _, item := range shard.sample[1:] {
+			if shard.evictBefore(item, victim) {
+				victim = item
+			}
+		}
+		clear(shard.sample)
+
+		if shard.evictionPolicy == CostEviction {
+			shard.inflation.Store(victim.priority.Load())
+		}
+		shard.removeItem(victim, Evicted)
+	}
+
+	return evictCount
+}
+
+// evictBefore reports whether the item should be evicted before the other item: the item with the lower priority with
+// CostEviction, the item closer to its expiry with TTLEviction, an item which expires before an item which never
+// expires, otherwise the less recently used item.
+func (shard *lruCacheShard[K, V]) evictBefore(item, other *lruListNode[K, V]) bool {
+	if shard.evictionPolicy == CostEviction {
+		return math.Float64frombits(item.priority.Load()) < math.Float64frombits(other.priority.Load())
+	}
+	if shard.evictionPolicy == TTLEviction {
+		switch {
+		case !item.TTL.IsZero() && !other.TTL.IsZero():
+			return item.TTL.Before(other.TTL)
+		case !item.TTL.IsZero():
+			return true
+		case !other.TTL.IsZero():
+			return false
+		}
+	}
+
+	return item.accessedAt.Load() < other.accessedAt.Load()
+}
+
+// removeItem removes a specific item from the shard by reference.
+func (shard *lruCacheShard[K, V]) removeItem(item *lruListNode[K, V], reason RemovalReason) {
+	shard.list.Remove(item)
+	shard.nodes.Delete(item.Key)
+	shard.cost -= item.cost
+	shard.valueSizes.add(int64(len(item.Value)), -1)
+	shard.countBucket(item.Key, -1)
+	shard.dependencies.forget(item.Key)
+	if namespace := shard.chargeNamespace(item.Key, -1, -int64(len(item.Value))); namespace != nil && reason == Evicted {
+		namespace.evictions.Add(1)
+	}
+	if shard.wheel != nil {
+		shard.wheel.Unschedule(item)
+	}
+	if shard.bloom != nil {
+		shard.bloom.remove()
+	}
+
+	if shard.ghosts != nil && reason == Evicted {
+		shard.ghosts.evict(string(item.Key))
+	}
+	if shard.victims != nil {
+		if reason == Evicted {
+			shard.victims.add(shard, item)
+		} else {
+			shard.victims.discard(item.Key)
+		}
+	}
+
+	shard.recordEvict(item)
+	shard.recordRemove(item.Key, item.Value, reason)
+
+	if reason == Expired {
+		shard.notifyExpired(item.Key)
+	}
+}
+
+// notifyExpired sends the key of an expired cache item to the expired keys channel. If the channel is full, the oldest
+// key is dropped, so an unconsumed channel never blocks the shard.
+func (shard *lruCacheShard[K, V]) notifyExpired(key K) {
+	if cap(shard.expiredKeys) == 0 {
+		return
+	}
+
+	for {
+		select {
+		case shard.expiredKeys <- key:
+			return
+		default:
+		}
+
+		select {
+		case <-shard.expiredKeys:
+		default:
+		}
 	}
 }
 
 // CleanupShard handles the periodic cleanup of the shard.
 func (shard *lruCacheShard[K, V]) CleanupShard() (evictCount int64) {
-	now := time.Now()
-	for _, item := range shard.nodes {
-		if item.TTL.Before(now) && !item.TTL.IsZero() {
-			shard.removeItem(item)
+	defer shard.debugCheck()
+
+	now := timeNow()
+	for _, item := range shard.nodes.All() {
+		if item.expired(now) {
+			shard.removeItem(item, Expired)
+			evictCount++
+		}
+	}
+	shard.expireTombstones(now)
+	shard.refreshBloom()
+
+	return evictCount
+}
+
+// CleanupShardStep removes the expired cache items of the shard like CleanupShard, but in a step of a paced cleanup:
+// the step starts at the specified cache item, or at the oldest one if it is nil or was removed meanwhile, walks
+// towards the newest one and stops once it removed limit cache items or the deadline passed. It returns the cache item
+// to resume at, nil once the shard was swept. The cache items moved to the front between the steps may be skipped
+// until the next cleanup.
+func (shard *lruCacheShard[K, V]) CleanupShardStep(
+	from *lruListNode[K, V], limit int64, deadline time.Time,
+) (evictCount int64, next *lruListNode[K, V]) {
+	defer shard.debugCheck()
+
+	if from == nil || from.list != shard.list {
+		from = shard.Oldest()
+	}
+
+	now := timeNow()
+	for item, visited := from, 0; item != nil; item, visited = next, visited+1 {
+		if evictCount >= limit || (!deadline.IsZero() && visited%64 == 63 && time.Now().After(deadline)) {
+			return evictCount, item
+		}
+
+		next = item.Prev()
+		if item.expired(now) {
+			shard.removeItem(item, Expired)
 			evictCount++
 		}
 	}
+	shard.expireTombstones(now)
+	shard.refreshBloom()
+
+	return evictCount, nil
+}
+
+// AdvanceWheel advances the timing wheel of the shard to the specified time and removes the cache items, which expired
+// meanwhile.
+func (shard *lruCacheShard[K, V]) AdvanceWheel(now time.Time) (evictCount int64) {
+	defer shard.debugCheck()
+
+	for _, item := range shard.wheel.Advance(now) {
+		shard.removeItem(item, Expired)
+		evictCount++
+	}
+	shard.expireTombstones(now)
+	shard.refreshBloom()
 
 	return evictCount
 }
 
-// Set adds a key-value pair with a specific TTL (time to live) to the shard.
+// Set adds a key-value pair with a specific TTL (time to live) and metadata to the shard. The metadata of an existing
+// cache item is kept, if no metadata was specified.
+// If the cache is over its capacity, the oldest items of the shard are evicted in a batch of up to evictBatchSize items,
+// so that the cache shrinks back to its capacity, e.g. after a capacity reduction. If the shard is over its cost
+// capacity, items are evicted until it fits, which may evict the set cache item with the CostEviction policy.
 // This operation does updates the recent-ness of the cache item.
-func (shard *lruCacheShard[K, V]) Set(cacheLen int64, key K, value V, ttl time.Time) (evicted, added bool) {
-	newItem := shard.getItemFromPool(key, value, ttl)
+func (shard *lruCacheShard[K, V]) Set(
+	cacheLen int64, key K, value V, ttl time.Time, meta *EntryMeta,
+) (evictCount int64, added bool) {
+	defer shard.debugCheck()
 
-	if item, found := shard.nodes[key]; found {
-		shard.list.MoveToFront(item)
+	shard.clearTombstone(key)
+	if shard.victims != nil {
+		shard.victims.discard(key)
+	}
+
+	if item, found := shard.nodes.Get(key); found {
+		shard.promote(item)
+		oldValue := item.Value
 		item.Value = value
 		item.TTL = ttl
-
-		if shard.telemetryOn {
-			shard.onUpdate(shard.loggingOn, item)
+		if meta != nil {
+			item.Meta = meta
 		}
+		shard.stamp(item, meta)
+		shard.seal(item)
+		shard.charge(item)
+		shard.chargeNamespace(key, 0, int64(len(value)-len(oldValue)))
+		shard.valueSizes.add(int64(len(oldValue)), -1)
+		shard.valueSizes.add(int64(len(value)), 1)
+		item.Version = uint64(item.accessedAt.Load())
+		if shard.wheel != nil {
+			shard.wheel.Schedule(item)
+		}
+		shard.attribute(item)
+
+		shard.recordUpdate(item)
+		shard.recordRemove(key, oldValue, Replaced)
 
-		return false, false
+		return shard.removeItemsOverQuota(item) + shard.removeItemsCostly(), false
 	} else {
-		item := shard.list.PushFront(newItem)
-		shard.nodes[key] = item
+		item := shard.list.PushFront(shard.getItemFromPool(key, value, ttl, meta))
+		item.referenced.Store(shard.evictionPolicy == ClockEviction)
+		shard.nodes.Set(key, item)
+		if shard.bloom != nil {
+			shard.bloom.add(string(key))
+		}
+		shard.touch(item)
+		shard.stamp(item, meta)
+		shard.seal(item)
+		shard.charge(item)
+		shard.chargeNamespace(key, 1, int64(len(value)))
+		shard.valueSizes.add(int64(len(value)), 1)
+		shard.countBucket(key, 1)
+		item.Version = uint64(item.accessedAt.Load())
+		if shard.wheel != nil {
+			shard.wheel.Schedule(item)
+		}
+		shard.attribute(item)
+
+		shard.recordAdd(item)
 
-		if shard.telemetryOn {
-			shard.onAdd(shard.loggingOn, item)
+		evictCount = shard.removeItemsOverQuota(item)
+		if excess := cacheLen + 1 - evictCount - shard.maxItems; excess > 0 {
+			evictCount += shard.removeItemsOldest(min(excess, max(shard.evictBatchSize, 1)))
 		}
+		evictCount += shard.removeItemsCostly()
+
+		return evictCount, true
+	}
+}
+
+// PeekOrAdd retrieves a value by the specified key from the shard, and adds the key-value pair with a specific TTL
+// (time to live) if the key doesn't exist. An expired cache item of the key is replaced, without being added.
+// This operation doesn't updates the recent-ness of an existing cache item.
+func (shard *lruCacheShard[K, V]) PeekOrAdd(
+	cacheLen int64, key K, value V, ttl time.Time,
+) (previous V, existed bool, evictCount int64, added bool) {
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		shard.recordHit(item, now)
+
+		return item.Value, true, 0, false
+	}
+
+	evictCount, added = shard.Set(cacheLen, key, value, ttl, nil)
+
+	return previous, false, evictCount, added
+}
+
+// Get retrieves a value by the specified key from the shard. A corrupted cache item is evicted and not found.
+// This operation does updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) Get(key K) (value V, found, corrupted bool) {
+	defer shard.debugCheck()
+
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		if !shard.intact(item) {
+			shard.removeCorrupted(item)
 
-		evict := cacheLen >= shard.maxItems
-		if evict {
-			shard.removeItemOldest()
-		    return true, false
+			return *new(V), false, true
 		}
 
-		return false, true
+		shard.promote(item)
+
+		shard.recordHit(item, now)
+
+		return item.Value, true, false
+	} else {
+		shard.recordMiss(key)
+
+		return *new(V), false, false
 	}
 }
 
-// Get retrieves a value by the specified key from the shard.
+// GetStatus retrieves a value by the specified key from the shard and reports whether the key was found, missing or
+// found but expired.
 // This operation does updates the recent-ness of the cache item.
-func (shard *lruCacheShard[K, V]) Get(key K) (value V, found bool) {
-	if item, found := shard.nodes[key]; found {
-		shard.list.MoveToFront(item)
+func (shard *lruCacheShard[K, V]) GetStatus(key K, now time.Time) (value V, status ResultStatus) {
+	if shard.bloom != nil && !shard.bloom.mayContain(string(key)) {
+		shard.recordMiss(key)
+
+		return value, ResultMiss
+	}
+
+	item, found := shard.nodes.Get(key)
+	switch {
+	case !found:
+		shard.recordMiss(key)
+
+		return value, ResultMiss
+	case item.expired(now):
+		shard.recordMiss(key)
+
+		return value, ResultExpired
+	case !shard.intact(item):
+		shard.removeCorrupted(item)
+
+		return value, ResultCorrupted
+	}
+
+	shard.promote(item)
+
+	shard.recordHit(item, now)
+
+	return item.Value, ResultHit
+}
+
+// GetItem retrieves the cache item of the specified key from the shard. A corrupted cache item is evicted and not found.
+// This operation does updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) GetItem(key K) (item *lruListNode[K, V], found, corrupted bool) {
+	defer shard.debugCheck()
 
-		if shard.telemetryOn {
-			shard.onHit(shard.loggingOn, item)
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		if !shard.intact(item) {
+			shard.removeCorrupted(item)
+
+			return nil, false, true
 		}
 
-		return item.Value, true
+		shard.promote(item)
+
+		shard.recordHit(item, now)
+
+		return item, true, false
 	} else {
-		if shard.telemetryOn {
-			shard.onMiss(shard.loggingOn, key)
+		shard.recordMiss(key)
+
+		return nil, false, false
+	}
+}
+
+// GetWithTTL retrieves a value and its expiry time by the specified key from the shard. A corrupted cache item is
+// evicted and not found.
+// This operation does updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) GetWithTTL(key K) (value V, ttl time.Time, found, corrupted bool) {
+	defer shard.debugCheck()
+
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		if !shard.intact(item) {
+			shard.removeCorrupted(item)
+
+			return *new(V), time.Time{}, false, true
 		}
 
-		return *new(V), false
+		shard.promote(item)
+
+		shard.recordHit(item, now)
+
+		return item.Value, item.TTL, true, false
+	} else {
+		shard.recordMiss(key)
+
+		return *new(V), time.Time{}, false, false
+	}
+}
+
+// GetRecent retrieves a value by the specified key from the shard, as long as no recency update is needed, i.e. the
+// key was not found, the cache item is already the most recently used one, or the eviction policy doesn't move the
+// cache item, and its value matches its checksum. Otherwise done is false and nothing is recorded, so that the value must
+// be retrieved by Get.
+// Safe to call under the read lock of the shard, the hit or miss is recorded concurrently with the other readers, see
+// Config.OnHit.
+func (shard *lruCacheShard[K, V]) GetRecent(key K) (value V, found, done bool) {
+	now := timeNow()
+	item, found := shard.lookup(key, now)
+	if !found {
+		shard.recordMiss(key)
+
+		return *new(V), false, true
 	}
+
+	if (shard.evictionPolicy == LRUEviction || shard.evictionPolicy == FairEviction) && item != shard.list.Front() {
+		return *new(V), true, false
+	}
+	if !shard.intact(item) {
+		return *new(V), false, false
+	}
+
+	shard.promote(item)
+	shard.recordHit(item, now)
+
+	return item.Value, true, true
+}
+
+// Entry retrieves the cache item of the specified key from the shard.
+// This operation doesn't updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) Entry(key K) (item *lruListNode[K, V], found bool) {
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		shard.recordHit(item, now)
+
+		return item, true
+	} else {
+		shard.recordMiss(key)
+
+		return nil, false
+	}
+}
+
+// Oldest returns the oldest (least recently used) cache item of the shard, or nil if the shard is empty.
+// This operation doesn't updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) Oldest() (item *lruListNode[K, V]) {
+	return shard.list.Back()
+}
+
+// Newest returns the newest (most recently used) cache item of the shard, or nil if the shard is empty.
+// This operation doesn't updates the recent-ness of the cache item.
+func (shard *lruCacheShard[K, V]) Newest() (item *lruListNode[K, V]) {
+	return shard.list.Front()
 }
 
 // Contains checks if a specified key exists in the shard.
 // This operation doesn't updates the recent-ness of the cache item.
 func (shard *lruCacheShard[K, V]) Contains(key K) (found bool) {
-	if item, found := shard.nodes[key]; found {
-		if shard.telemetryOn {
-			shard.onHit(shard.loggingOn, item)
-		}
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		shard.recordHit(item, now)
 
 		return true
 	} else {
-		if shard.telemetryOn {
-			shard.onMiss(shard.loggingOn, key)
-		}
+		shard.recordMiss(key)
 
 		return false
 	}
@@ -214,31 +1045,37 @@ func (shard *lruCacheShard[K, V]) Contains(key K) (found bool) {
 // Peek retrieves a value by the specified key from the shard.
 // This operation doesn't updates the recent-ness of the cache item.
 func (shard *lruCacheShard[K, V]) Peek(key K) (value V, found bool) {
-	if item, found := shard.nodes[key]; found {
-		if shard.telemetryOn {
-			shard.onHit(shard.loggingOn, item)
-		}
+	now := timeNow()
+	if item, found := shard.lookup(key, now); found {
+		shard.recordHit(item, now)
 
 		return item.Value, true
 	} else {
-		if shard.telemetryOn {
-			shard.onMiss(shard.loggingOn, key)
-		}
+		shard.recordMiss(key)
 
 		return *new(V), false
 	}
 }
 
-// Remove removes a key-value pair from the shard.
+// Remove removes a key-value pair from the shard and records the tombstone of the key, also if it doesn't exist.
 func (shard *lruCacheShard[K, V]) Remove(key K) (removed bool) {
-	if item, found := shard.nodes[key]; found {
-		shard.removeItem(item)
+	defer shard.debugCheck()
+
+	now := timeNow()
+	if shard.hybridClock != nil {
+		now = shard.hybridClock.Now()
+	}
+	shard.recordTombstone(key, now)
+	if shard.victims != nil {
+		shard.victims.discard(key)
+	}
+
+	if item, found := shard.nodes.Get(key); found {
+		shard.removeItem(item, Deleted)
 
 		return true
 	} else {
-		if shard.telemetryOn {
-			shard.onMiss(shard.loggingOn, key)
-		}
+		shard.recordMiss(key)
 
 		return false
 	}
@@ -246,9 +1083,109 @@ func (shard *lruCacheShard[K, V]) Remove(key K) (removed bool) {
 
 // Purge clears all items in the shard.
 func (shard *lruCacheShard[K, V]) Purge() {
+	defer shard.debugCheck()
+
+	for key, item := range shard.nodes.All() {
+		shard.dependencies.forget(key)
+		shard.recordRemove(key, item.Value, Purged)
+	}
+	if shard.victims != nil {
+		shard.victims.discardShard(shard, nil)
+	}
+
 	shard.list = newLRUList[K, V]()
 	shard.nodesPool = generic_syncpool.New[lruListNode[K, V]]()
-	shard.nodes = make(map[K]*lruListNode[K, V], shard.maxItems)
+	shard.nodes = newShardIndex[K, V](shard.indexType, shard.maxItems)
+	shard.cost = 0
+	shard.valueSizes = Histogram{}
+	clear(shard.namespaceUsage)
+	clear(shard.bucketCounts)
+	shard.inflation.Store(0)
+	if shard.wheel != nil {
+		shard.wheel = newTimingWheel[K, V](shard.expiryResolution, time.Now())
+	}
+	if shard.bloom != nil {
+		shard.bloom = newBloomFilter(shard.bloomCapacity, shard.bloomFalsePositiveRate)
+	}
+}
+
+// refreshBloom rebuilds the bloom filter of the shard from the keys of the shard, if it is stale. Must be called with
+// the lock held.
+func (shard *lruCacheShard[K, V]) refreshBloom() {
+	if shard.bloom == nil || !shard.bloom.stale() {
+		return
+	}
+
+	shard.bloom = newBloomFilter(max(shard.bloomCapacity, shard.Len()), shard.bloomFalsePositiveRate)
+	for key := range shard.nodes.All() {
+		shard.bloom.add(string(key))
+	}
+}
+
+// debugCheck panics with the diagnostics of the violated invariant, if the debug checks are enabled and an invariant of
+// the shard is violated. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) debugCheck() {
+	if !shard.debugChecks {
+		return
+	}
+
+	if err := shard.checkInvariants(); err != nil {
+		panic(err.Error())
+	}
+}
+
+// checkInvariants verifies that the list and the index of the shard hold the same cache items, that the nodes of the
+// list are linked consistently and belong to the list, that the bloom filter passes their keys, and that their costs
+// and the usage of their namespaces add up to the totals of the shard. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) checkInvariants() (err error) {
+	if listLen, indexLen := shard.list.Len(), shard.nodes.Len(); listLen != indexLen {
+		return fmt.Errorf("sq_cache: shard %d list length %d != index length %d", shard.id, listLen, indexLen)
+	}
+
+	count, cost := 0, int64(0)
+	var valueSizes Histogram
+	usage := make([]namespaceUsage, len(shard.namespaceUsage))
+	for item := shard.list.root.next; item != shard.list.root; item = item.next {
+		cost += item.cost
+		valueSizes.add(int64(len(item.Value)), 1)
+		if shard.namespaces != nil {
+			if index := shard.namespaces.indexOf(item.Key); index >= 0 {
+				usage[index].items++
+				usage[index].bytes += int64(len(item.Value))
+			}
+		}
+		if count++; count > shard.list.Len() {
+			return fmt.Errorf("sq_cache: shard %d list holds more nodes than its length %d", shard.id, shard.list.Len())
+		}
+		if item.list != shard.list {
+			return fmt.Errorf("sq_cache: shard %d node %v doesn't belong to the list of the shard", shard.id, item.Key)
+		}
+		if item.next.prev != item {
+			return fmt.Errorf("sq_cache: shard %d node %v is not linked back by its next node", shard.id, item.Key)
+		}
+		if indexed, found := shard.nodes.Get(item.Key); !found || indexed != item {
+			return fmt.Errorf("sq_cache: shard %d node %v is not the indexed node of its key", shard.id, item.Key)
+		}
+		if shard.bloom != nil && !shard.bloom.mayContain(string(item.Key)) {
+			return fmt.Errorf("sq_cache: shard %d node %v is missing in the bloom filter", shard.id, item.Key)
+		}
+	}
+	if count != shard.list.Len() {
+		return fmt.Errorf("sq_cache: shard %d list holds %d nodes != its length %d", shard.id, count, shard.list.Len())
+	}
+	if cost != shard.cost {
+		return fmt.Errorf("sq_cache: shard %d cache items cost %d != its total cost %d", shard.id, cost, shard.cost)
+	}
+	if valueSizes != shard.valueSizes {
+		return fmt.Errorf("sq_cache: shard %d value sizes %v != its accounted value sizes %v", shard.id, valueSizes.Counts,
+			shard.valueSizes.Counts)
+	}
+	if !slices.Equal(usage, shard.namespaceUsage) {
+		return fmt.Errorf("sq_cache: shard %d namespace usage %v != its accounted usage %v", shard.id, usage,
+			shard.namespaceUsage)
+	}
+
+	return nil
 }
 
 // telemetry returns the shard's telemetry (add, update, hit, miss, evict counters).
@@ -258,5 +1195,5 @@ func (shard *lruCacheShard[K, V]) Telemetry() (telemetry *telemetry) {
 
 // telemetryReset resets the shard's telemetry counters (add, update, hit, miss, evict) to zero.
 func (shard *lruCacheShard[K, V]) TelemetryReset() {
-	shard.telemetry = newTelemetry()
+	shard.telemetry.reset()
 }
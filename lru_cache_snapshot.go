@@ -0,0 +1,616 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"iter"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// snapshotMagic identifies the snapshots, snapshotVersion is the version of their format. The version 1 snapshots
+// consist of gob-encoded batches of entries, the version 2 snapshots consist of gob-encoded chunks and end with a
+// checksum of the entries. The version 3 snapshots consist of binary record frames and a trailer frame, see format.go,
+// and don't break with the changes of the Entry struct.
+const (
+	snapshotMagic   = "SQS"
+	snapshotVersion = 3
+)
+
+// snapshotEnd is the operation of the trailer frame, which ends a version 3 snapshot.
+const snapshotEnd walOp = 0xff
+
+// snapshotChunk is a chunk of a version 2 snapshot, holding either a batch of entries, or the number of entries and
+// their checksum at the end of the snapshot.
+type snapshotChunk[K IKey, V IValue] struct {
+	Entries  []Entry[K, V]
+	Count    int64
+	Checksum []byte
+}
+
+// snapshotHash is the SHA-256 hash of the keys, values and expiry times of the entries of a snapshot.
+type snapshotHash[K IKey, V IValue] struct {
+	hash.Hash
+	buf [binary.MaxVarintLen64]byte
+}
+
+// newSnapshotHash creates an empty snapshot hash.
+func newSnapshotHash[K IKey, V IValue]() (h *snapshotHash[K, V]) {
+	return &snapshotHash[K, V]{Hash: sha256.New()}
+}
+
+// add adds the key, value and expiry time of an entry to the hash. The lengths are prefixed, so that the boundaries of
+// the keys and values are part of the hash.
+func (h *snapshotHash[K, V]) add(entry Entry[K, V]) {
+	h.Write(h.buf[:binary.PutUvarint(h.buf[:], uint64(len(entry.Key)))])
+	h.Write([]byte(entry.Key))
+	h.Write(h.buf[:binary.PutUvarint(h.buf[:], uint64(len(entry.Value)))])
+	h.Write(entry.Value)
+
+	var ttl int64
+	if !entry.TTL.IsZero() {
+		ttl = entry.TTL.UnixNano()
+	}
+	h.Write(h.buf[:binary.PutVarint(h.buf[:], ttl)])
+}
+
+// snapshotSuffix is the suffix of the snapshot names.
+const snapshotSuffix = ".snap"
+
+// SnapshotStore is an interface that stores the snapshots of a cache by name, e.g. in a directory or an object store.
+type SnapshotStore interface {
+	// Create creates a snapshot with the specified name, which is complete once the writer was closed without error.
+	Create(name string) (w io.WriteCloser, err error)
+	// Open opens the snapshot with the specified name.
+	Open(name string) (r io.ReadCloser, err error)
+	// List returns the names of the complete snapshots.
+	List() (names []string, err error)
+	// Remove removes the snapshot with the specified name.
+	Remove(name string) (err error)
+}
+
+// SnapshotPolicy configures the automatic snapshots of a cache.
+type SnapshotPolicy struct {
+	// Store stores the snapshots.
+	Store SnapshotStore
+	// Interval is the interval of the periodic snapshots. Zero disables the periodic snapshots.
+	Interval time.Duration
+	// OnClose takes a snapshot, when the cache is closed.
+	OnClose bool
+	// MaxSnapshots is the number of snapshots retained, the older snapshots are removed. Zero retains all snapshots.
+	MaxSnapshots int
+	// RestoreOnStart restores the latest snapshot, when the cache is created.
+	RestoreOnStart bool
+}
+
+// DirSnapshotStore is a SnapshotStore, which stores the snapshots as files in a directory.
+type DirSnapshotStore struct {
+	Dir string
+}
+
+// Create creates the file of a snapshot. The snapshot is written to a temporary file, which is renamed once the writer
+// was closed, so that an incomplete snapshot is never listed.
+func (store DirSnapshotStore) Create(name string) (w io.WriteCloser, err error) {
+	if err = os.MkdirAll(store.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp(store.Dir, name+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &dirSnapshotFile{File: file, path: filepath.Join(store.Dir, name)}, nil
+}
+
+// Open opens the file of a snapshot.
+func (store DirSnapshotStore) Open(name string) (r io.ReadCloser, err error) {
+	return os.Open(filepath.Join(store.Dir, name))
+}
+
+// List returns the names of the snapshot files in the directory, sorted from the oldest to the newest.
+func (store DirSnapshotStore) List() (names []string, err error) {
+	entries, err := os.ReadDir(store.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), snapshotSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Remove removes the file of a snapshot.
+func (store DirSnapshotStore) Remove(name string) (err error) {
+	return os.Remove(filepath.Join(store.Dir, name))
+}
+
+// dirSnapshotFile represents the temporary file of a snapshot, which is renamed to its path on Close.
+type dirSnapshotFile struct {
+	*os.File
+	path string
+}
+
+// Close syncs and closes the temporary file and renames it to the path of the snapshot.
+func (file *dirSnapshotFile) Close() (err error) {
+	if err = file.File.Sync(); err != nil {
+		_ = file.File.Close()
+		_ = os.Remove(file.File.Name())
+		return err
+	}
+	if err = file.File.Close(); err != nil {
+		_ = os.Remove(file.File.Name())
+		return err
+	}
+
+	return os.Rename(file.File.Name(), file.path)
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes to the underlying writer and counts the written bytes.
+func (cw *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = cw.w.Write(p)
+	cw.n += int64(n)
+
+	return n, err
+}
+
+// WriteSnapshot writes a snapshot of the cache items to the specified writer, streamed by ExportEntries. The snapshot
+// ends with a SHA-256 checksum of the cache items, which is validated by ReadSnapshot. Any writer can be used, e.g. a
+// file, a network connection or the writer of an object store.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the snapshot.
+//   - w: The writer to write the snapshot to.
+//
+// Returns:
+//   - count: The number of cache items in the snapshot.
+//   - err: An error if the cache is stopped or closed, if the context is done, if the snapshot can't be written, or if
+//     any other issue occurs.
+//
+// Example Usage:
+//
+//	file, err := os.Create("cache.snap")
+//	count, err := cache.WriteSnapshot(ctx, file)
+func (cache *LRUCache[K, V]) WriteSnapshot(ctx context.Context, w io.Writer) (count int64, err error) {
+	return cache.writeSnapshot(ctx, w, cache.ExportEntries)
+}
+
+// WriteNewestSnapshot writes a snapshot of the specified number of the most recently accessed cache items to the
+// specified writer, streamed by ExportNewest, e.g. to warm a freshly started instance with the hot cache items of a
+// peer. The snapshot is read by ReadSnapshot.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the snapshot.
+//   - w: The writer to write the snapshot to.
+//   - n: The maximum number of cache items in the snapshot.
+//
+// Returns:
+//   - count: The number of cache items in the snapshot.
+//   - err: An error if the cache is stopped or closed, if the context is done, if the snapshot can't be written, or if
+//     any other issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.WriteNewestSnapshot(ctx, w, 10000)
+func (cache *LRUCache[K, V]) WriteNewestSnapshot(ctx context.Context, w io.Writer, n int64) (count int64, err error) {
+	return cache.writeSnapshot(ctx, w, func(ctx context.Context, fn func(batch []Entry[K, V]) error) (int64, error) {
+		return cache.ExportNewest(ctx, n, fn)
+	})
+}
+
+// WriteHotSnapshot writes a snapshot of the cached items of the specified number of the most frequently accessed keys
+// to the specified writer, streamed by ExportHot, e.g. to replicate the hot cache items to further peers. The snapshot
+// is read by ReadSnapshot or ReadSnapshotFunc.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the snapshot.
+//   - w: The writer to write the snapshot to.
+//   - n: The maximum number of hot keys.
+//
+// Returns:
+//   - count: The number of cache items in the snapshot.
+//   - err: An error if the tracking is disabled, if the cache is stopped or closed, if the context is done, if the
+//     snapshot can't be written, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.WriteHotSnapshot(ctx, w, 1000)
+func (cache *LRUCache[K, V]) WriteHotSnapshot(ctx context.Context, w io.Writer, n int) (count int64, err error) {
+	return cache.writeSnapshot(ctx, w, func(ctx context.Context, fn func(batch []Entry[K, V]) error) (int64, error) {
+		return cache.ExportHot(ctx, n, fn)
+	})
+}
+
+// writeSnapshot writes a snapshot of the cache items streamed by the specified export to the writer.
+func (cache *LRUCache[K, V]) writeSnapshot(
+	ctx context.Context, w io.Writer, export func(ctx context.Context, fn func(batch []Entry[K, V]) error) (int64, error),
+) (count int64, err error) {
+	bw := bufio.NewWriter(w)
+	if _, err = bw.Write(appendFormatHeader(nil, snapshotMagic, snapshotVersion)); err != nil {
+		return 0, err
+	}
+
+	h := newSnapshotHash[K, V]()
+	var buf []byte
+	count, err = export(ctx, func(batch []Entry[K, V]) error {
+		buf = buf[:0]
+		for _, entry := range batch {
+			h.add(entry)
+
+			var meta *EntryMeta
+			if !entry.Meta.isZero() {
+				meta = &entry.Meta
+			}
+			buf = appendRecord(buf, walSet, entry.Key, entry.Value, entry.TTL, meta)
+		}
+		_, err := bw.Write(buf)
+		return err
+	})
+	if err != nil {
+		return count, err
+	}
+
+	trailer := []byte{byte(snapshotEnd)}
+	trailer = binary.AppendUvarint(trailer, uint64(count))
+	checksum := h.Sum(nil)
+	trailer = binary.AppendUvarint(trailer, uint64(len(checksum)))
+	trailer = append(trailer, checksum...)
+	if _, err = bw.Write(appendFrame(nil, trailer)); err != nil {
+		return count, err
+	}
+
+	return count, bw.Flush()
+}
+
+// ReadSnapshot restores the cache items of a snapshot written by WriteSnapshot, streamed by ImportEntries. The cache
+// items of the snapshot keep their expiry times, expired cache items are skipped. The snapshot is read completely and
+// its checksum is validated before any cache item is restored, so a truncated or corrupted snapshot doesn't restore
+// partial data. The snapshots of all format versions are read, the snapshots of the version 1 format don't have a
+// checksum and are restored batch by batch.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the restore.
+//   - r: The reader to read the snapshot from.
+//
+// Returns:
+//   - count: The number of restored cache items.
+//   - err: ErrSnapshotChecksum if the checksum doesn't match, ErrUnsupportedFormat if the snapshot was written in an
+//     unknown format version, e.g. by a newer version of the package, an error if the cache is stopped or closed, if
+//     the context is done, if the snapshot is invalid, or if any other issue occurs.
+func (cache *LRUCache[K, V]) ReadSnapshot(ctx context.Context, r io.Reader) (count int64, err error) {
+	return cache.ReadSnapshotFunc(ctx, r, nil)
+}
+
+// ReadSnapshotFunc restores the cache items of a snapshot like ReadSnapshot, but only the cache items accepted by the
+// specified function, e.g. only the keys a peer is responsible for. The checksum is validated over all cache items of
+// the snapshot.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the restore.
+//   - r: The reader to read the snapshot from.
+//   - keep: The function accepting the cache items to restore, nil restores all cache items.
+//
+// Returns:
+//   - count: The number of restored cache items.
+//   - err: The errors of ReadSnapshot.
+//
+// Example Usage:
+//
+//	count, err := cache.ReadSnapshotFunc(ctx, r, func(entry sq_cache.Entry[string, []byte]) bool {
+//	    return strings.HasPrefix(entry.Key, "user:")
+//	})
+func (cache *LRUCache[K, V]) ReadSnapshotFunc(
+	ctx context.Context, r io.Reader, keep func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	br := bufio.NewReader(r)
+
+	version, err := readFormatHeader(br, snapshotMagic, snapshotVersion, 3)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	switch version {
+	case 1:
+		return cache.readSnapshotBatches(ctx, gob.NewDecoder(br), keep)
+	case 2:
+		return cache.readSnapshotChunks(ctx, gob.NewDecoder(br), keep)
+	default:
+		return cache.readSnapshotFrames(ctx, br, keep)
+	}
+}
+
+// readSnapshotFrames reads the frames of a version 3 snapshot, validates the checksum and restores the cache items.
+func (cache *LRUCache[K, V]) readSnapshotFrames(
+	ctx context.Context, r *bufio.Reader, keep func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	var entries []Entry[K, V]
+	h := newSnapshotHash[K, V]()
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		var payload []byte
+		if payload, err = readFrame(r); errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("invalid snapshot: %w", io.ErrUnexpectedEOF)
+		} else if errors.Is(err, errFrameChecksum) {
+			return 0, ErrSnapshotChecksum
+		} else if err != nil {
+			return 0, fmt.Errorf("invalid snapshot: %w", err)
+		}
+
+		if len(payload) > 0 && walOp(payload[0]) == snapshotEnd {
+			decoder := &walDecoder{payload: payload[1:]}
+			entryCount := decoder.readUvarint()
+			checksum := decoder.readBytes()
+			if decoder.err != nil {
+				return 0, fmt.Errorf("invalid snapshot: %w", decoder.err)
+			}
+			if entryCount != uint64(len(entries)) || !bytes.Equal(checksum, h.Sum(nil)) {
+				return 0, ErrSnapshotChecksum
+			}
+			break
+		}
+
+		var record walRecord[K, V]
+		if record, err = decodeRecord[K, V](payload); err != nil {
+			return 0, fmt.Errorf("invalid snapshot: %w", err)
+		}
+		if record.op != walSet {
+			return 0, fmt.Errorf("invalid snapshot: unexpected record %d", record.op)
+		}
+
+		entry := Entry[K, V]{Key: record.key, Value: record.value, TTL: record.ttl}
+		if record.meta != nil {
+			entry.Meta = *record.meta
+		}
+		h.add(entry)
+		entries = append(entries, entry)
+	}
+
+	return cache.ImportEntries(ctx, keptEntries(slices.Values(entries), keep))
+}
+
+// readSnapshotChunks reads the chunks of a version 2 snapshot, validates the checksum and restores the cache items.
+func (cache *LRUCache[K, V]) readSnapshotChunks(
+	ctx context.Context, decoder *gob.Decoder, keep func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	var batches [][]Entry[K, V]
+	var entries int64
+	h := newSnapshotHash[K, V]()
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		var chunk snapshotChunk[K, V]
+		if err = decoder.Decode(&chunk); errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("invalid snapshot: %w", io.ErrUnexpectedEOF)
+		} else if err != nil {
+			return 0, fmt.Errorf("invalid snapshot: %w", err)
+		}
+
+		if chunk.Checksum != nil {
+			if chunk.Count != entries || !bytes.Equal(chunk.Checksum, h.Sum(nil)) {
+				return 0, ErrSnapshotChecksum
+			}
+			break
+		}
+
+		for _, entry := range chunk.Entries {
+			h.add(entry)
+		}
+		entries += int64(len(chunk.Entries))
+		batches = append(batches, chunk.Entries)
+	}
+
+	for _, batch := range batches {
+		var imported int64
+		imported, err = cache.ImportEntries(ctx, keptEntries(slices.Values(batch), keep))
+		count += imported
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// readSnapshotBatches reads the batches of a version 1 snapshot and restores the cache items batch by batch.
+func (cache *LRUCache[K, V]) readSnapshotBatches(
+	ctx context.Context, decoder *gob.Decoder, keep func(entry Entry[K, V]) bool,
+) (count int64, err error) {
+	for {
+		var batch []Entry[K, V]
+		if err = decoder.Decode(&batch); errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return count, fmt.Errorf("invalid snapshot: %w", err)
+		}
+
+		var imported int64
+		imported, err = cache.ImportEntries(ctx, keptEntries(slices.Values(batch), keep))
+		count += imported
+		if err != nil {
+			return count, err
+		}
+	}
+}
+
+// keptEntries filters the specified entries by the keep function, nil keeps all entries.
+func keptEntries[K IKey, V IValue](
+	entries iter.Seq[Entry[K, V]], keep func(entry Entry[K, V]) bool,
+) (kept iter.Seq[Entry[K, V]]) {
+	if keep == nil {
+		return entries
+	}
+
+	return func(yield func(Entry[K, V]) bool) {
+		for entry := range entries {
+			if keep(entry) && !yield(entry) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot writes a snapshot of the cache items to the store of the snapshot policy and removes the snapshots exceeding
+// the retention of the policy.
+//
+// Returns:
+//   - name: The name of the written snapshot.
+//   - err: An error if no snapshot policy is configured, if the cache is stopped or closed, if the snapshot can't be
+//     written, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	name, err := cache.Snapshot()
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Snapshot() (name string, err error) {
+	return cache.snapshot(cache.ExportEntries)
+}
+
+// snapshot writes a snapshot of the cache items streamed by the specified export function to the store of the snapshot
+// policy. See Snapshot.
+func (cache *LRUCache[K, V]) snapshot(
+	export func(ctx context.Context, fn func(batch []Entry[K, V]) error) (int64, error),
+) (name string, err error) {
+	if cache.snapshotPolicy == nil || cache.snapshotPolicy.Store == nil {
+		return "", errors.New("cache snapshot policy is not configured")
+	}
+
+	store := cache.snapshotPolicy.Store
+	name = fmt.Sprintf("%s-%020d%s", LibraryName, time.Now().UnixNano(), snapshotSuffix)
+
+	w, err := store.Create(name)
+	if err != nil {
+		return "", err
+	}
+
+	cw := &countingWriter{w: w}
+	count, err := cache.writeSnapshot(context.Background(), cw, export)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	cache.snapshotTime.Store(time.Now().Unix())
+	cache.snapshotBytes.Store(cw.n)
+	cache.snapshotItems.Store(count)
+
+	if cache.snapshotPolicy.MaxSnapshots > 0 {
+		names, err := store.List()
+		if err != nil {
+			return name, err
+		}
+		for len(names) > cache.snapshotPolicy.MaxSnapshots {
+			if err = store.Remove(names[0]); err != nil {
+				return name, err
+			}
+			names = names[1:]
+		}
+	}
+
+	return name, nil
+}
+
+// restoreSnapshot restores the latest snapshot of the store of the snapshot policy, if any.
+func (cache *LRUCache[K, V]) restoreSnapshot() (count int64, err error) {
+	names, err := cache.snapshotPolicy.Store.List()
+	if err != nil || len(names) == 0 {
+		return 0, err
+	}
+
+	r, err := cache.snapshotPolicy.Store.Open(names[len(names)-1])
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	return cache.ReadSnapshot(WithOrigin(context.Background(), RestoreOrigin), r)
+}
+
+// snapshotTicker takes the periodic snapshots of the snapshot policy, until the context is done.
+func (cache *LRUCache[K, V]) snapshotTicker(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(cache.snapshotPolicy.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if cache.Status() != Started {
+				continue
+			}
+			if _, err := cache.Snapshot(); err != nil && cache.loggingOn {
+				log.Printf("%s: snapshot - %v", LibraryName, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
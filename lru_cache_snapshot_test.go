@@ -0,0 +1,100 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// writeTestSnapshot returns a snapshot of a cache holding the specified cache items.
+func writeTestSnapshot(t testing.TB, items map[string]string) (snapshot []byte) {
+	t.Helper()
+
+	cache := newTestCache(t, &Config[string, []byte]{})
+	for key, value := range items {
+		if _, err := cache.SetWithMeta(key, []byte(value), 0, EntryMeta{Source: "test", Cost: 1}); err != nil {
+			t.Fatalf("SetWithMeta() error = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := cache.WriteSnapshot(context.Background(), &buf); err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	items := map[string]string{"a": "1", "b": "2", "c": ""}
+	snapshot := writeTestSnapshot(t, items)
+
+	cache := newTestCache(t, &Config[string, []byte]{})
+	if count, err := cache.ReadSnapshot(context.Background(), bytes.NewReader(snapshot)); err != nil ||
+		count != int64(len(items)) {
+		t.Fatalf("ReadSnapshot() = %d, %v, want %d, nil", count, err, len(items))
+	}
+
+	for key, value := range items {
+		if got, err := cache.Get(key); err != nil || string(got) != value {
+			t.Errorf("Get(%q) = %q, %v, want %q, nil", key, got, err, value)
+		}
+	}
+
+	truncated := snapshot[:len(snapshot)-1]
+	if count, err := cache.ReadSnapshot(context.Background(), bytes.NewReader(truncated)); err == nil {
+		t.Errorf("ReadSnapshot() of a truncated snapshot = %d, nil, want an error", count)
+	}
+}
+
+func FuzzReadSnapshot(f *testing.F) {
+	f.Add(writeTestSnapshot(f, map[string]string{"a": "1", "b": "2"}))
+	f.Add(writeTestSnapshot(f, nil))
+	f.Add(appendFormatHeader(nil, snapshotMagic, snapshotVersion))
+	f.Add([]byte(snapshotMagic))
+
+	cache := newTestCache(f, &Config[string, []byte]{})
+
+	f.Fuzz(func(t *testing.T, snapshot []byte) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// Only the version 1 snapshots, which don't have a checksum, restore the cache items read before an error.
+		count, err := cache.ReadSnapshot(ctx, bytes.NewReader(snapshot))
+		if err != nil && count != 0 && !bytes.HasPrefix(snapshot, []byte(snapshotMagic+"\x01")) {
+			t.Fatalf("ReadSnapshot() = %d, %v, want no restored cache items on error", count, err)
+		}
+	})
+}
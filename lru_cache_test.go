@@ -0,0 +1,410 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+// newTestCache creates a small cache for the tests, with the logging disabled, which is closed when the test ends. The
+// MaxShards and MaxItems default to 4 and 100, if they weren't specified.
+func newTestCache(t testing.TB, config *Config[string, []byte]) (cache *LRUCache[string, []byte]) {
+	t.Helper()
+
+	if config == nil {
+		config = &Config[string, []byte]{}
+	}
+	if config.MaxShards == 0 {
+		config.MaxShards = 4
+	}
+	if config.MaxItems == 0 {
+		config.MaxItems = 100
+	}
+	config.LoggingDisabled = true
+
+	cache, err := NewLRUCache[string, []byte](context.Background(), config)
+	if err != nil {
+		t.Fatalf("NewLRUCache() error = %v", err)
+	}
+	t.Cleanup(cache.Close)
+
+	return cache
+}
+
+// waitFor waits until the condition is met, failing the test after a second.
+func waitFor(t testing.TB, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition wasn't met within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// nopAuditSink is an AuditSink discarding the audit events.
+type nopAuditSink struct{}
+
+func (nopAuditSink) Audit(events []AuditEvent[string]) {}
+
+// newLeakTestConfig returns a configuration starting all goroutines of a cache: the cleanup, snapshot, write-ahead log
+// and write buffer tickers, the audit log, the Set coalescers and the bulk loads.
+func newLeakTestConfig(t *testing.T) (config *Config[string, []byte]) {
+	return &Config[string, []byte]{
+		CleanupInterval:   time.Millisecond * 10,
+		SetCoalesceWindow: time.Millisecond * 10,
+		WriteBufferSize:   16,
+		Snapshot:          &SnapshotPolicy{Store: DirSnapshotStore{Dir: t.TempDir()}, Interval: time.Millisecond * 10, OnClose: true},
+		WAL:               &WALPolicy{Dir: t.TempDir(), SyncInterval: time.Millisecond * 10, CompactInterval: time.Millisecond * 10},
+		AuditSink:         nopAuditSink{},
+		BulkLoader: func(ctx context.Context, keys []string) (map[string][]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		BulkLoadWindow: time.Millisecond * 10,
+	}
+}
+
+func TestCloseTerminatesGoroutines(t *testing.T) {
+	closes := map[string]func(cache *LRUCache[string, []byte]){
+		"Close": func(cache *LRUCache[string, []byte]) {
+			cache.Close()
+		},
+		"StopAndClose": func(cache *LRUCache[string, []byte]) {
+			cache.Stop()
+			cache.Close()
+		},
+		"RestartAndClose": func(cache *LRUCache[string, []byte]) {
+			for range 10 {
+				cache.Stop()
+				cache.Start()
+			}
+			cache.Close()
+		},
+		"CloseContext": func(cache *LRUCache[string, []byte]) {
+			if err := cache.CloseContext(context.Background()); err != nil {
+				t.Errorf("CloseContext() error = %v", err)
+			}
+		},
+	}
+
+	for name, closeCache := range closes {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+			cache := newTestCache(t, newLeakTestConfig(t))
+
+			for _, key := range []string{"a", "b", "c"} {
+				if _, err := cache.Set(key, []byte(key)); err != nil {
+					t.Fatalf("Set(%q) error = %v", key, err)
+				}
+			}
+			loaded := make(chan struct{})
+			go func() {
+				defer close(loaded)
+				_, _ = cache.GetOrLoad(context.Background(), "missing")
+			}()
+			// Let the tickers tick, and the bulk load start.
+			time.Sleep(time.Millisecond * 50)
+
+			closeCache(cache)
+			<-loaded
+		})
+	}
+}
+
+func TestSetsApplyDefaultTTL(t *testing.T) {
+	sets := map[string]func(cache *LRUCache[string, []byte], key string) error{
+		"Set": func(cache *LRUCache[string, []byte], key string) error {
+			_, err := cache.Set(key, []byte(key))
+			return err
+		},
+		"SetWithTTL": func(cache *LRUCache[string, []byte], key string) error {
+			_, err := cache.SetWithTTL(key, []byte(key), 0)
+			return err
+		},
+		"SetWithCost": func(cache *LRUCache[string, []byte], key string) error {
+			_, err := cache.SetWithCost(key, []byte(key), 1)
+			return err
+		},
+		"ContainsOrAdd": func(cache *LRUCache[string, []byte], key string) error {
+			_, _, err := cache.ContainsOrAdd(key, []byte(key))
+			return err
+		},
+		"PeekOrAdd": func(cache *LRUCache[string, []byte], key string) error {
+			_, _, err := cache.PeekOrAdd(key, []byte(key))
+			return err
+		},
+		"UpdateSet": func(cache *LRUCache[string, []byte], key string) error {
+			return cache.Update([]string{key}, func(view *TxView[string, []byte]) error {
+				return view.Set(key, []byte(key))
+			})
+		},
+		"Warm": func(cache *LRUCache[string, []byte], key string) error {
+			_, err := cache.Warm(context.Background(), func(yield func(string, []byte) bool) {
+				yield(key, []byte(key))
+			})
+			return err
+		},
+	}
+
+	for name, set := range sets {
+		t.Run(name, func(t *testing.T) {
+			for _, config := range []*Config[string, []byte]{
+				{DefaultTTL: time.Minute},
+				{TTLFunc: func(key string, value []byte) time.Duration { return time.Minute }},
+			} {
+				cache := newTestCache(t, config)
+
+				if err := set(cache, "a"); err != nil {
+					t.Fatalf("%s() error = %v", name, err)
+				}
+
+				_, ttl, found, err := cache.GetWithTTL("a")
+				if err != nil || !found {
+					t.Fatalf("GetWithTTL() = %t, %v, want found", found, err)
+				}
+				if ttl <= 0 || ttl > time.Minute {
+					t.Errorf("GetWithTTL() ttl = %v, want at most %v", ttl, time.Minute)
+				}
+			}
+		})
+	}
+}
+
+func TestSetsStayWithinCapacity(t *testing.T) {
+	cache := newTestCache(t, &Config[string, []byte]{MaxShards: 16, MaxItems: 4})
+
+	for i := range 100 {
+		key := strconv.Itoa(i)
+		if _, err := cache.Set(key, []byte(key)); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+		if n := cache.Len(); n > 4 {
+			t.Fatalf("Len() = %d after Set(%q), want at most 4", n, key)
+		}
+	}
+	if value, err := cache.Get("99"); err != nil || string(value) != "99" {
+		t.Errorf("Get(%q) = %q, %v, want %q", "99", value, err, "99")
+	}
+}
+
+func TestSetCoalescing(t *testing.T) {
+	t.Run("ReadYourWrites", func(t *testing.T) {
+		cache := newTestCache(t, &Config[string, []byte]{SetCoalesceWindow: time.Hour, WriteBufferReadYourWrites: true})
+
+		for _, value := range []string{"1", "2", "3"} {
+			if _, err := cache.Set("a", []byte(value)); err != nil {
+				t.Fatalf("Set(%q) error = %v", value, err)
+			}
+			if got, err := cache.Get("a"); err != nil || string(got) != value {
+				t.Errorf("Get() = %q, %v, want %q", got, err, value)
+			}
+		}
+	})
+
+	t.Run("RemoveDropsCoalescedSet", func(t *testing.T) {
+		cache := newTestCache(t, &Config[string, []byte]{SetCoalesceWindow: time.Millisecond * 10})
+
+		for _, value := range []string{"1", "2"} {
+			if _, err := cache.Set("a", []byte(value)); err != nil {
+				t.Fatalf("Set(%q) error = %v", value, err)
+			}
+		}
+		if _, err := cache.Remove("a"); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		time.Sleep(time.Millisecond * 30)
+
+		if got, _ := cache.Get("a"); got != nil {
+			t.Errorf("Get() = %q after the window, want the removed key not to be resurrected", got)
+		}
+	})
+}
+
+func TestNewLRUCacheKeepsUserConfig(t *testing.T) {
+	config := &Config[string, []byte]{ExpiryDurationInSeconds: 60, CleanupDurationInSeconds: 30}
+
+	cache := newTestCache(t, config)
+
+	if config.DefaultTTL != 0 || config.CleanupInterval != 0 || config.EvictBatchSize != 0 || config.OnAdd != nil {
+		t.Errorf("NewLRUCache() changed the user configuration to %+v", config)
+	}
+	if got := cache.Config(); got.DefaultTTL != time.Minute || got.CleanupInterval != time.Second*30 {
+		t.Errorf("Config() = %v, %v, want %v, %v", got.DefaultTTL, got.CleanupInterval, time.Minute, time.Second*30)
+	}
+}
+
+func TestDeprecatedSwitchesOverrideDisabled(t *testing.T) {
+	cache := newTestCache(t, &Config[string, []byte]{
+		TelemetryDisabled: true, CallbacksDisabled: true, TelemetryOn: true,
+	})
+
+	if got := cache.Config(); !got.TelemetryOn || got.CallbacksOn {
+		t.Errorf("Config() TelemetryOn, CallbacksOn = %t, %t, want true, false", got.TelemetryOn, got.CallbacksOn)
+	}
+}
+
+func TestSetWithTTLRejectsTooShortTTL(t *testing.T) {
+	cache := newTestCache(t, nil)
+
+	if _, err := cache.SetWithTTL("a", []byte("a"), 30); !errors.Is(err, ErrTTLTooShort) {
+		t.Errorf("SetWithTTL(30) error = %v, want %v", err, ErrTTLTooShort)
+	}
+	for _, duration := range []time.Duration{0, NoExpiry, MinTTL, time.Second * 30} {
+		if _, err := cache.SetWithTTL("a", []byte("a"), duration); err != nil {
+			t.Errorf("SetWithTTL(%v) error = %v, want nil", duration, err)
+		}
+	}
+}
+
+func TestCloseWaitsForRunningOperations(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	cache := newTestCache(t, &Config[string, []byte]{
+		OnAdd: func(loggingOn bool, node *lruListNode[string, []byte]) {
+			close(entered)
+			<-release
+		},
+	})
+
+	go cache.Set("a", []byte("a"))
+	<-entered
+
+	closed := make(chan struct{})
+	go func() {
+		cache.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("Close() returned before the running Set returned")
+	case <-time.After(time.Millisecond * 20):
+	}
+
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close() didn't return after the running Set returned")
+	}
+}
+
+func TestCloseContextBoundsWait(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	cache := newTestCache(t, &Config[string, []byte]{
+		OnAdd: func(loggingOn bool, node *lruListNode[string, []byte]) {
+			close(entered)
+			<-release
+		},
+	})
+
+	go cache.Set("a", []byte("a"))
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	if err := cache.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CloseContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if status := cache.Status(); status != Closed {
+		t.Errorf("Status() = %v, want %v", status, Closed)
+	}
+	if !cache.IsCleanupRunning() {
+		t.Error("IsCleanupRunning() = false, want the cache released only after the running Set returned")
+	}
+
+	close(release)
+	waitFor(t, func() bool { return !cache.IsCleanupRunning() })
+}
+
+func FuzzExpiresAt(f *testing.F) {
+	f.Add(int64(0))
+	f.Add(int64(NoExpiry))
+	f.Add(int64(30))
+	f.Add(int64(MinTTL))
+	f.Add(int64(time.Hour))
+	f.Add(int64(1<<63 - 1))
+	f.Add(int64(-1 << 63))
+
+	cache := newTestCache(f, &Config[string, []byte]{DefaultTTL: time.Minute})
+
+	f.Fuzz(func(t *testing.T, duration int64) {
+		d := time.Duration(duration)
+
+		err := checkTTL(d)
+		if (d > 0 && d < MinTTL) != errors.Is(err, ErrTTLTooShort) {
+			t.Fatalf("checkTTL(%v) error = %v", d, err)
+		}
+		if err != nil {
+			return
+		}
+
+		before := time.Now()
+		ttl := cache.expiresAt("key", nil, d)
+		switch {
+		case d < 0:
+			if !ttl.IsZero() {
+				t.Fatalf("expiresAt(%v) = %v, want no expiry", d, ttl)
+			}
+		case d == 0:
+			if ttl.Before(before.Add(time.Minute)) || ttl.After(time.Now().Add(time.Minute)) {
+				t.Fatalf("expiresAt(0) = %v, want the DefaultTTL from now", ttl)
+			}
+		default:
+			if !ttl.After(before) {
+				t.Fatalf("expiresAt(%v) = %v, want an expiry after %v", d, ttl, before)
+			}
+		}
+	})
+}
+
+func TestTopKeysClampsNegativeCount(t *testing.T) {
+	cache := newTestCache(t, &Config[string, []byte]{HotKeysCapacity: 16})
+
+	if _, err := cache.Set("a", []byte("a")); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	_, _ = cache.Get("a")
+
+	keys, err := cache.TopKeys(-1)
+	if err != nil || len(keys) != 0 {
+		t.Errorf("TopKeys(-1) = %v, %v, want no keys", keys, err)
+	}
+}
@@ -0,0 +1,318 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/maphash"
+	"io"
+	"iter"
+	"sync"
+	"time"
+)
+
+// TraceOp defines the operations of an access trace.
+type TraceOp uint8
+
+// TraceOp constants
+const (
+	TraceGet TraceOp = iota + 1
+	TraceSet
+	TraceRemove
+)
+
+// traceMagic is the header of a trace file, the last byte is the version of the format.
+var traceMagic = [4]byte{'S', 'Q', 'T', 1}
+
+// traceRecordSize is the size of an encoded TraceRecord in bytes (op, key hash, timestamp, size).
+const traceRecordSize = 1 + 8 + 8 + 4
+
+// TraceRecord represents a single access of an access trace. The keys are anonymized by a hash, which is seeded per
+// trace, so the hashes of the same key only match within a trace.
+type TraceRecord struct {
+	Op        TraceOp
+	KeyHash   uint64
+	Timestamp time.Time
+	Size      uint32
+}
+
+// tracer represents an active recording of an access trace.
+type tracer struct {
+	sync.Mutex
+
+	writer *bufio.Writer
+	seed   maphash.Seed
+	err    error
+}
+
+// record writes a TraceRecord of the specified operation to the trace. The first write error stops the recording of
+// further records and is returned by StopTrace.
+func (t *tracer) record(op TraceOp, key string, size int) {
+	var buf [traceRecordSize]byte
+
+	buf[0] = byte(op)
+	binary.LittleEndian.PutUint64(buf[1:9], maphash.String(t.seed, key))
+	binary.LittleEndian.PutUint64(buf[9:17], uint64(time.Now().UnixNano()))
+	binary.LittleEndian.PutUint32(buf[17:21], uint32(min(size, 1<<32-1)))
+
+	t.Lock()
+	if t.err == nil {
+		_, t.err = t.writer.Write(buf[:])
+	}
+	t.Unlock()
+}
+
+// StartTrace starts recording an anonymized access trace (operation, key hash, timestamp, value size) of Get, Set and
+// Remove operations to the specified writer. The trace can be replayed by ReplayTrace against other configurations.
+//
+// Parameters:
+//   - w: The writer to record the trace to, e.g. a file.
+//
+// Returns:
+//   - err: An error if a trace is already recording, or if the header could not be written.
+//
+// Example Usage:
+//
+//	file, err := os.Create("cache.trace")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	err = cache.StartTrace(file)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) StartTrace(w io.Writer) (err error) {
+	if cache.tracer.Load() != nil {
+		return errors.New("cache is already recording a trace")
+	}
+
+	t := &tracer{
+		writer: bufio.NewWriter(w),
+		seed:   maphash.MakeSeed(),
+	}
+	if _, err = t.writer.Write(traceMagic[:]); err != nil {
+		return err
+	}
+
+	if !cache.tracer.CompareAndSwap(nil, t) {
+		return errors.New("cache is already recording a trace")
+	}
+
+	return nil
+}
+
+// StopTrace stops recording the access trace and flushes the buffered records to the writer. The writer is not closed.
+//
+// Returns:
+//   - err: An error if no trace is recording, or if a record could not be written.
+func (cache *LRUCache[K, V]) StopTrace() (err error) {
+	t := cache.tracer.Swap(nil)
+	if t == nil {
+		return errors.New("cache is not recording a trace")
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	return t.writer.Flush()
+}
+
+// trace records the specified operation, if a trace is recording.
+func (cache *LRUCache[K, V]) trace(op TraceOp, key K, size int) {
+	if t := cache.tracer.Load(); t != nil {
+		t.record(op, string(key), size)
+	}
+}
+
+// ReadTrace returns an iterator over the records of an access trace recorded by StartTrace.
+//
+// Parameters:
+//   - r: The reader to read the trace from.
+//
+// Returns:
+//   - records: An iterator over the records and the error, which stopped reading the trace.
+func ReadTrace(r io.Reader) (records iter.Seq2[TraceRecord, error]) {
+	return func(yield func(TraceRecord, error) bool) {
+		reader := bufio.NewReader(r)
+
+		var magic [4]byte
+		if _, err := io.ReadFull(reader, magic[:]); err != nil {
+			yield(TraceRecord{}, err)
+			return
+		}
+		if magic != traceMagic {
+			yield(TraceRecord{}, errors.New("invalid trace header"))
+			return
+		}
+
+		var buf [traceRecordSize]byte
+		for {
+			if _, err := io.ReadFull(reader, buf[:]); err != nil {
+				if err != io.EOF {
+					yield(TraceRecord{}, err)
+				}
+				return
+			}
+
+			record := TraceRecord{
+				Op:        TraceOp(buf[0]),
+				KeyHash:   binary.LittleEndian.Uint64(buf[1:9]),
+				Timestamp: time.Unix(0, int64(binary.LittleEndian.Uint64(buf[9:17]))),
+				Size:      binary.LittleEndian.Uint32(buf[17:21]),
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// TraceReplayResult holds the outcome of replaying an access trace against a configuration.
+type TraceReplayResult struct {
+	Gets    int64
+	Hits    int64
+	Sets    int64
+	Removes int64
+	Evicted int64
+}
+
+// HitRatio returns the ratio of the gets that were hits, zero if the trace holds no gets.
+func (result TraceReplayResult) HitRatio() (ratio float64) {
+	if result.Gets == 0 {
+		return 0
+	}
+
+	return float64(result.Hits) / float64(result.Gets)
+}
+
+// ReplayTrace re-runs an access trace recorded by StartTrace against a new cache with the specified configuration,
+// e.g. to compare the hit ratios of different shard counts and capacities. The values are replayed with their recorded
+// size, TTLs and the timing of the accesses are not replayed. A get, which misses in the replay but hit in the trace,
+// is filled with a value of the last recorded size of the key, as a cache-aside application would.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the replay.
+//   - r: The reader to read the trace from.
+//   - config: The configuration of the cache to replay the trace against. The callbacks are not triggered during the
+//     replay.
+//
+// Returns:
+//   - result: The counts of the replayed operations and hits.
+//   - err: An error if the trace could not be read, if the context is done, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	result, err := sq_cache.ReplayTrace(ctx, file, &sq_cache.Config[string, []byte]{MaxShards: 64, MaxItems: 100000})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	fmt.Printf("hit ratio: %.2f%%\n", result.HitRatio()*100)
+func ReplayTrace(ctx context.Context, r io.Reader, config *Config[string, []byte]) (result TraceReplayResult, err error) {
+	cacheCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cache, err := NewLRUCache[string, []byte](cacheCtx, config)
+	if err != nil {
+		return result, err
+	}
+	defer cache.Close()
+
+	for _, shard := range cache.shards {
+		shard.Lock()
+		shard.callbacksOn = false
+		shard.Unlock()
+	}
+
+	var (
+		keyBuf   [8]byte
+		valueBuf []byte
+	)
+
+	set := func(key string, size uint32) (err error) {
+		if int(size) > len(valueBuf) {
+			valueBuf = make([]byte, size)
+		}
+		value := valueBuf[:size:size]
+		_, evicted, err := cache.set(key, value, cache.expiresAt(key, value, 0), nil)
+		if evicted {
+			result.Evicted++
+		}
+		return err
+	}
+
+	for record, err := range ReadTrace(r) {
+		if err != nil {
+			return result, err
+		}
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+
+		binary.LittleEndian.PutUint64(keyBuf[:], record.KeyHash)
+		key := string(keyBuf[:])
+
+		switch record.Op {
+		case TraceGet:
+			result.Gets++
+			value, err := cache.Get(key)
+			if err != nil {
+				return result, err
+			}
+			if value != nil {
+				result.Hits++
+			} else if record.Size > 0 {
+				if err = set(key, record.Size); err != nil {
+					return result, err
+				}
+			}
+		case TraceSet:
+			result.Sets++
+			if err = set(key, record.Size); err != nil {
+				return result, err
+			}
+		case TraceRemove:
+			result.Removes++
+			if _, err = cache.Remove(key); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, nil
+}
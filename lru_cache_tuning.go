@@ -0,0 +1,133 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+	"log"
+	"math/bits"
+)
+
+// Shard tuning constants
+const (
+	// shardTuningMinSamples is the minimum number of sampled lock acquisitions, before a shard count is recommended.
+	shardTuningMinSamples = 10000
+	// shardTuningTargetContention is the share of contended lock acquisitions the recommendation aims for.
+	shardTuningTargetContention = 0.01
+	// shardTuningMaxSkew is the ratio of the lock acquisitions of the busiest shard to the mean, above which the
+	// contention is caused by hot keys, that can't be spread by more shards.
+	shardTuningMaxSkew = 4.0
+	// shardTuningMinFactor and shardTuningMaxFactor limit the change of the shard count per recommendation.
+	shardTuningMinFactor = 0.25
+	shardTuningMaxFactor = 16.0
+	// shardTuningMaxShards is the maximum shard count, that is recommended.
+	shardTuningMaxShards = 65536
+)
+
+// RecommendShardCount recommends a shard count based on the sampled shard lock contention and the skew of the lock
+// acquisitions over the shards. The shard count is scaled, so that the share of contended lock acquisitions approaches
+// one percent, and is rounded to a power of two. If the lock acquisitions are skewed towards a few shards, no higher
+// shard count is recommended, as more shards don't spread hot keys. As long as too few lock acquisitions were sampled,
+// the configured shard count is returned.
+//
+// Returns:
+//   - shards: The recommended number of shards.
+//   - err: An error if the cache is closed, if the shard tuning is disabled, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	shards, err := cache.RecommendShardCount()
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) RecommendShardCount() (shards int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	}
+
+	if !cache.shardTuningOn {
+		return 0, errors.New("cache shard tuning is disabled")
+	}
+
+	return cache.recommendShardCount(), nil
+}
+
+// recommendShardCount computes the recommended shard count from the lock counters of the shards.
+func (cache *LRUCache[K, V]) recommendShardCount() (shards int64) {
+	var locks, contentions, maxLocks int64
+
+	for shardId := range cache.shards {
+		shardTelemetry := cache.shards[shardId].Telemetry()
+		shardLocks := shardTelemetry.GetLockCounter()
+
+		locks += shardLocks
+		contentions += shardTelemetry.GetLockContentionCounter()
+		maxLocks = max(maxLocks, shardLocks)
+	}
+
+	if locks < shardTuningMinSamples {
+		return cache.maxShards
+	}
+
+	contention := float64(contentions) / float64(locks)
+	skew := float64(maxLocks) / (float64(locks) / float64(len(cache.shards)))
+
+	factor := min(max(contention/shardTuningTargetContention, shardTuningMinFactor), shardTuningMaxFactor)
+	if skew > shardTuningMaxSkew {
+		factor = min(factor, 1)
+	}
+
+	return roundShardCount(int64(float64(cache.maxShards) * factor))
+}
+
+// roundShardCount rounds the specified shard count up to the next power of two, within the range of 1 and the maximum
+// recommended shard count.
+func roundShardCount(shards int64) (rounded int64) {
+	if shards <= 1 {
+		return 1
+	}
+
+	return min(int64(1)<<bits.Len64(uint64(shards-1)), shardTuningMaxShards)
+}
+
+// logShardRecommendation logs the recommended shard count, if it differs from the configured shard count.
+func (cache *LRUCache[K, V]) logShardRecommendation() {
+	if shards := cache.recommendShardCount(); shards != cache.maxShards {
+		log.Printf(
+			"%s: shard tuning - configured shards - %d - recommended shards - %d", LibraryName, cache.maxShards, shards,
+		)
+	}
+}
@@ -0,0 +1,311 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// txWrite represents a buffered Set or Remove of a transaction.
+type txWrite[V IValue] struct {
+	value   V
+	ttl     time.Time
+	removed bool
+}
+
+// TxView represents the view of a transaction of Update on the keys of the transaction. Its Sets and Removes are
+// buffered and applied together once the transaction succeeds, its Gets see the buffered Sets and Removes.
+type TxView[K IKey, V IValue] struct {
+	cache *LRUCache[K, V]
+
+	// keys are the normalized keys of the transaction, writes holds their buffered Sets and Removes in their order.
+	keys   map[K]struct{}
+	writes map[K]txWrite[V]
+	order  []K
+}
+
+// key normalizes the specified key and checks whether it is part of the transaction.
+func (view *TxView[K, V]) key(key K) (normalized K, err error) {
+	normalized = view.cache.normalizeKey(key)
+	if _, found := view.keys[normalized]; !found {
+		return normalized, ErrKeyNotInTransaction
+	}
+
+	return normalized, nil
+}
+
+// write buffers a Set or Remove of the key.
+func (view *TxView[K, V]) write(key K, write txWrite[V]) {
+	if _, found := view.writes[key]; !found {
+		view.order = append(view.order, key)
+	}
+	view.writes[key] = write
+}
+
+// Get retrieves a value by the specified key, including the Sets and Removes of the transaction.
+// This operation doesn't updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve, which must be part of the transaction.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - found: A boolean indicating whether the key was found.
+//   - err: ErrKeyNotInTransaction if the key isn't part of the transaction.
+func (view *TxView[K, V]) Get(key K) (value V, found bool, err error) {
+	if key, err = view.key(key); err != nil {
+		return value, false, err
+	}
+
+	if write, found := view.writes[key]; found {
+		if write.removed {
+			return value, false, nil
+		}
+		return view.cache.readValue(write.value), true, nil
+	}
+
+	value, found = view.cache.shards[view.cache.generateShardId(key, view.cache.maxShards)].Peek(key)
+
+	return view.cache.readValue(value), found, nil
+}
+
+// Set adds a key-value pair to the cache, once the transaction succeeds. The cache item expires after the duration
+// derived by the TTLFunc or after the default duration time, if any, like by Set of the cache.
+//
+// Parameters:
+//   - key: The key to associate with the value, which must be part of the transaction.
+//   - value: The value to store in the cache.
+//
+// Returns:
+//   - err: ErrKeyNotInTransaction if the key isn't part of the transaction, ErrValueTooLarge if the value exceeds the
+//     maximum value size.
+func (view *TxView[K, V]) Set(key K, value V) (err error) {
+	return view.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL adds a key-value pair with a specific TTL (time to live) to the cache, once the transaction succeeds. The
+// TTL is handled as by SetWithTTL of the cache. Values which are vetoed by the admit hook, or which exceed the size
+// limit in the pass-through mode, are silently not cached, and the cached value of the key is removed.
+//
+// Parameters:
+//   - key: The key to associate with the value, which must be part of the transaction.
+//   - value: The value to store in the cache.
+//   - duration: The time-to-live (TTL) for the cache entry.
+//
+// Returns:
+//   - err: ErrKeyNotInTransaction if the key isn't part of the transaction, ErrValueTooLarge if the value exceeds the
+//     maximum value size.
+func (view *TxView[K, V]) SetWithTTL(key K, value V, duration time.Duration) (err error) {
+	if key, err = view.key(key); err != nil {
+		return err
+	}
+	if err = checkTTL(duration); err != nil {
+		return err
+	}
+
+	cacheable, err := view.cache.cacheable(key, value)
+	if err != nil {
+		return err
+	}
+	if !cacheable {
+		view.write(key, txWrite[V]{removed: true})
+		return nil
+	}
+
+	view.write(key, txWrite[V]{value: view.cache.writeValue(value), ttl: view.cache.expiresAt(key, value, duration)})
+
+	return nil
+}
+
+// Remove removes a key-value pair from the cache, once the transaction succeeds.
+//
+// Parameters:
+//   - key: The key to remove from the cache, which must be part of the transaction.
+//
+// Returns:
+//   - err: ErrKeyNotInTransaction if the key isn't part of the transaction.
+func (view *TxView[K, V]) Remove(key K) (err error) {
+	if key, err = view.key(key); err != nil {
+		return err
+	}
+
+	view.write(key, txWrite[V]{removed: true})
+
+	return nil
+}
+
+// Update runs the specified function as a transaction on the specified keys: the shards of the keys are locked in
+// ascending order, so no other operation observes an intermediate state, e.g. to update two related cache items
+// together. The Sets and Removes of the function are applied only if it returns nil, otherwise they are discarded and
+// its error is returned. The function must not call other methods of the cache, as the shards are locked. The
+// dependents of removed cache items (see DependOn) are removed after the transaction.
+//
+// Parameters:
+//   - keys: The keys of the transaction, the function can only access these keys.
+//   - fn: The function, which reads and writes the keys by the view of the transaction.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, the error of the function, ErrCacheFull if the Sets exceed
+//     the capacity with the NoEviction policy, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	err := cache.Update([]string{"account:1", "account:2"}, func(view *sq_cache.TxView[string, []byte]) error {
+//	    from, _, err := view.Get("account:1")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    to, _, err := view.Get("account:2")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if err = view.Set("account:1", debit(from)); err != nil {
+//	        return err
+//	    }
+//	    return view.Set("account:2", credit(to))
+//	})
+func (cache *LRUCache[K, V]) Update(keys []K, fn func(view *TxView[K, V]) error) (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	case Stopped:
+		return errors.New("cache is stopped, must be started before calling method Update()")
+	}
+
+	view := &TxView[K, V]{
+		cache:  cache,
+		keys:   make(map[K]struct{}, len(keys)),
+		writes: make(map[K]txWrite[V], len(keys)),
+	}
+	var shardIds []int64
+	for _, key := range keys {
+		key = cache.normalizeKey(key)
+		view.keys[key] = struct{}{}
+		shardIds = append(shardIds, cache.generateShardId(key, cache.maxShards))
+	}
+	slices.Sort(shardIds)
+	shardIds = slices.Compact(shardIds)
+
+	for _, shardId := range shardIds {
+		cache.shards[shardId].LockMeasured()
+		if cache.writeBuffers != nil {
+			cache.applyWrites(shardId)
+		}
+	}
+
+	var dependents []K
+	if err = fn(view); err == nil {
+		for _, key := range view.order {
+			if view.writes[key].removed {
+				dependents = append(dependents, cache.dependencies.dependentsOf(key)...)
+			}
+		}
+		err = cache.commit(view)
+	}
+
+	for _, shardId := range slices.Backward(shardIds) {
+		cache.shards[shardId].Unlock()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range view.order {
+		if write := view.writes[key]; write.removed {
+			cache.trace(TraceRemove, key, 0)
+			cache.audit(context.Background(), AuditRemove, key)
+		} else {
+			cache.trace(TraceSet, key, len(write.value))
+			cache.audit(context.Background(), AuditSet, key)
+		}
+	}
+	for _, dependent := range dependents {
+		cache.remove(context.Background(), dependent)
+	}
+
+	return nil
+}
+
+// commit applies the buffered Sets and Removes of a transaction. With the NoEviction policy the capacity for the new
+// keys is checked first, so that nothing is applied if they don't fit. Must be called with the locks of the shards of
+// the transaction held.
+func (cache *LRUCache[K, V]) commit(view *TxView[K, V]) (err error) {
+	if cache.evictionPolicy == NoEviction {
+		var added int64
+		for _, key := range view.order {
+			shard := cache.shards[cache.generateShardId(key, cache.maxShards)]
+			_, found := shard.nodes.Get(key)
+			switch {
+			case view.writes[key].removed && found:
+				added--
+			case !view.writes[key].removed && !found:
+				added++
+			}
+		}
+		if cache.len.Load()+added > cache.maxItems.Load() {
+			return ErrCacheFull
+		}
+	}
+
+	for _, key := range view.order {
+		shardId := cache.generateShardId(key, cache.maxShards)
+		shard := cache.shards[shardId]
+		if cache.setCoalescers != nil {
+			cache.setCoalescers[shardId].discard(key)
+		}
+
+		write := view.writes[key]
+		if write.removed {
+			if shard.Remove(key) {
+				cache.len.Add(-1)
+				cache.walRemove(key)
+			}
+			continue
+		}
+
+		evictCount, added := shard.Set(cache.len.Load(), key, write.value, write.ttl, nil)
+		cache.walSet(key, write.value, write.ttl, nil)
+		if added {
+			cache.len.Add(1)
+		}
+		cache.len.Add(-evictCount)
+	}
+
+	return nil
+}
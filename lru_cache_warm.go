@@ -0,0 +1,143 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Warm pre-populates the cache with the specified entries, e.g. before traffic is served.
+// This operation does updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the warmup.
+//   - entries: The key-value pairs to add to the cache.
+//
+// Returns:
+//   - count: The number of cache items that were added or updated.
+//   - err: An error if the cache is stopped or closed, if the context is done, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	count, err := cache.Warm(ctx, maps.All(entries))
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Warm(ctx context.Context, entries iter.Seq2[K, V]) (count int64, err error) {
+	for key, value := range entries {
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		if _, err = cache.Set(key, value); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// WarmFromLoader pre-populates the cache by loading the specified keys with the configured loader, e.g. before traffic
+// is served. The number of concurrent loads is limited by the specified concurrency.
+// This operation does updates the recent-ness of the cache items.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the warmup.
+//   - keys: The keys to load into the cache.
+//   - concurrency: The maximum number of concurrent loads.
+//
+// Returns:
+//   - count: The number of cache items that were found or loaded.
+//   - err: An error joining the errors of all failed loads, if any.
+//
+// Example Usage:
+//
+//	count, err := cache.WarmFromLoader(ctx, []string{"my-key-1", "my-key-2"}, 8)
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) WarmFromLoader(ctx context.Context, keys []K, concurrency int) (count int64, err error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	var loaded atomic.Int64
+	var errsMutex sync.Mutex
+	var errs []error
+
+	keysCh := make(chan K)
+
+	for range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for key := range keysCh {
+				if _, err := cache.GetOrLoad(ctx, key); err != nil {
+					errsMutex.Lock()
+					errs = append(errs, err)
+					errsMutex.Unlock()
+					continue
+				}
+				loaded.Add(1)
+			}
+		}()
+	}
+
+	var ctxErr error
+
+send:
+	for _, key := range keys {
+		select {
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break send
+		case keysCh <- key:
+		}
+	}
+	close(keysCh)
+
+	wg.Wait()
+
+	if ctxErr != nil {
+		errs = append(errs, ctxErr)
+	}
+
+	return loaded.Load(), errors.Join(errs...)
+}
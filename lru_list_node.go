@@ -36,6 +36,7 @@
 package sq_cache
 
 import (
+	"sync/atomic"
 	"time"
 )
 
@@ -49,6 +50,38 @@ type lruListNode[K IKey, V IValue] struct {
 	Key   K
 	Value V
 	TTL   time.Time
+	Meta  *EntryMeta
+
+	// Version is the tick of the cache clock at the last write of the node. It increases with every write and is unique
+	// across all keys, so it never repeats after a key was removed and added again.
+	Version uint64
+
+	// accessedAt is the tick of the cache clock at the last access, used to compare the recent-ness across shards.
+	accessedAt atomic.Int64
+
+	// referenced is the reference bit of the ClockEviction policy, set by an access instead of moving the node.
+	referenced atomic.Bool
+
+	// checksum is the CRC-32 checksum of the value, set if the checksums are enabled.
+	checksum uint32
+
+	// source is the path, which populated the value of the node.
+	source EntrySource
+
+	// cost is the cost of the node, charged against the cost capacity of its shard.
+	cost int64
+	// priority is the GreedyDual-Size priority of the CostEviction policy as float64 bits, set by every access.
+	priority atomic.Uint64
+
+	// hits and lastAccessAt are the number of hits and the Unix time in nanoseconds of the last hit or the addition of
+	// the node, tracked if the access stats are enabled.
+	hits         atomic.Int64
+	lastAccessAt atomic.Int64
+
+	// wheelNext, wheelPrev and wheelSlot link the node into the slot of the timing wheel, which schedules its expiry.
+	wheelNext *lruListNode[K, V]
+	wheelPrev *lruListNode[K, V]
+	wheelSlot *wheelSlot[K, V]
 }
 
 // newLRUListNode creates and returns a new lruListNode instance.
@@ -57,6 +90,11 @@ func newLRUListNode[K IKey, V IValue]() *lruListNode[K, V] {
 	return lln
 }
 
+// expired checks if the node has an expiry time, which is before the specified time.
+func (lln *lruListNode[K, V]) expired(now time.Time) bool {
+	return !lln.TTL.IsZero() && lln.TTL.Before(now)
+}
+
 // Next returns the next node in the list, or nil if there is no next node or if the list is invalid.
 func (lln *lruListNode[K, V]) Next() *lruListNode[K, V] {
 	if p := lln.next; lln.list != nil && p != lln.list.root {
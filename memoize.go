@@ -0,0 +1,158 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memoizeId is used to give every memoized function its own key namespace in the cache.
+var memoizeId atomic.Int64
+
+// memoizeCall represents an in-flight call of a memoized function for a single key.
+type memoizeCall[R any] struct {
+	wg sync.WaitGroup
+
+	result R
+	err    error
+}
+
+// Memoize returns a memoized version of the specified function, which caches the results in the cache for the specified
+// TTL (time to live). Concurrent calls with the same argument are coalesced into a single call of the function
+// (singleflight). Errors are not cached. The results are encoded with encoding/gob.
+//
+// Parameters:
+//   - cache: The cache to store the results in.
+//   - fn: The function to memoize.
+//   - ttl: The TTL (time to live) of the results, zero falls back to the default TTL of the cache.
+//
+// Returns:
+//   - memoized: The memoized function.
+//
+// Example Usage:
+//
+//	render := sq_cache.Memoize(cache, renderTemplate, time.Minute)
+//	page, err := render("index")
+func Memoize[A comparable, R any](
+	cache *LRUCache[string, []byte], fn func(arg A) (R, error), ttl time.Duration,
+) (memoized func(arg A) (R, error)) {
+	return MemoizeWithKey(cache, func(arg A) string {
+		return MemoizeKey(arg)
+	}, fn, ttl)
+}
+
+// MemoizeWithKey returns a memoized version of the specified function, which caches the results by the key built by
+// keyFn from the arguments, e.g. for functions with multiple arguments passed as a struct.
+//
+// Parameters:
+//   - cache: The cache to store the results in.
+//   - keyFn: The function building the key of the arguments, e.g. by MemoizeKey.
+//   - fn: The function to memoize.
+//   - ttl: The TTL (time to live) of the results, zero falls back to the default TTL of the cache.
+//
+// Returns:
+//   - memoized: The memoized function.
+//
+// Example Usage:
+//
+//	type query struct{ user string; page int }
+//
+//	search := sq_cache.MemoizeWithKey(cache, func(q query) string {
+//	    return sq_cache.MemoizeKey(q.user, q.page)
+//	}, doSearch, time.Minute)
+func MemoizeWithKey[A any, R any](
+	cache *LRUCache[string, []byte], keyFn func(arg A) string, fn func(arg A) (R, error), ttl time.Duration,
+) (memoized func(arg A) (R, error)) {
+	prefix := "memoize:" + strconv.FormatInt(memoizeId.Add(1), 10) + ":"
+
+	var mutex sync.Mutex
+	calls := make(map[string]*memoizeCall[R])
+
+	return func(arg A) (result R, err error) {
+		key := prefix + keyFn(arg)
+
+		if value, err := cache.Get(key); err == nil && value != nil {
+			if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&result); err == nil {
+				return result, nil
+			}
+		}
+
+		mutex.Lock()
+		if call, found := calls[key]; found {
+			mutex.Unlock()
+			call.wg.Wait()
+			return call.result, call.err
+		}
+		call := &memoizeCall[R]{}
+		call.wg.Add(1)
+		calls[key] = call
+		mutex.Unlock()
+
+		call.result, call.err = fn(arg)
+		if call.err == nil {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(call.result); err == nil {
+				_, _ = cache.SetContext(WithSource(context.Background(), LoaderSource), key, buf.Bytes(), ttl)
+			}
+		}
+
+		mutex.Lock()
+		delete(calls, key)
+		mutex.Unlock()
+
+		call.wg.Done()
+
+		return call.result, call.err
+	}
+}
+
+// MemoizeKey builds a key from the specified arguments, by their types and values.
+func MemoizeKey(args ...any) (key string) {
+	var b strings.Builder
+
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteByte(0)
+		}
+		fmt.Fprintf(&b, "%T:%v", arg, arg)
+	}
+
+	return b.String()
+}
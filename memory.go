@@ -0,0 +1,197 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// mapSlotOverhead is the estimated overhead of a slot of a Go map in bytes besides the key and the element, i.e. the
+// control byte and the unused capacity of the map at its average load factor.
+const mapSlotOverhead = 8
+
+// EstimateSize estimates the heap memory held by a cache item in bytes: the node of the cache item, its slot in the
+// index of the shard and the bytes of the key and the capacity of the value. It is the Cost function of the cache, if
+// MaxMemory is specified without a Cost function.
+//
+// The estimate is a lower bound. It doesn't include the metadata of the cache item (see SizeOf), the rounding of the
+// allocations to the size classes of the Go allocator or the memory of the optional structures, e.g. the bloom filters,
+// the timing wheel or the write buffers. Values sharing a backing array are counted once per cache item.
+//
+// Parameters:
+//   - key: The key of the cache item.
+//   - value: The value of the cache item.
+//
+// Returns:
+//   - size: The estimated size of the cache item in bytes.
+//
+// Example Usage:
+//
+//	cache, err := sq_cache.NewLRUCache(ctx, &sq_cache.Config[string, []byte]{
+//	    MaxMemory: 256 << 20,
+//	    Cost: func(key string, value []byte) int64 {
+//	        return sq_cache.EstimateSize(key, value) + 64 // e.g. the metadata attached to every cache item
+//	    },
+//	})
+func EstimateSize[K IKey, V IValue](key K, value V) (size int64) {
+	var node lruListNode[K, V]
+	var pointer *lruListNode[K, V]
+
+	size = int64(unsafe.Sizeof(node))
+	size += int64(unsafe.Sizeof(key)+unsafe.Sizeof(pointer)) + mapSlotOverhead
+	size += int64(len(key)) + int64(cap(value))
+
+	return size
+}
+
+// SizeOf estimates the heap memory held by an arbitrary value in bytes, by walking it with reflection: the size of the
+// value itself (unsafe.Sizeof) plus the bytes of its strings, the capacity of its slices, the slots of its maps and the
+// values its pointers and interfaces refer to. The memory behind a pointer, a slice or a map is counted once, even if
+// it is referred to multiple times, so cyclic values are supported.
+//
+// The estimate is approximate: the maps are estimated by their length instead of their allocated buckets, strings
+// sharing their bytes are counted per string, and channels, functions and unsafe pointers are counted by their size
+// only. Walking large values is expensive, so it is meant for the Cost functions of small values and for sampling.
+//
+// Parameters:
+//   - value: The value to estimate.
+//
+// Returns:
+//   - size: The estimated size of the value in bytes, zero for nil.
+//
+// Example Usage:
+//
+//	Cost: func(key string, value []byte) int64 {
+//	    return sq_cache.EstimateSize(key, value) + sq_cache.SizeOf(decode(value).Attributes)
+//	},
+func SizeOf(value any) (size int64) {
+	if value == nil {
+		return 0
+	}
+
+	v := reflect.ValueOf(value)
+
+	return int64(v.Type().Size()) + referencedSize(v, make(map[uintptr]struct{}))
+}
+
+// referencedSize returns the size of the memory referred to by the value, without the size of the value itself. The
+// addresses of the visited pointers, slices and maps are tracked by seen, so that they are counted once.
+func referencedSize(v reflect.Value, seen map[uintptr]struct{}) (size int64) {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || !visit(v.Pointer(), seen) {
+			return 0
+		}
+		size = int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if !flat(v.Type().Elem()) {
+			for i := range v.Len() {
+				size += referencedSize(v.Index(i), seen)
+			}
+		}
+	case reflect.Array:
+		if !flat(v.Type().Elem()) {
+			for i := range v.Len() {
+				size += referencedSize(v.Index(i), seen)
+			}
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			size += referencedSize(v.Field(i), seen)
+		}
+	case reflect.Pointer:
+		if v.IsNil() || !visit(v.Pointer(), seen) {
+			return 0
+		}
+		size = int64(v.Type().Elem().Size()) + referencedSize(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		size = int64(v.Elem().Type().Size()) + referencedSize(v.Elem(), seen)
+	case reflect.Map:
+		if v.IsNil() || !visit(v.Pointer(), seen) {
+			return 0
+		}
+		size = int64(v.Len()) * (int64(v.Type().Key().Size()+v.Type().Elem().Size()) + mapSlotOverhead)
+		if !flat(v.Type().Key()) || !flat(v.Type().Elem()) {
+			for iter := v.MapRange(); iter.Next(); {
+				size += referencedSize(iter.Key(), seen) + referencedSize(iter.Value(), seen)
+			}
+		}
+	}
+
+	return size
+}
+
+// visit marks the address as seen and reports whether it wasn't seen before.
+func visit(address uintptr, seen map[uintptr]struct{}) (first bool) {
+	if _, found := seen[address]; found {
+		return false
+	}
+	seen[address] = struct{}{}
+
+	return true
+}
+
+// flat reports whether the values of the type don't refer to any further memory, so they needn't be walked.
+func flat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return flat(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if !flat(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// costFunc returns the Cost function of the cache items: the specified Cost function, EstimateSize if MaxMemory is
+// specified without one, or nil if every cache item costs 1.
+func costFunc[K IKey, V IValue](config *Config[K, V]) (cost func(key K, value V) int64) {
+	if config.Cost == nil && config.MaxMemory > 0 {
+		return EstimateSize[K, V]
+	}
+
+	return config.Cost
+}
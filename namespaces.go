@@ -0,0 +1,312 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+)
+
+// NamespaceQuota bounds the cache items of a namespace, see Config.Namespaces. Zero means no limit.
+type NamespaceQuota struct {
+	// MaxItems is the maximum number of cache items of the namespace.
+	MaxItems int64
+	// MaxBytes is the maximum total size of the values of the namespace in bytes.
+	MaxBytes int64
+}
+
+// NamespaceStats represents the usage and the telemetry of a namespace, returned by NamespaceStats.
+type NamespaceStats struct {
+	Quota NamespaceQuota
+
+	// Items and Bytes are the number of cache items of the namespace and the total size of their values.
+	Items int64
+	Bytes int64
+
+	// Hits, Misses and Evictions count the lookups and the evictions of the cache items of the namespace.
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// namespace represents a configured namespace with its quota and its counters, shared by all shards.
+type namespace[K IKey] struct {
+	prefix K
+	quota  NamespaceQuota
+
+	// shardQuota is the quota of a single shard, the quota divided between the shards.
+	shardQuota NamespaceQuota
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+}
+
+// namespaces maps the keys to their namespaces by the longest matching prefix.
+type namespaces[K IKey] struct {
+	// list holds the namespaces ordered by the length of their prefixes, the longest first.
+	list []*namespace[K]
+}
+
+// namespaceUsage represents the cache items of a namespace in a shard.
+type namespaceUsage struct {
+	items int64
+	bytes int64
+}
+
+// newNamespaces initializes and returns a new namespaces instance for the specified quotas, nil if no namespace is
+// configured. The quotas are divided between the specified number of shards.
+func newNamespaces[K IKey](quotas map[K]NamespaceQuota, maxShards int64) (ns *namespaces[K]) {
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	ns = &namespaces[K]{list: make([]*namespace[K], 0, len(quotas))}
+	for prefix, quota := range quotas {
+		ns.list = append(ns.list, &namespace[K]{
+			prefix: prefix,
+			quota:  quota,
+			shardQuota: NamespaceQuota{
+				MaxItems: (quota.MaxItems + maxShards - 1) / maxShards,
+				MaxBytes: (quota.MaxBytes + maxShards - 1) / maxShards,
+			},
+		})
+	}
+	slices.SortFunc(ns.list, func(a, b *namespace[K]) int {
+		return cmp.Or(cmp.Compare(len(b.prefix), len(a.prefix)), cmp.Compare(a.prefix, b.prefix))
+	})
+
+	return ns
+}
+
+// indexOf returns the index of the namespace of the specified key, -1 if the key doesn't belong to any namespace.
+func (ns *namespaces[K]) indexOf(key K) (index int) {
+	for index, namespace := range ns.list {
+		if len(key) >= len(namespace.prefix) && key[:len(namespace.prefix)] == namespace.prefix {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// lookup returns the index of the namespace with the specified prefix, -1 if it isn't configured.
+func (ns *namespaces[K]) lookup(prefix K) (index int) {
+	if ns == nil {
+		return -1
+	}
+
+	return slices.IndexFunc(ns.list, func(namespace *namespace[K]) bool {
+		return namespace.prefix == prefix
+	})
+}
+
+// overQuota reports whether the usage of a shard exceeds the shard quota of the namespace.
+func (namespace *namespace[K]) overQuota(usage namespaceUsage) bool {
+	return (namespace.shardQuota.MaxItems > 0 && usage.items > namespace.shardQuota.MaxItems) ||
+		(namespace.shardQuota.MaxBytes > 0 && usage.bytes > namespace.shardQuota.MaxBytes)
+}
+
+// chargeNamespace accounts the specified number of cache items and bytes of a key to the usage of its namespace in
+// the shard and returns the namespace, nil if the key doesn't belong to any namespace. Must be called with the lock
+// held.
+func (shard *lruCacheShard[K, V]) chargeNamespace(key K, items, bytes int64) (namespace *namespace[K]) {
+	if shard.namespaces == nil {
+		return nil
+	}
+
+	index := shard.namespaces.indexOf(key)
+	if index < 0 {
+		return nil
+	}
+
+	shard.namespaceUsage[index].items += items
+	shard.namespaceUsage[index].bytes += bytes
+
+	return shard.namespaces.list[index]
+}
+
+// namespaceOf returns the namespace of the specified key, nil if the key doesn't belong to any namespace.
+func (shard *lruCacheShard[K, V]) namespaceOf(key K) (namespace *namespace[K]) {
+	if shard.namespaces == nil {
+		return nil
+	}
+
+	if index := shard.namespaces.indexOf(key); index >= 0 {
+		return shard.namespaces.list[index]
+	}
+
+	return nil
+}
+
+// removeItemsOverQuota evicts the oldest cache items of the namespace of the specified cache item from the shard, until
+// the namespace fits its shard quota, but never the specified cache item. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) removeItemsOverQuota(item *lruListNode[K, V]) (evictCount int64) {
+	if shard.namespaces == nil {
+		return 0
+	}
+
+	index := shard.namespaces.indexOf(item.Key)
+	if index < 0 {
+		return 0
+	}
+
+	namespace := shard.namespaces.list[index]
+	for victim := shard.list.Back(); victim != nil && namespace.overQuota(shard.namespaceUsage[index]); {
+		prev := victim.Prev()
+		if victim != item && shard.namespaces.indexOf(victim.Key) == index {
+			shard.removeItem(victim, Evicted)
+			evictCount++
+		}
+		victim = prev
+	}
+
+	return evictCount
+}
+
+// removeNamespace removes all cache items of the specified namespace from the shard and returns their keys. Must be
+// called with the lock held.
+func (shard *lruCacheShard[K, V]) removeNamespace(index int) (keys []K) {
+	defer shard.debugCheck()
+
+	for key, item := range shard.nodes.All() {
+		if shard.namespaces.indexOf(key) == index {
+			shard.removeItem(item, Purged)
+			keys = append(keys, key)
+		}
+	}
+	if shard.victims != nil {
+		shard.victims.discardShard(shard, func(key K) bool {
+			return shard.namespaces.indexOf(key) == index
+		})
+	}
+	shard.refreshBloom()
+
+	return keys
+}
+
+// NamespaceStats returns the usage and the telemetry of the namespace with the specified prefix.
+//
+// Parameters:
+//   - prefix: The prefix of the namespace, as configured by Config.Namespaces.
+//
+// Returns:
+//   - stats: The usage and the telemetry of the namespace.
+//   - err: An error if the namespace isn't configured.
+//
+// Example Usage:
+//
+//	stats, err := cache.NamespaceStats("tenant-a:")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	log.Printf("tenant-a holds %d of %d cache items", stats.Items, stats.Quota.MaxItems)
+func (cache *LRUCache[K, V]) NamespaceStats(prefix K) (stats NamespaceStats, err error) {
+	index := cache.namespaces.lookup(prefix)
+	if index < 0 {
+		return stats, errors.New("namespace doesn't exist")
+	}
+
+	namespace := cache.namespaces.list[index]
+	stats = NamespaceStats{
+		Quota: namespace.quota,
+
+		Hits:      namespace.hits.Load(),
+		Misses:    namespace.misses.Load(),
+		Evictions: namespace.evictions.Load(),
+	}
+	for _, shard := range cache.shards {
+		shard.RLockMeasured()
+		stats.Items += shard.namespaceUsage[index].items
+		stats.Bytes += shard.namespaceUsage[index].bytes
+		shard.RUnlock()
+	}
+
+	return stats, nil
+}
+
+// PurgeNamespace removes all cache items of the namespace with the specified prefix, including their coalesced and
+// buffered Sets, e.g. when a tenant is offboarded. The cache keeps serving, the shards are locked one at a time.
+//
+// Parameters:
+//   - prefix: The prefix of the namespace, as configured by Config.Namespaces.
+//
+// Returns:
+//   - removed: The number of removed cache items.
+//   - err: An error if the cache is closed, or if the namespace isn't configured.
+//
+// Example Usage:
+//
+//	removed, err := cache.PurgeNamespace("tenant-a:")
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) PurgeNamespace(prefix K) (removed int64, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return 0, ErrClosed
+	}
+
+	index := cache.namespaces.lookup(prefix)
+	if index < 0 {
+		return 0, errors.New("namespace doesn't exist")
+	}
+
+	cache.audit(context.Background(), AuditPurge, prefix)
+
+	for shardId, shard := range cache.shards {
+		if cache.setCoalescers != nil {
+			cache.setCoalescers[shardId].discardFunc(func(key K) bool {
+				return cache.namespaces.indexOf(key) == index
+			})
+		}
+		cache.flushWrites(int64(shardId))
+
+		shard.LockMeasured()
+		keys := shard.removeNamespace(index)
+		for _, key := range keys {
+			cache.walRemove(key)
+		}
+		shard.Unlock()
+
+		cache.len.Add(-int64(len(keys)))
+		removed += int64(len(keys))
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,220 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// NoopCache is a Cache which caches nothing: every read misses and every write is discarded. With a loader, GetOrLoad
+// passes every read through to the loader. It is meant for measuring the effectiveness of a cache (A/B testing) and
+// for disabling caching by configuration without changing the code of the consumers.
+type NoopCache[K IKey, V IValue] struct {
+	status CacheStatus
+	loader func(ctx context.Context, key K) (value V, err error)
+}
+
+// NoopCache implements the Cache interface.
+var _ Cache[string, []byte] = (*NoopCache[string, []byte])(nil)
+
+// NewNoopCache creates a started cache, which always misses and discards the writes. GetOrLoad fails with
+// ErrLoaderNotConfigured.
+//
+// Returns:
+//   - cache: The new cache.
+//
+// Example Usage:
+//
+//	var cache sq_cache.Cache[string, []byte] = sq_cache.NewNoopCache[string, []byte]()
+//	if cachingOn {
+//	    cache, err = sq_cache.NewLRUCache(ctx, config)
+//	}
+func NewNoopCache[K IKey, V IValue]() (cache *NoopCache[K, V]) {
+	return &NoopCache[K, V]{status: Started}
+}
+
+// NewPassthroughCache creates a started cache, which caches nothing and loads every value of GetOrLoad by the
+// specified loader.
+//
+// Parameters:
+//   - loader: The function loading the values.
+//
+// Returns:
+//   - cache: The new cache.
+//
+// Example Usage:
+//
+//	cache := sq_cache.NewPassthroughCache(func(ctx context.Context, key string) ([]byte, error) {
+//	    return db.Load(ctx, key)
+//	})
+func NewPassthroughCache[K IKey, V IValue](
+	loader func(ctx context.Context, key K) (value V, err error),
+) (cache *NoopCache[K, V]) {
+	return &NoopCache[K, V]{status: Started, loader: loader}
+}
+
+// check returns an error, if the cache is closed or stopped.
+func (cache *NoopCache[K, V]) check(method string) (err error) {
+	switch cache.status {
+	case Closed:
+		return ErrClosed
+	case Stopped:
+		return errors.New("cache is stopped, must be started before calling method " + method + "()")
+	}
+
+	return nil
+}
+
+// Set discards the key-value pair. If the key wasn't specified, it is generated based on the specified value.
+func (cache *NoopCache[K, V]) Set(key K, value V) (returnKey K, err error) {
+	if err = cache.check("Set"); err != nil {
+		return returnKey, err
+	}
+	if key == "" {
+		key = generateKey[K](value)
+	}
+
+	return key, nil
+}
+
+// SetWithTTL discards the key-value pair. If the key wasn't specified, it is generated based on the specified value.
+func (cache *NoopCache[K, V]) SetWithTTL(key K, value V, duration time.Duration) (returnKey K, err error) {
+	if err = cache.check("SetWithTTL"); err != nil {
+		return returnKey, err
+	}
+	if key == "" {
+		key = generateKey[K](value)
+	}
+
+	return key, nil
+}
+
+// Get always misses.
+func (cache *NoopCache[K, V]) Get(key K) (value V, err error) {
+	return value, cache.check("Get")
+}
+
+// GetWithTTL always misses.
+func (cache *NoopCache[K, V]) GetWithTTL(key K) (value V, ttl time.Duration, found bool, err error) {
+	return value, 0, false, cache.check("GetWithTTL")
+}
+
+// GetOrLoad loads the value by the loader, without caching it. It fails with ErrLoaderNotConfigured, if the cache has
+// no loader.
+func (cache *NoopCache[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	if err = cache.check("GetOrLoad"); err != nil {
+		return value, err
+	}
+	if cache.loader == nil {
+		return value, ErrLoaderNotConfigured
+	}
+
+	return cache.loader(ctx, key)
+}
+
+// Peek always misses.
+func (cache *NoopCache[K, V]) Peek(key K) (value V, err error) {
+	return value, cache.check("Peek")
+}
+
+// Contains always reports false.
+func (cache *NoopCache[K, V]) Contains(key K) (found bool, err error) {
+	return false, cache.check("Contains")
+}
+
+// ContainsOrAdd reports that the key didn't exist and discards the key-value pair.
+func (cache *NoopCache[K, V]) ContainsOrAdd(key K, value V) (existed, evicted bool, err error) {
+	return false, false, cache.check("ContainsOrAdd")
+}
+
+// PeekOrAdd reports that the key didn't exist and discards the key-value pair.
+func (cache *NoopCache[K, V]) PeekOrAdd(key K, value V) (previous V, existed bool, err error) {
+	return previous, false, cache.check("PeekOrAdd")
+}
+
+// Remove reports that the key didn't exist.
+func (cache *NoopCache[K, V]) Remove(key K) (removed bool, err error) {
+	return false, cache.check("Remove")
+}
+
+// RemoveOldest removes nothing.
+func (cache *NoopCache[K, V]) RemoveOldest(n int) (removed int, err error) {
+	return 0, cache.check("RemoveOldest")
+}
+
+// Purge removes nothing, the cache must be stopped like a LRUCache.
+func (cache *NoopCache[K, V]) Purge() (err error) {
+	switch cache.status {
+	case Closed:
+		return ErrClosed
+	case Started:
+		return errors.New("cache is started, must be stopped before calling method Purge()")
+	}
+
+	return nil
+}
+
+// Range calls the function for no cache item.
+func (cache *NoopCache[K, V]) Range(ctx context.Context, fn func(key K, value V) bool) (count int64, err error) {
+	return 0, cache.check("Range")
+}
+
+// Len always returns zero.
+func (cache *NoopCache[K, V]) Len() (len int64) {
+	return 0
+}
+
+// Status returns the current status of the cache.
+func (cache *NoopCache[K, V]) Status() (status CacheStatus) {
+	return cache.status
+}
+
+// Start activates the cache, unless it is closed.
+func (cache *NoopCache[K, V]) Start() {
+	if cache.status != Closed {
+		cache.status = Started
+	}
+}
+
+// Stop deactivates the cache, unless it is closed.
+func (cache *NoopCache[K, V]) Stop() {
+	if cache.status != Closed {
+		cache.status = Stopped
+	}
+}
+
+// Close closes the cache.
+func (cache *NoopCache[K, V]) Close() {
+	cache.status = Closed
+}
@@ -0,0 +1,98 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+)
+
+// Operation represents the metadata of an operation of the cache, e.g. to attribute the logs and the metrics of the
+// callbacks and the audit events to a trace and a tenant.
+type Operation struct {
+	TraceID  string
+	TenantID string
+	// Attributes are further metadata of the operation, they must not be modified once attached to a context.
+	Attributes map[string]string
+}
+
+// operationKey is the context key of the Operation.
+type operationKey struct{}
+
+// WithOperation returns a copy of the context, which carries the specified metadata to the context callbacks
+// (OnAddContext, OnUpdateContext, OnEvictContext and OnRemoveContext) and the audit events of the operations performed
+// with it, e.g. by SetContext, RemoveContext, PurgeContext or ImportEntries.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - operation: The metadata of the operations.
+//
+// Returns:
+//   - operationCtx: The context carrying the metadata.
+//
+// Example Usage:
+//
+//	ctx = sq_cache.WithOperation(ctx, sq_cache.Operation{TraceID: traceID, TenantID: "tenant-a"})
+//	_, err := cache.SetContext(ctx, "tenant-a:report", report, time.Hour)
+func WithOperation(ctx context.Context, operation Operation) (operationCtx context.Context) {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFrom returns the metadata of the operation carried by the context, e.g. within a context callback.
+//
+// Parameters:
+//   - ctx: The context of the operation.
+//
+// Returns:
+//   - operation: The metadata of the operation.
+//   - found: A boolean indicating whether the context carries metadata.
+//
+// Example Usage:
+//
+//	OnEvictContext: func(ctx context.Context, node *lruListNode[string, []byte]) {
+//	    operation, _ := sq_cache.OperationFrom(ctx)
+//	    evictions.WithLabelValues(operation.TenantID).Inc()
+//	},
+func OperationFrom(ctx context.Context) (operation Operation, found bool) {
+	operation, found = ctx.Value(operationKey{}).(Operation)
+
+	return operation, found
+}
+
+// operationContext returns the context of the running mutation of the shard, or the background context. Must be
+// called with the lock of the shard held.
+func (shard *lruCacheShard[K, V]) operationContext() (ctx context.Context) {
+	if shard.opCtx != nil {
+		return shard.opCtx
+	}
+
+	return context.Background()
+}
@@ -0,0 +1,293 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PeerDiscovery discovers the peers of a distributed cache, e.g. the URLs of their admin APIs, so that the peer lists
+// don't have to be maintained by hand. StaticPeers and DNSPeers implement it.
+type PeerDiscovery interface {
+	// Peers returns the current peers.
+	Peers(ctx context.Context) (peers []string, err error)
+}
+
+// StaticPeers is a PeerDiscovery of a fixed list of peers.
+type StaticPeers []string
+
+// Peers returns the list of peers.
+func (peers StaticPeers) Peers(_ context.Context) (result []string, err error) {
+	return slices.Clone(peers), nil
+}
+
+// DNSPeers is a PeerDiscovery, which resolves the peers by the addresses of a host name, e.g. a headless service of
+// Kubernetes. Every address is returned as Scheme + "://" + address + ":" + Port.
+type DNSPeers struct {
+	// Host is the host name to resolve.
+	Host string
+	// Port is the port of the peers.
+	Port string
+	// Scheme is the scheme of the peers, "http" if empty.
+	Scheme string
+	// Resolver resolves the host name, net.DefaultResolver if nil.
+	Resolver *net.Resolver
+}
+
+// Peers resolves the host name to the peers, sorted by their addresses.
+func (dns DNSPeers) Peers(ctx context.Context) (peers []string, err error) {
+	resolver := dns.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	scheme := dns.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	addresses, err := resolver.LookupHost(ctx, dns.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	peers = make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		peers = append(peers, scheme+"://"+net.JoinHostPort(address, dns.Port))
+	}
+	slices.Sort(peers)
+
+	return peers, nil
+}
+
+// PeerEvent is a change of the membership of a peer.
+type PeerEvent struct {
+	Peer string
+	// Joined is true if the peer joined, false if it left.
+	Joined bool
+}
+
+// PeerRing is a consistent hash ring of peers, on which every peer is placed multiple times (virtual nodes), so that a
+// change of the membership only relocates the keys of the joined or left peer. It is safe for concurrent use.
+type PeerRing struct {
+	mutex        sync.RWMutex
+	virtualNodes int64
+	peers        []string
+	points       []uint64
+	owners       map[uint64]string
+}
+
+// NewPeerRing creates an empty hash ring of peers.
+//
+// Parameters:
+//   - virtualNodes: The number of virtual nodes per peer, at least one.
+//
+// Returns:
+//   - ring: The hash ring, see SetPeers and WatchPeers.
+//
+// Example Usage:
+//
+//	ring := sq_cache.NewPeerRing(128)
+//	ring.SetPeers([]string{"http://10.0.0.11:6061", "http://10.0.0.12:6061"})
+//	peer, found := ring.Owner("my-key")
+func NewPeerRing(virtualNodes int64) (ring *PeerRing) {
+	return &PeerRing{
+		virtualNodes: max(virtualNodes, 1),
+		owners:       make(map[uint64]string),
+	}
+}
+
+// SetPeers replaces the peers of the ring.
+//
+// Parameters:
+//   - peers: The peers, duplicates are ignored.
+//
+// Returns:
+//   - events: The joined and the left peers, sorted by peer.
+func (ring *PeerRing) SetPeers(peers []string) (events []PeerEvent) {
+	peers = slices.Clone(peers)
+	slices.Sort(peers)
+	peers = slices.Compact(peers)
+
+	points := make([]uint64, 0, int64(len(peers))*ring.virtualNodes)
+	owners := make(map[uint64]string, int64(len(peers))*ring.virtualNodes)
+	for _, peer := range peers {
+		for virtualNode := range ring.virtualNodes {
+			point := hashUint64(peer + "-" + strconv.FormatInt(virtualNode, 10))
+			if _, found := owners[point]; found {
+				continue
+			}
+
+			points = append(points, point)
+			owners[point] = peer
+		}
+	}
+	slices.Sort(points)
+
+	ring.mutex.Lock()
+	previous := ring.peers
+	ring.peers, ring.points, ring.owners = peers, points, owners
+	ring.mutex.Unlock()
+
+	for _, peer := range previous {
+		if _, found := slices.BinarySearch(peers, peer); !found {
+			events = append(events, PeerEvent{Peer: peer})
+		}
+	}
+	for _, peer := range peers {
+		if _, found := slices.BinarySearch(previous, peer); !found {
+			events = append(events, PeerEvent{Peer: peer, Joined: true})
+		}
+	}
+	slices.SortFunc(events, func(a, b PeerEvent) int {
+		return cmp.Compare(a.Peer, b.Peer)
+	})
+
+	return events
+}
+
+// Peers returns the peers of the ring, sorted by peer.
+func (ring *PeerRing) Peers() (peers []string) {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+
+	return slices.Clone(ring.peers)
+}
+
+// Owner returns the peer of the first virtual node on the ring at or after the hash of the specified key.
+//
+// Parameters:
+//   - key: The key to look up.
+//
+// Returns:
+//   - peer: The peer owning the key.
+//   - found: A boolean indicating whether the ring has any peer.
+func (ring *PeerRing) Owner(key string) (peer string, found bool) {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+
+	if len(ring.points) == 0 {
+		return "", false
+	}
+
+	i, _ := slices.BinarySearch(ring.points, hashUint64(key))
+	if i == len(ring.points) {
+		i = 0
+	}
+
+	return ring.owners[ring.points[i]], true
+}
+
+// Replicas returns the owner of the key followed by the next distinct peers on the ring, e.g. the peers replicating a
+// hot key to spread its load.
+//
+// Parameters:
+//   - key: The key to look up.
+//   - n: The maximum number of peers, the replication factor.
+//
+// Returns:
+//   - peers: The owner and the further replicas of the key, empty if the ring has no peer.
+func (ring *PeerRing) Replicas(key string, n int) (peers []string) {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+
+	n = min(n, len(ring.peers))
+	if n <= 0 {
+		return nil
+	}
+
+	i, _ := slices.BinarySearch(ring.points, hashUint64(key))
+	peers = make([]string, 0, n)
+	for range ring.points {
+		if i == len(ring.points) {
+			i = 0
+		}
+		if peer := ring.owners[ring.points[i]]; !slices.Contains(peers, peer) {
+			peers = append(peers, peer)
+			if len(peers) == n {
+				break
+			}
+		}
+		i++
+	}
+
+	return peers
+}
+
+// WatchPeers polls the discovery in the specified interval and updates the ring with the discovered peers, until the
+// context is done. A failed discovery keeps the current peers, so a DNS outage doesn't empty the ring.
+//
+// Parameters:
+//   - ctx: The context of the watch.
+//   - discovery: The discovery of the peers.
+//   - interval: The interval of the polls.
+//   - ring: The ring to update.
+//   - onChange: The function called with every membership change, may be nil.
+//
+// Returns:
+//   - err: The error of the context.
+//
+// Example Usage:
+//
+//	ring := sq_cache.NewPeerRing(128)
+//	discovery := sq_cache.DNSPeers{Host: "cache.default.svc.cluster.local", Port: "6061"}
+//	go sq_cache.WatchPeers(ctx, discovery, time.Second*10, ring, func(event sq_cache.PeerEvent) {
+//	    log.Printf("peer %s joined: %v", event.Peer, event.Joined)
+//	})
+func WatchPeers(
+	ctx context.Context, discovery PeerDiscovery, interval time.Duration, ring *PeerRing, onChange func(event PeerEvent),
+) (err error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if peers, err := discovery.Peers(ctx); err == nil {
+			for _, event := range ring.SetPeers(peers) {
+				if onChange != nil {
+					onChange(event)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
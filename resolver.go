@@ -0,0 +1,151 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// hybridClock is a hybrid logical clock in nanoseconds: its timestamps follow the wall clock, but never repeat or go
+// backwards, and never fall behind the timestamps observed from other instances, so the causally later write of a key
+// always has the later timestamp, even if the wall clocks of the instances are skewed.
+type hybridClock struct {
+	last atomic.Int64
+}
+
+// Now returns the next timestamp of the clock: the wall clock, or the last timestamp plus one nanosecond if the wall
+// clock didn't advance past it.
+func (clock *hybridClock) Now() (t time.Time) {
+	for {
+		last := clock.last.Load()
+		next := max(timeNow().UnixNano(), last+1)
+		if clock.last.CompareAndSwap(last, next) {
+			return time.Unix(0, next)
+		}
+	}
+}
+
+// observe advances the clock to the specified timestamp of another instance, if it is ahead of the clock.
+func (clock *hybridClock) observe(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+
+	nanos := t.UnixNano()
+	for {
+		last := clock.last.Load()
+		if nanos <= last || clock.last.CompareAndSwap(last, nanos) {
+			return
+		}
+	}
+}
+
+// LastWriterWins is a Resolver, which keeps the entry written last by the creation time of its metadata. With equal
+// creation times the entry with the greater value wins, so that all instances converge to the same value. An entry
+// without creation time is older than any entry with one.
+//
+// Parameters:
+//   - key: The key of the entries.
+//   - current: The cached entry.
+//   - incoming: The imported entry.
+//
+// Returns:
+//   - useIncoming: A boolean indicating whether the imported entry replaces the cached entry.
+//
+// Example Usage:
+//
+//	config := &sq_cache.Config[string, []byte]{
+//	    Resolver: sq_cache.LastWriterWins[string, []byte],
+//	}
+func LastWriterWins[K IKey, V IValue](key K, current, incoming Entry[K, V]) (useIncoming bool) {
+	switch incoming.Meta.CreatedAt.Compare(current.Meta.CreatedAt) {
+	case 1:
+		return true
+	case -1:
+		return false
+	default:
+		return bytes.Compare(incoming.Value, current.Value) > 0
+	}
+}
+
+// stamp sets the creation time of the metadata of the written cache item by the hybrid clock, unless the write
+// specified it, so that a Resolver can order the writes of the instances. Must be called with the lock held, after the
+// value and the metadata of the cache item were set.
+func (shard *lruCacheShard[K, V]) stamp(item *lruListNode[K, V], meta *EntryMeta) {
+	if shard.hybridClock == nil || (meta != nil && !meta.CreatedAt.IsZero()) {
+		return
+	}
+
+	var stamped EntryMeta
+	if item.Meta != nil {
+		stamped = *item.Meta
+	}
+	stamped.CreatedAt = shard.hybridClock.Now()
+	item.Meta = &stamped
+}
+
+// importEntry imports the entry of the normalized key, if the Resolver prefers it over the cached entry of the key.
+// Concurrent imports of the same key are serialized by the key locks, so they are resolved one after another.
+func (cache *LRUCache[K, V]) importEntry(
+	ctx context.Context, key K, entry Entry[K, V], meta *EntryMeta,
+) (imported bool, err error) {
+	cache.hybridClock.observe(entry.Meta.CreatedAt)
+
+	mutex := cache.keyLock(key)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	shardId := cache.generateShardId(key, cache.maxShards)
+	cache.flushWrites(shardId)
+
+	shard := cache.shards[shardId]
+	shard.RLockMeasured()
+	item, found := shard.lookup(key, timeNow())
+	var current Entry[K, V]
+	if found {
+		current = cache.entry(item)
+	}
+	shard.RUnlock()
+
+	if found && !cache.resolver(key, current, entry) {
+		return false, nil
+	}
+
+	if _, _, err = cache.setContext(ctx, key, entry.Value, entry.TTL, meta); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,424 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package s3_store provides a sq_cache SnapshotStore, which stores the snapshots of a cache as objects of an
+// S3-compatible object store (e.g. AWS S3, MinIO or Ceph), so that a fleet of instances can bootstrap from a shared warm
+// snapshot at startup. The requests are signed by the AWS Signature Version 4, without any dependency on an AWS SDK.
+package s3_store
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// maxErrorBytes is the maximum size of a read error response.
+	maxErrorBytes = 1 << 16
+
+	// emptyPayloadHash is the SHA-256 hash of an empty payload.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// errInvalidStore is returned when the endpoint or the bucket of the store isn't set.
+var errInvalidStore = errors.New("s3_store: endpoint and bucket must be set")
+
+// Store is a sq_cache.SnapshotStore, which stores the snapshots as objects of a bucket of an S3-compatible object
+// store. The snapshots are uploaded once their writer was closed, so an incomplete snapshot is never listed. The
+// uploads are signed with the SHA-256 hash of their payload, which is validated by the object store.
+type Store struct {
+	// Endpoint is the URL of the object store, e.g. "https://s3.eu-central-1.amazonaws.com" or "http://minio:9000".
+	Endpoint string
+	// Region is the region of the bucket, e.g. "eu-central-1".
+	Region string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Prefix is prepended to the names of the snapshots, e.g. "my-service/cache/".
+	Prefix string
+
+	// AccessKeyID, SecretAccessKey and SessionToken are the credentials the requests are signed with. The SessionToken
+	// is optional.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// PathStyle addresses the bucket by the path of the URL instead of the host, as required by most self-hosted
+	// object stores.
+	PathStyle bool
+
+	// Client sends the requests, http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+var _ sq_cache.SnapshotStore = (*Store)(nil)
+
+// Create creates a snapshot, which is buffered in a temporary file and uploaded once the writer was closed.
+//
+// Parameters:
+//   - name: The name of the snapshot.
+//
+// Returns:
+//   - w: The writer of the snapshot.
+//   - err: An error if the temporary file can't be created.
+//
+// Example Usage:
+//
+//	store := &s3_store.Store{
+//	    Endpoint:        "https://s3.eu-central-1.amazonaws.com",
+//	    Region:          "eu-central-1",
+//	    Bucket:          "my-bucket",
+//	    Prefix:          "my-service/cache/",
+//	    AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//	    SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	}
+//	config := &sq_cache.Config[string, []byte]{
+//	    Snapshot: &sq_cache.SnapshotPolicy{Store: store, Interval: time.Minute * 5, RestoreOnStart: true},
+//	}
+func (store *Store) Create(name string) (w io.WriteCloser, err error) {
+	file, err := os.CreateTemp("", "sq_cache-snapshot-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &upload{store: store, name: name, file: file, hash: sha256.New()}, nil
+}
+
+// Open downloads a snapshot.
+//
+// Parameters:
+//   - name: The name of the snapshot.
+//
+// Returns:
+//   - r: The body of the snapshot, which must be closed.
+//   - err: An error wrapping fs.ErrNotExist if the snapshot doesn't exist, or any other issue.
+func (store *Store) Open(name string) (r io.ReadCloser, err error) {
+	response, err := store.do(http.MethodGet, store.Prefix+name, nil, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, store.responseError(response, name)
+	}
+
+	return response.Body, nil
+}
+
+// List returns the names of the snapshots below the prefix, sorted from the oldest to the newest by their names.
+//
+// Returns:
+//   - names: The names of the snapshots without the prefix.
+//   - err: An error if the objects can't be listed.
+func (store *Store) List() (names []string, err error) {
+	var token string
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {store.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		response, err := store.do(http.MethodGet, "", query, nil, 0, emptyPayloadHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != http.StatusOK {
+			return nil, store.responseError(response, "")
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(response.Body).Decode(&result)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3_store: invalid list response: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			if name := strings.TrimPrefix(object.Key, store.Prefix); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Remove deletes a snapshot.
+//
+// Parameters:
+//   - name: The name of the snapshot.
+//
+// Returns:
+//   - err: An error if the snapshot can't be deleted.
+func (store *Store) Remove(name string) (err error) {
+	response, err := store.do(http.MethodDelete, store.Prefix+name, nil, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return store.responseError(response, name)
+	}
+
+	return nil
+}
+
+// listBucketResult represents the response of a ListObjectsV2 request.
+type listBucketResult struct {
+	Contents []struct {
+		Key string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// errorResponse represents the error response of the object store.
+type errorResponse struct {
+	Code    string
+	Message string
+}
+
+// responseError reads and closes the body of an unsuccessful response and returns it as error.
+func (store *Store) responseError(response *http.Response, name string) (err error) {
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBytes))
+
+	var errResponse errorResponse
+	if xml.Unmarshal(body, &errResponse) != nil || errResponse.Code == "" {
+		errResponse.Code = response.Status
+	}
+
+	err = fmt.Errorf("s3_store: %s %q: %s %s", response.Request.Method, name, errResponse.Code, errResponse.Message)
+	if response.StatusCode == http.StatusNotFound {
+		err = fmt.Errorf("%w: %w", fs.ErrNotExist, err)
+	}
+
+	return err
+}
+
+// upload represents a snapshot, which is buffered in a temporary file and uploaded on Close.
+type upload struct {
+	store *Store
+	name  string
+	file  *os.File
+	hash  hash.Hash
+	size  int64
+}
+
+// Write writes to the temporary file and hashes the written bytes.
+func (upload *upload) Write(p []byte) (n int, err error) {
+	n, err = upload.file.Write(p)
+	upload.hash.Write(p[:n])
+	upload.size += int64(n)
+
+	return n, err
+}
+
+// Close uploads the temporary file and removes it.
+func (upload *upload) Close() (err error) {
+	defer os.Remove(upload.file.Name())
+	defer upload.file.Close()
+
+	if _, err = upload.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	payloadHash := hex.EncodeToString(upload.hash.Sum(nil))
+	response, err := upload.store.do(http.MethodPut, upload.store.Prefix+upload.name, nil, upload.file, upload.size, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return upload.store.responseError(response, upload.name)
+	}
+
+	return nil
+}
+
+// do sends a signed request for the specified object key, or for the bucket if the key is empty.
+func (store *Store) do(method, key string, query url.Values, body io.Reader, size int64, payloadHash string) (response *http.Response, err error) {
+	if store.Endpoint == "" || store.Bucket == "" {
+		return nil, errInvalidStore
+	}
+
+	endpoint, err := url.Parse(store.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("s3_store: invalid endpoint: %w", err)
+	}
+
+	path := "/" + key
+	if store.PathStyle {
+		path = "/" + store.Bucket + path
+	} else {
+		endpoint.Host = store.Bucket + "." + endpoint.Host
+	}
+	endpoint.Path = path
+	endpoint.RawPath = escapePath(path)
+	endpoint.RawQuery = escapeQuery(query)
+
+	request, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.ContentLength = size
+	}
+
+	store.sign(request, payloadHash, time.Now().UTC())
+
+	client := store.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return client.Do(request)
+}
+
+// sign signs the request by the AWS Signature Version 4.
+func (store *Store) sign(request *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if store.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", store.SessionToken)
+	}
+
+	headers := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	values := map[string]string{
+		"host":                 request.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if store.SessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+		values["x-amz-security-token"] = store.SessionToken
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, header := range headers {
+		canonicalHeaders.WriteString(header + ":" + values[header] + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + store.Region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+store.SecretAccessKey), date)
+	key = hmacSHA256(key, store.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		store.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the specified key.
+func hmacSHA256(key []byte, data string) (sum []byte) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// escapePath escapes a path as required by the signature, keeping the slashes.
+func escapePath(path string) (escaped string) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// escapeQuery encodes the query sorted by its keys, as required by the signature.
+func escapeQuery(query url.Values) (escaped string) {
+	var parts []string
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[key] {
+			parts = append(parts, escape(key)+"="+escape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// escape percent-encodes all bytes except the unreserved characters, as required by the signature.
+func escape(s string) (escaped string) {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			builder.WriteByte(c)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,445 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package sessions provides an in-memory session store for net/http on top of a sq_cache LRUCache. The sessions expire
+// after a sliding TTL (time to live), which is extended on every access.
+package sessions
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+const (
+	// DefaultCookieName is the name of the session cookie, if no cookie name was specified.
+	DefaultCookieName = "session_id"
+
+	// idBytes is the number of random bytes of a session id.
+	idBytes = 32
+)
+
+// ErrSessionNotFound is returned when a session doesn't exist or is expired.
+var ErrSessionNotFound = errors.New("sessions: session not found")
+
+// Session represents a session of a user.
+type Session struct {
+	ID        string
+	UserID    string
+	Values    map[string]string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Store is a session store, which keeps the sessions in a LRUCache. The sessions of a user are indexed, so that they
+// can be enumerated and destroyed together. The index keeps the expiry of the sessions, its expired sessions are
+// pruned once per TTL, so that the sessions, which expire or are evicted without being destroyed, don't pile up.
+type Store struct {
+	cache *sq_cache.LRUCache[string, []byte]
+	ttl   time.Duration
+
+	// CookieName is the name of the session cookie.
+	CookieName string
+	// CookieSecure marks the session cookie as secure, i.e. it's only sent over HTTPS.
+	CookieSecure bool
+
+	usersMutex sync.Mutex
+	users      map[string]map[string]time.Time
+	// nextPrune is the time of the next pruning of the expired sessions of the index.
+	nextPrune time.Time
+}
+
+// NewStore creates a Store, which keeps the sessions in the specified cache for the specified sliding TTL.
+//
+// Parameters:
+//   - cache: The cache to store the sessions in.
+//   - ttl: The sliding TTL (time to live) of the sessions.
+//
+// Returns:
+//   - store: The created Store object.
+//
+// Example Usage:
+//
+//	store := sessions.NewStore(cache, time.Minute*30)
+//	session, err := store.Create("user-42", nil)
+//	if err != nil {
+//	    panic(err)
+//	}
+func NewStore(cache *sq_cache.LRUCache[string, []byte], ttl time.Duration) (store *Store) {
+	return &Store{
+		cache: cache,
+		ttl:   ttl,
+
+		CookieName:   DefaultCookieName,
+		CookieSecure: true,
+
+		users: make(map[string]map[string]time.Time),
+	}
+}
+
+// generateId generates a cryptographically secure, URL-safe session id.
+func generateId() (id string, err error) {
+	b := make([]byte, idBytes)
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Create creates a new session of the specified user with the specified values.
+//
+// Parameters:
+//   - userID: The id of the user of the session.
+//   - values: The initial values of the session.
+//
+// Returns:
+//   - session: The created session.
+//   - err: An error if the session id couldn't be generated, if the cache is stopped or closed, or if any other issue
+//     occurs.
+func (store *Store) Create(userID string, values map[string]string) (session *Session, err error) {
+	id, err := generateId()
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = make(map[string]string)
+	}
+
+	session = &Session{
+		ID:        id,
+		UserID:    userID,
+		Values:    values,
+		CreatedAt: time.Now(),
+	}
+
+	if err = store.Save(session); err != nil {
+		return nil, err
+	}
+
+	store.usersMutex.Lock()
+	store.prune(session.CreatedAt)
+	ids, found := store.users[userID]
+	if !found {
+		ids = make(map[string]time.Time)
+		store.users[userID] = ids
+	}
+	ids[id] = session.ExpiresAt
+	store.usersMutex.Unlock()
+
+	return session, nil
+}
+
+// prune removes the expired sessions from the index of the users, at most once per TTL. Must be called with the lock
+// of the index held.
+func (store *Store) prune(now time.Time) {
+	if now.Before(store.nextPrune) {
+		return
+	}
+	store.nextPrune = now.Add(store.ttl)
+
+	for userID, ids := range store.users {
+		for id, expiresAt := range ids {
+			if !now.Before(expiresAt) {
+				delete(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			delete(store.users, userID)
+		}
+	}
+}
+
+// touch updates the expiry of the session in the index of its user, if the session is still indexed.
+func (store *Store) touch(session *Session) {
+	store.usersMutex.Lock()
+	if ids, found := store.users[session.UserID]; found {
+		if _, found = ids[session.ID]; found {
+			ids[session.ID] = session.ExpiresAt
+		}
+	}
+	store.usersMutex.Unlock()
+}
+
+// Save stores the session and extends its expiry by the TTL.
+//
+// Parameters:
+//   - session: The session to store.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (store *Store) Save(session *Session) (err error) {
+	value, err := store.encode(session)
+	if err != nil {
+		return err
+	}
+
+	if _, err = store.cache.SetWithTTL(session.ID, value, store.ttl); err != nil {
+		return err
+	}
+	store.touch(session)
+
+	return nil
+}
+
+// encode extends the expiry of the session by the TTL and encodes it.
+func (store *Store) encode(session *Session) (value []byte, err error) {
+	session.ExpiresAt = time.Now().Add(store.ttl)
+
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(session); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Get fetches the session by the specified id and extends its expiry by the TTL. The session is read and stored again
+// atomically, so that a concurrent Destroy or DestroyByUser isn't undone by the extension.
+//
+// Parameters:
+//   - id: The id of the session.
+//
+// Returns:
+//   - session: The session.
+//   - err: ErrSessionNotFound if the session doesn't exist or is expired, an error if the cache is stopped or closed,
+//     or if any other issue occurs.
+func (store *Store) Get(id string) (session *Session, err error) {
+	err = store.cache.Update([]string{id}, func(view *sq_cache.TxView[string, []byte]) error {
+		value, found, err := view.Get(id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrSessionNotFound
+		}
+
+		session = &Session{}
+		if err = gob.NewDecoder(bytes.NewReader(value)).Decode(session); err != nil {
+			return err
+		}
+		if value, err = store.encode(session); err != nil {
+			return err
+		}
+
+		return view.SetWithTTL(id, value, store.ttl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.touch(session)
+
+	return session, nil
+}
+
+// load fetches the session by the specified id without extending its expiry.
+func (store *Store) load(id string) (session *Session, err error) {
+	value, _, found, err := store.cache.GetWithTTL(id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrSessionNotFound
+	}
+
+	session = &Session{}
+	if err = gob.NewDecoder(bytes.NewReader(value)).Decode(session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Refresh extends the expiry of the session by the specified id by the TTL.
+//
+// Parameters:
+//   - id: The id of the session.
+//
+// Returns:
+//   - err: ErrSessionNotFound if the session doesn't exist or is expired, an error if the cache is stopped or closed,
+//     or if any other issue occurs.
+func (store *Store) Refresh(id string) (err error) {
+	_, err = store.Get(id)
+
+	return err
+}
+
+// Destroy removes the session by the specified id.
+//
+// Parameters:
+//   - id: The id of the session.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (store *Store) Destroy(id string) (err error) {
+	session, err := store.load(id)
+	if err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
+	}
+
+	if session != nil {
+		store.usersMutex.Lock()
+		delete(store.users[session.UserID], id)
+		if len(store.users[session.UserID]) == 0 {
+			delete(store.users, session.UserID)
+		}
+		store.usersMutex.Unlock()
+	}
+
+	_, err = store.cache.Remove(id)
+
+	return err
+}
+
+// ListByUser returns all sessions of the specified user, without extending their expiry. Expired and evicted sessions
+// are removed from the index of the user.
+//
+// Parameters:
+//   - userID: The id of the user.
+//
+// Returns:
+//   - sessions: The sessions of the user.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (store *Store) ListByUser(userID string) (sessions []*Session, err error) {
+	store.usersMutex.Lock()
+	ids := make([]string, 0, len(store.users[userID]))
+	for id := range store.users[userID] {
+		ids = append(ids, id)
+	}
+	store.usersMutex.Unlock()
+
+	var expired []string
+	for _, id := range ids {
+		session, err := store.load(id)
+		if errors.Is(err, ErrSessionNotFound) {
+			expired = append(expired, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		store.usersMutex.Lock()
+		for _, id := range expired {
+			delete(store.users[userID], id)
+		}
+		if len(store.users[userID]) == 0 {
+			delete(store.users, userID)
+		}
+		store.usersMutex.Unlock()
+	}
+
+	return sessions, nil
+}
+
+// DestroyByUser removes all sessions of the specified user, e.g. on a password change.
+//
+// Parameters:
+//   - userID: The id of the user.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (store *Store) DestroyByUser(userID string) (err error) {
+	store.usersMutex.Lock()
+	ids := store.users[userID]
+	delete(store.users, userID)
+	store.usersMutex.Unlock()
+
+	var errs []error
+	for id := range ids {
+		if _, err := store.cache.Remove(id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// FromRequest fetches the session by the session cookie of the request and extends its expiry by the TTL.
+//
+// Parameters:
+//   - r: The request carrying the session cookie.
+//
+// Returns:
+//   - session: The session.
+//   - err: ErrSessionNotFound if the request has no session cookie, or if the session doesn't exist or is expired.
+func (store *Store) FromRequest(r *http.Request) (session *Session, err error) {
+	cookie, err := r.Cookie(store.CookieName)
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+
+	return store.Get(cookie.Value)
+}
+
+// SetCookie sets the session cookie of the session on the response.
+//
+// Parameters:
+//   - w: The response writer.
+//   - session: The session.
+func (store *Store) SetCookie(w http.ResponseWriter, session *Session) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     store.CookieName,
+		Value:    session.ID,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		Secure:   store.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ClearCookie removes the session cookie on the response.
+//
+// Parameters:
+//   - w: The response writer.
+func (store *Store) ClearCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     store.CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   store.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,235 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pendingSet represents the latest coalesced Set of a key, which is applied at the end of the window.
+type pendingSet[V IValue] struct {
+	value V
+	ttl   time.Time
+	meta  *EntryMeta
+	// source is the source of the latest coalesced Set.
+	source EntrySource
+
+	// dirty reports whether a Set was coalesced, after the Set which opened the window or the last coalesced Set was
+	// applied.
+	dirty bool
+
+	// timer closes the window.
+	timer *time.Timer
+}
+
+// setCoalescer represents a thread-safe write-debounce of a shard. The first Set of a key is applied right away and
+// opens a window, the further Sets of the key within the window are coalesced into a single Set of the latest value,
+// which is applied at the end of the window. The coalesced Sets are applied with the lock of the shard held, after
+// checking that their window is still open, so that they never overwrite a later Set or resurrect a removed key.
+type setCoalescer[K IKey, V IValue] struct {
+	sync.Mutex
+
+	window time.Duration
+	// shard is the lock of the shard, which is acquired before the lock of the coalescer.
+	shard sync.Locker
+	// apply applies a coalesced Set, it is called with the lock of the shard held.
+	apply func(key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource)
+	// evict evicts the overflow of the cache after the coalesced Sets were applied, it is called without the lock of
+	// the shard held.
+	evict func()
+
+	pending map[K]*pendingSet[V]
+	// dirty is the number of the open windows with a coalesced Set, which wasn't applied yet.
+	dirty atomic.Int64
+}
+
+// newSetCoalescer initializes and returns a new setCoalescer instance of the shard with the specified lock, which
+// applies the coalesced Sets by apply.
+func newSetCoalescer[K IKey, V IValue](
+	window time.Duration, shard sync.Locker, apply func(key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource),
+	evict func(),
+) (coalescer *setCoalescer[K, V]) {
+	return &setCoalescer[K, V]{
+		window: window,
+		shard:  shard,
+		apply:  apply,
+		evict:  evict,
+
+		pending: make(map[K]*pendingSet[V]),
+	}
+}
+
+// hold coalesces the Set of a key, if a window of the key is open, and reports whether the Set was held back. Otherwise
+// it opens a window of the key and the Set must be applied by the caller. Must be called with the lock of the shard
+// held, which the caller keeps until the Set is applied, so that the window can't be flushed before.
+func (coalescer *setCoalescer[K, V]) hold(
+	key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource,
+) (held bool) {
+	coalescer.Lock()
+	defer coalescer.Unlock()
+
+	if pending, found := coalescer.pending[key]; found {
+		pending.value = value
+		pending.ttl = ttl
+		pending.source = source
+		if meta != nil {
+			pending.meta = meta
+		}
+		if !pending.dirty {
+			pending.dirty = true
+			coalescer.dirty.Add(1)
+		}
+
+		return true
+	}
+
+	pending := &pendingSet[V]{}
+	coalescer.pending[key] = pending
+	pending.timer = time.AfterFunc(coalescer.window, func() {
+		coalescer.flush(key, pending)
+	})
+
+	return false
+}
+
+// flush closes the specified window of a key and applies the coalesced Set of the key, if any. A window, which was
+// discarded meanwhile, is not flushed.
+func (coalescer *setCoalescer[K, V]) flush(key K, pending *pendingSet[V]) {
+	coalescer.shard.Lock()
+	coalescer.Lock()
+	current := coalescer.pending[key] == pending
+	if current {
+		coalescer.drop(key, pending)
+	}
+	coalescer.Unlock()
+
+	applied := current && pending.dirty
+	if applied {
+		coalescer.apply(key, pending.value, pending.ttl, pending.meta, pending.source)
+	}
+	coalescer.shard.Unlock()
+
+	if applied {
+		coalescer.evict()
+	}
+}
+
+// flushDirty applies the coalesced Sets of all keys right away and keeps their windows open, e.g. before a read of the
+// shard, so that the read sees the preceding Sets.
+func (coalescer *setCoalescer[K, V]) flushDirty() {
+	if coalescer.dirty.Load() == 0 {
+		return
+	}
+
+	coalescer.shard.Lock()
+	coalescer.Lock()
+	dirty := make(map[K]*pendingSet[V], coalescer.dirty.Load())
+	for key, pending := range coalescer.pending {
+		if pending.dirty {
+			pending.dirty = false
+			dirty[key] = pending
+		}
+	}
+	coalescer.dirty.Add(-int64(len(dirty)))
+	coalescer.Unlock()
+
+	for key, pending := range dirty {
+		coalescer.apply(key, pending.value, pending.ttl, pending.meta, pending.source)
+	}
+	coalescer.shard.Unlock()
+
+	coalescer.evict()
+}
+
+// drop removes the window of a key, without stopping its timer. Must be called with the lock of the coalescer held.
+func (coalescer *setCoalescer[K, V]) drop(key K, pending *pendingSet[V]) {
+	delete(coalescer.pending, key)
+	if pending.dirty {
+		coalescer.dirty.Add(-1)
+	}
+}
+
+// discard closes the window of a key and drops the coalesced Set of the key, e.g. when the key is removed.
+func (coalescer *setCoalescer[K, V]) discard(key K) {
+	coalescer.Lock()
+	if pending, found := coalescer.pending[key]; found {
+		pending.timer.Stop()
+		coalescer.drop(key, pending)
+	}
+	coalescer.Unlock()
+}
+
+// discardAll closes the windows of all keys and drops their coalesced Sets, e.g. when the cache is purged.
+func (coalescer *setCoalescer[K, V]) discardAll() {
+	coalescer.Lock()
+	for _, pending := range coalescer.pending {
+		pending.timer.Stop()
+	}
+	clear(coalescer.pending)
+	coalescer.dirty.Store(0)
+	coalescer.Unlock()
+}
+
+// discardFunc closes the windows of the keys matched by the specified function and drops their coalesced Sets, e.g.
+// when a namespace is purged.
+func (coalescer *setCoalescer[K, V]) discardFunc(match func(key K) bool) {
+	coalescer.Lock()
+	for key, pending := range coalescer.pending {
+		if match(key) {
+			pending.timer.Stop()
+			coalescer.drop(key, pending)
+		}
+	}
+	coalescer.Unlock()
+}
+
+// flushAll closes the windows of all keys and applies their coalesced Sets right away, e.g. when the cache is closed.
+func (coalescer *setCoalescer[K, V]) flushAll() {
+	coalescer.shard.Lock()
+	coalescer.Lock()
+	pendings := coalescer.pending
+	coalescer.pending = make(map[K]*pendingSet[V])
+	coalescer.dirty.Store(0)
+	coalescer.Unlock()
+
+	for key, pending := range pendings {
+		pending.timer.Stop()
+		if pending.dirty {
+			coalescer.apply(key, pending.value, pending.ttl, pending.meta, pending.source)
+		}
+	}
+	coalescer.shard.Unlock()
+
+	coalescer.evict()
+}
@@ -0,0 +1,78 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+// NewPartitionShardId creates a shardId generation function, which maps the keys to the shards by the partition of the
+// caller, instead of hashing the key. If the work is already partitioned by key across worker goroutines, the same
+// partition function aligns every worker with its own shards, so the workers don't contend for the same shard locks
+// and the key isn't hashed twice. The partition is reduced modulo the number of shards.
+//
+// Parameters:
+//   - partition: The function returning the partition (shard hint) of a key.
+//
+// Returns:
+//   - generateShardId: The shardId generation function, e.g. for the GenerateShardId configuration.
+//
+// Example Usage:
+//
+//	config := &sq_cache.Config[string, []byte]{
+//	    MaxShards:       int64(workers),
+//	    GenerateShardId: sq_cache.NewPartitionShardId(func(key string) int64 {
+//	        return pipeline.Partition(key)
+//	    }),
+//	}
+func NewPartitionShardId[K IKey](partition func(key K) int64) (generateShardId func(key K, maxShards int64) int64) {
+	return func(key K, maxShards int64) (shardId int64) {
+		shardId = partition(key) % maxShards
+		if shardId < 0 {
+			shardId += maxShards
+		}
+
+		return shardId
+	}
+}
+
+// ShardOf returns the shardId the specified key is mapped to, e.g. to verify the alignment of the shards with the
+// partitions of the caller.
+//
+// Parameters:
+//   - key: The key to map.
+//
+// Returns:
+//   - shardId: The shardId of the key, between 0 and MaxShards() - 1.
+//
+// Example Usage:
+//
+//	shardId := cache.ShardOf("my-key")
+func (cache *LRUCache[K, V]) ShardOf(key K) (shardId int64) {
+	return cache.generateShardId(cache.normalizeKey(key), cache.maxShards)
+}
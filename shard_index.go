@@ -0,0 +1,349 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/rand/v2"
+	"sync"
+)
+
+// shardIndex is an interface that defines the key index of a shard, which maps the keys to the cache items.
+type shardIndex[K IKey, V IValue] interface {
+	Get(key K) (item *lruListNode[K, V], found bool)
+	Set(key K, item *lruListNode[K, V])
+	Delete(key K)
+	Len() int
+	All() iter.Seq2[K, *lruListNode[K, V]]
+	// Sample appends up to n cache items, starting at a random position of the index, to the specified slice.
+	Sample(n int, items []*lruListNode[K, V]) []*lruListNode[K, V]
+}
+
+// newShardIndex creates the key index of a shard based on the specified ShardIndexType.
+func newShardIndex[K IKey, V IValue](indexType ShardIndexType, capacity int64) (index shardIndex[K, V]) {
+	switch indexType {
+	case MapIndex:
+		return newMapIndex[K, V](capacity)
+	case SyncMapIndex:
+		return newSyncMapIndex[K, V]()
+	case OpenAddressingIndex:
+		return newOpenAddressingIndex[K, V]()
+	default:
+		panic("ShardIndexType doesn't exists")
+	}
+}
+
+// mapIndex is a key index backed by the built-in map.
+type mapIndex[K IKey, V IValue] struct {
+	nodes map[K]*lruListNode[K, V]
+}
+
+// newMapIndex creates a key index backed by the built-in map with the specified capacity.
+func newMapIndex[K IKey, V IValue](capacity int64) (index *mapIndex[K, V]) {
+	return &mapIndex[K, V]{
+		nodes: make(map[K]*lruListNode[K, V], capacity),
+	}
+}
+
+// Get retrieves the cache item by the specified key.
+func (index *mapIndex[K, V]) Get(key K) (item *lruListNode[K, V], found bool) {
+	item, found = index.nodes[key]
+	return item, found
+}
+
+// Set associates the specified key with the cache item.
+func (index *mapIndex[K, V]) Set(key K, item *lruListNode[K, V]) {
+	index.nodes[key] = item
+}
+
+// Delete removes the specified key.
+func (index *mapIndex[K, V]) Delete(key K) {
+	delete(index.nodes, key)
+}
+
+// Len returns the number of keys.
+func (index *mapIndex[K, V]) Len() int {
+	return len(index.nodes)
+}
+
+// All returns an iterator over all keys and cache items. Keys may be deleted during the iteration.
+func (index *mapIndex[K, V]) All() iter.Seq2[K, *lruListNode[K, V]] {
+	return func(yield func(K, *lruListNode[K, V]) bool) {
+		for key, item := range index.nodes {
+			if !yield(key, item) {
+				return
+			}
+		}
+	}
+}
+
+// Sample appends up to n cache items to the specified slice. The iteration of a map starts at a random position.
+func (index *mapIndex[K, V]) Sample(n int, items []*lruListNode[K, V]) []*lruListNode[K, V] {
+	for _, item := range index.nodes {
+		if n--; n < 0 {
+			break
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// syncMapIndex is a key index backed by sync.Map, which is optimized for read-heavy workloads.
+type syncMapIndex[K IKey, V IValue] struct {
+	nodes sync.Map
+	// len is the number of keys. It isn't atomic, as all writers of the index, Set and Delete, hold the write lock of
+	// the shard, and all readers of len hold at least its read lock, like the ones of the other key indexes.
+	len int
+}
+
+// newSyncMapIndex creates a key index backed by sync.Map.
+func newSyncMapIndex[K IKey, V IValue]() (index *syncMapIndex[K, V]) {
+	return &syncMapIndex[K, V]{}
+}
+
+// Get retrieves the cache item by the specified key.
+func (index *syncMapIndex[K, V]) Get(key K) (item *lruListNode[K, V], found bool) {
+	value, found := index.nodes.Load(key)
+	if !found {
+		return nil, false
+	}
+
+	return value.(*lruListNode[K, V]), true
+}
+
+// Set associates the specified key with the cache item.
+func (index *syncMapIndex[K, V]) Set(key K, item *lruListNode[K, V]) {
+	if _, loaded := index.nodes.Swap(key, item); !loaded {
+		index.len++
+	}
+}
+
+// Delete removes the specified key.
+func (index *syncMapIndex[K, V]) Delete(key K) {
+	if _, loaded := index.nodes.LoadAndDelete(key); loaded {
+		index.len--
+	}
+}
+
+// Len returns the number of keys.
+func (index *syncMapIndex[K, V]) Len() int {
+	return index.len
+}
+
+// All returns an iterator over all keys and cache items. Keys may be deleted during the iteration.
+func (index *syncMapIndex[K, V]) All() iter.Seq2[K, *lruListNode[K, V]] {
+	return func(yield func(K, *lruListNode[K, V]) bool) {
+		index.nodes.Range(func(key, value any) bool {
+			return yield(key.(K), value.(*lruListNode[K, V]))
+		})
+	}
+}
+
+// Sample appends up to n cache items to the specified slice. The iteration order of a sync.Map isn't randomized, so
+// a random number of cache items is skipped first, which makes sampling linear in the number of cache items. The
+// skipped cache items are sampled last, so that every cache item is sampled at most once.
+func (index *syncMapIndex[K, V]) Sample(n int, items []*lruListNode[K, V]) []*lruListNode[K, V] {
+	if index.len == 0 || n <= 0 {
+		return items
+	}
+
+	start := rand.IntN(index.len)
+	position := 0
+	index.nodes.Range(func(_, value any) bool {
+		if position++; position <= start {
+			return true
+		}
+		items = append(items, value.(*lruListNode[K, V]))
+		n--
+		return n > 0
+	})
+
+	position = 0
+	index.nodes.Range(func(_, value any) bool {
+		if n <= 0 || position >= start {
+			return false
+		}
+		items = append(items, value.(*lruListNode[K, V]))
+		position++
+		n--
+		return true
+	})
+
+	return items
+}
+
+// openAddressingSlot represents a slot of the open addressing key index.
+type openAddressingSlot[K IKey, V IValue] struct {
+	key     K
+	item    *lruListNode[K, V]
+	deleted bool
+}
+
+// openAddressingIndex is a key index backed by an open addressing hash table with linear probing, which stores the
+// keys and cache items inline without the bucket overhead of the built-in map.
+type openAddressingIndex[K IKey, V IValue] struct {
+	seed  maphash.Seed
+	slots []openAddressingSlot[K, V]
+	len   int
+	used  int
+}
+
+// openAddressingMinSlots is the initial number of slots of the open addressing key index.
+const openAddressingMinSlots = 8
+
+// newOpenAddressingIndex creates a key index backed by an open addressing hash table.
+func newOpenAddressingIndex[K IKey, V IValue]() (index *openAddressingIndex[K, V]) {
+	return &openAddressingIndex[K, V]{
+		seed:  maphash.MakeSeed(),
+		slots: make([]openAddressingSlot[K, V], openAddressingMinSlots),
+	}
+}
+
+// find returns the slot position of the specified key, or the position of the empty slot that ends the probe sequence
+// if the key wasn't found.
+func (index *openAddressingIndex[K, V]) find(key K) (pos int, found bool) {
+	mask := len(index.slots) - 1
+	pos = int(maphash.String(index.seed, string(key))) & mask
+
+	for {
+		slot := &index.slots[pos]
+		if slot.item == nil && !slot.deleted {
+			return pos, false
+		}
+		if !slot.deleted && slot.key == key {
+			return pos, true
+		}
+		pos = (pos + 1) & mask
+	}
+}
+
+// resize rebuilds the hash table with the specified number of slots, dropping all deleted slots.
+func (index *openAddressingIndex[K, V]) resize(size int) {
+	slots := index.slots
+
+	index.slots = make([]openAddressingSlot[K, V], size)
+	index.len = 0
+	index.used = 0
+
+	for i := range slots {
+		if slots[i].item != nil && !slots[i].deleted {
+			index.Set(slots[i].key, slots[i].item)
+		}
+	}
+}
+
+// Get retrieves the cache item by the specified key.
+func (index *openAddressingIndex[K, V]) Get(key K) (item *lruListNode[K, V], found bool) {
+	pos, found := index.find(key)
+	if !found {
+		return nil, false
+	}
+
+	return index.slots[pos].item, true
+}
+
+// Set associates the specified key with the cache item. The hash table grows, once three quarters of the slots are
+// used by keys or deleted slots.
+func (index *openAddressingIndex[K, V]) Set(key K, item *lruListNode[K, V]) {
+	pos, found := index.find(key)
+	if found {
+		index.slots[pos].item = item
+		return
+	}
+
+	if (index.used+1)*4 > len(index.slots)*3 {
+		size := len(index.slots)
+		if (index.len+1)*2 > size {
+			size *= 2
+		}
+		index.resize(size)
+		pos, _ = index.find(key)
+	}
+
+	index.slots[pos] = openAddressingSlot[K, V]{key: key, item: item}
+	index.len++
+	index.used++
+}
+
+// Delete removes the specified key. The slot is marked as deleted, so that the probe sequences of other keys and
+// running iterations stay intact.
+func (index *openAddressingIndex[K, V]) Delete(key K) {
+	pos, found := index.find(key)
+	if !found {
+		return
+	}
+
+	index.slots[pos] = openAddressingSlot[K, V]{deleted: true}
+	index.len--
+}
+
+// Len returns the number of keys.
+func (index *openAddressingIndex[K, V]) Len() int {
+	return index.len
+}
+
+// All returns an iterator over all keys and cache items. Keys may be deleted during the iteration.
+func (index *openAddressingIndex[K, V]) All() iter.Seq2[K, *lruListNode[K, V]] {
+	return func(yield func(K, *lruListNode[K, V]) bool) {
+		slots := index.slots
+		for i := range slots {
+			if slots[i].item == nil || slots[i].deleted {
+				continue
+			}
+			if !yield(slots[i].key, slots[i].item) {
+				return
+			}
+		}
+	}
+}
+
+// Sample appends up to n cache items to the specified slice, scanning the slots from a random slot on.
+func (index *openAddressingIndex[K, V]) Sample(n int, items []*lruListNode[K, V]) []*lruListNode[K, V] {
+	slots := index.slots
+	start := rand.IntN(len(slots))
+	for i := range slots {
+		if n <= 0 {
+			break
+		}
+
+		slot := &slots[(start+i)&(len(slots)-1)]
+		if slot.item == nil || slot.deleted {
+			continue
+		}
+		items = append(items, slot.item)
+		n--
+	}
+
+	return items
+}
@@ -0,0 +1,284 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// shardIndexTypes are the key index implementations, which are tested and benchmarked.
+var shardIndexTypes = []ShardIndexType{MapIndex, SyncMapIndex, OpenAddressingIndex}
+
+// newTestItem creates a cache item with the specified key.
+func newTestItem(key string) (item *lruListNode[string, []byte]) {
+	item = newLRUListNode[string, []byte]()
+	item.Key = key
+
+	return item
+}
+
+func TestShardIndex(t *testing.T) {
+	for _, indexType := range shardIndexTypes {
+		t.Run(indexType.String(), func(t *testing.T) {
+			index := newShardIndex[string, []byte](indexType, 0)
+
+			for i := range 1000 {
+				key := strconv.Itoa(i)
+				index.Set(key, newTestItem(key))
+			}
+			replaced := newTestItem("0")
+			index.Set("0", replaced)
+			for i := 0; i < 1000; i += 2 {
+				index.Delete(strconv.Itoa(i))
+			}
+			index.Delete("missing")
+
+			if n := index.Len(); n != 500 {
+				t.Errorf("Len() = %d, want 500", n)
+			}
+			for i := range 1000 {
+				key := strconv.Itoa(i)
+				item, found := index.Get(key)
+				if found != (i%2 == 1) {
+					t.Fatalf("Get(%q) found = %t, want %t", key, found, i%2 == 1)
+				}
+				if found && item.Key != key {
+					t.Fatalf("Get(%q) = item of %q", key, item.Key)
+				}
+			}
+
+			count := 0
+			for key, item := range index.All() {
+				if item.Key != key {
+					t.Fatalf("All() yielded %q with the item of %q", key, item.Key)
+				}
+				count++
+			}
+			if count != 500 {
+				t.Errorf("All() yielded %d keys, want 500", count)
+			}
+
+			if items := index.Sample(10, nil); len(items) != 10 {
+				t.Errorf("Sample(10) = %d items, want 10", len(items))
+			}
+			if items := index.Sample(1000, nil); len(items) != 500 {
+				t.Errorf("Sample(1000) = %d items, want all 500", len(items))
+			}
+		})
+	}
+}
+
+func TestOpenAddressingIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, index *openAddressingIndex[string, []byte])
+	}{
+		{
+			name: "probes past tombstones",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				for i := range 6 {
+					index.Set(strconv.Itoa(i), newTestItem(strconv.Itoa(i)))
+				}
+				for i := range 5 {
+					index.Delete(strconv.Itoa(i))
+				}
+
+				if _, found := index.Get("5"); !found {
+					t.Error(`Get("5") found = false, want the key found behind the tombstones`)
+				}
+				for i := range 5 {
+					if _, found := index.Get(strconv.Itoa(i)); found {
+						t.Errorf("Get(%q) found = true, want the deleted key not found", strconv.Itoa(i))
+					}
+				}
+			},
+		},
+		{
+			name: "reuses no tombstone for an existing key",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				index.Set("a", newTestItem("a"))
+				index.Set("b", newTestItem("b"))
+				index.Delete("a")
+				index.Set("b", newTestItem("b"))
+
+				count := 0
+				for range index.All() {
+					count++
+				}
+				if n := index.Len(); n != 1 || count != 1 {
+					t.Errorf("Len() = %d, All() yielded %d keys, want 1, 1", n, count)
+				}
+			},
+		},
+		{
+			name: "resizes after deletes without growing",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				for i := range 10000 {
+					key := strconv.Itoa(i)
+					index.Set(key, newTestItem(key))
+					index.Delete(key)
+				}
+
+				if slots := len(index.slots); slots != openAddressingMinSlots {
+					t.Errorf("len(slots) = %d, want %d, as the tombstones are dropped by the resize", slots,
+						openAddressingMinSlots)
+				}
+				if index.len != 0 || index.used > len(index.slots)*3/4 {
+					t.Errorf("len, used = %d, %d, want 0 and at most three quarters of the slots", index.len, index.used)
+				}
+			},
+		},
+		{
+			name: "grows with the keys",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				for i := range 1000 {
+					key := strconv.Itoa(i)
+					index.Set(key, newTestItem(key))
+				}
+
+				if slots := len(index.slots); slots < 1000*4/3 || slots&(slots-1) != 0 {
+					t.Errorf("len(slots) = %d, want a power of two above the load factor", slots)
+				}
+				for i := range 1000 {
+					if _, found := index.Get(strconv.Itoa(i)); !found {
+						t.Fatalf("Get(%q) found = false, want true", strconv.Itoa(i))
+					}
+				}
+			},
+		},
+		{
+			name: "deletes during All",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				for i := range 100 {
+					key := strconv.Itoa(i)
+					index.Set(key, newTestItem(key))
+				}
+
+				seen := make(map[string]bool)
+				for key := range index.All() {
+					if seen[key] {
+						t.Fatalf("All() yielded %q twice", key)
+					}
+					seen[key] = true
+					index.Delete(key)
+				}
+
+				if len(seen) != 100 || index.Len() != 0 {
+					t.Errorf("All() yielded %d keys, Len() = %d, want 100, 0", len(seen), index.Len())
+				}
+			},
+		},
+		{
+			name: "samples an empty index",
+			run: func(t *testing.T, index *openAddressingIndex[string, []byte]) {
+				if items := index.Sample(5, nil); len(items) != 0 {
+					t.Errorf("Sample(5) = %d items, want none", len(items))
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, newOpenAddressingIndex[string, []byte]())
+		})
+	}
+}
+
+// benchmarkKeys returns the specified number of keys for the benchmarks.
+func benchmarkKeys(n int) (keys []string) {
+	keys = make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+
+	return keys
+}
+
+func BenchmarkShardIndexGet(b *testing.B) {
+	keys := benchmarkKeys(100000)
+
+	for _, indexType := range shardIndexTypes {
+		b.Run(indexType.String(), func(b *testing.B) {
+			index := newShardIndex[string, []byte](indexType, int64(len(keys)))
+			for _, key := range keys {
+				index.Set(key, newTestItem(key))
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				index.Get(keys[i%len(keys)])
+			}
+		})
+	}
+}
+
+func BenchmarkShardIndexSet(b *testing.B) {
+	keys := benchmarkKeys(100000)
+	item := newTestItem("")
+
+	for _, indexType := range shardIndexTypes {
+		b.Run(indexType.String(), func(b *testing.B) {
+			index := newShardIndex[string, []byte](indexType, 0)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				index.Set(keys[i%len(keys)], item)
+			}
+		})
+	}
+}
+
+func BenchmarkShardIndexChurn(b *testing.B) {
+	keys := benchmarkKeys(100000)
+	item := newTestItem("")
+
+	for _, indexType := range shardIndexTypes {
+		b.Run(indexType.String(), func(b *testing.B) {
+			index := newShardIndex[string, []byte](indexType, int64(len(keys)/2))
+			for _, key := range keys[:len(keys)/2] {
+				index.Set(key, item)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				index.Delete(keys[i%len(keys)])
+				index.Set(keys[(i+len(keys)/2)%len(keys)], item)
+			}
+		})
+	}
+}
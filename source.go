@@ -0,0 +1,98 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"time"
+)
+
+// sourceKey is the context key of the EntrySource.
+type sourceKey struct{}
+
+// WithSource returns a copy of the context, which attributes the cache items written with it to the specified source,
+// e.g. to attribute the values of a custom read-through layer to the loader. Without a source, the cache items are
+// attributed by the origin of the context (see WithOrigin): PeerOrigin to PeerSource, RestoreOrigin to RestoreSource
+// and all others to SetSource. The source of a cache item is reported by GetEntry and counted by the telemetry.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - source: The source of the cache items.
+//
+// Returns:
+//   - sourceCtx: The context carrying the source.
+//
+// Example Usage:
+//
+//	_, err := cache.SetContext(sq_cache.WithSource(ctx, sq_cache.LoaderSource), "user:42", user, time.Minute)
+func WithSource(ctx context.Context, source EntrySource) (sourceCtx context.Context) {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// sourceFrom returns the source of the cache items written with the context, see WithSource.
+func sourceFrom(ctx context.Context) (source EntrySource) {
+	if source, found := ctx.Value(sourceKey{}).(EntrySource); found {
+		return source
+	}
+
+	origin, _ := ctx.Value(auditOriginKey{}).(AuditOrigin)
+	switch origin {
+	case PeerOrigin:
+		return PeerSource
+	case RestoreOrigin:
+		return RestoreSource
+	default:
+		return SetSource
+	}
+}
+
+// counterMode returns the telemetry counter of the cache items populated by the source.
+func (source EntrySource) counterMode() (mode counterMode) {
+	switch source {
+	case LoaderSource:
+		return LoaderFill
+	case PeerSource:
+		return PeerFill
+	case PromotionSource:
+		return PromotionFill
+	case RestoreSource:
+		return RestoreFill
+	default:
+		return SetFill
+	}
+}
+
+// contextSetter is implemented by the caches, which accept the context of a Set, e.g. the LRUCache. The Chain uses it
+// to attribute the back-filled cache items to the PromotionSource.
+type contextSetter[K IKey, V IValue] interface {
+	SetContext(ctx context.Context, key K, value V, duration time.Duration) (returnKey K, err error)
+}
@@ -31,7 +31,19 @@
 
 package sq_cache
 
+import (
+	"time"
+)
+
 const (
 	// The name of the library
 	LibraryName = "sq_cache"
+
+	// NoExpiry can be used as TTL (time to live) for cache items that never expire.
+	NoExpiry time.Duration = -1
+
+	// MinTTL is the shortest TTL (time to live) accepted by the Sets with a duration. Shorter positive durations are
+	// rejected with ErrTTLTooShort, as they are most likely seconds passed as a time.Duration, e.g. SetWithTTL(k, v, 30)
+	// instead of SetWithTTL(k, v, time.Second*30), which would expire right away.
+	MinTTL time.Duration = time.Millisecond
 )
\ No newline at end of file
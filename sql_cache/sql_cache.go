@@ -0,0 +1,461 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+// Package sql_cache provides a cache-aside query result cache for database/sql on top of a sq_cache LRUCache.
+package sql_cache
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rommarius/sq_cache"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// ErrNoRows is returned by Result.Scan, if the result has no rows.
+var ErrNoRows = errors.New("sql_cache: result has no rows")
+
+// Result is the cached result of a query.
+type Result struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// Scan copies the columns of the first row into the values pointed at by dest, like sql.Row.Scan.
+func (result *Result) Scan(dest ...any) (err error) {
+	if len(result.Rows) == 0 {
+		return ErrNoRows
+	}
+
+	return scanRow(result.Rows[0], dest)
+}
+
+// ScanRow copies the columns of the specified row into the values pointed at by dest.
+func (result *Result) ScanRow(row int, dest ...any) (err error) {
+	if row < 0 || row >= len(result.Rows) {
+		return ErrNoRows
+	}
+
+	return scanRow(result.Rows[row], dest)
+}
+
+// scanRow copies the columns of a row into the values pointed at by dest.
+func scanRow(row []any, dest []any) (err error) {
+	if len(dest) != len(row) {
+		return fmt.Errorf("sql_cache: expected %d destination arguments in Scan, not %d", len(row), len(dest))
+	}
+
+	for i, value := range row {
+		if scanner, ok := dest[i].(sql.Scanner); ok {
+			if err = scanner.Scan(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = assign(dest[i], value); err != nil {
+			return fmt.Errorf("sql_cache: column %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// assign stores the value in the destination pointer, if the types match.
+func assign(dest any, value any) (err error) {
+	switch d := dest.(type) {
+	case *any:
+		*d = value
+	case *string:
+		switch v := value.(type) {
+		case string:
+			*d = v
+		case []byte:
+			*d = string(v)
+		default:
+			return fmt.Errorf("can't assign %T to *string", value)
+		}
+	case *[]byte:
+		switch v := value.(type) {
+		case []byte:
+			*d = bytes.Clone(v)
+		case string:
+			*d = []byte(v)
+		default:
+			return fmt.Errorf("can't assign %T to *[]byte", value)
+		}
+	case *int64:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("can't assign %T to *int64", value)
+		}
+		*d = v
+	case *int:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("can't assign %T to *int", value)
+		}
+		*d = int(v)
+	case *float64:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("can't assign %T to *float64", value)
+		}
+		*d = v
+	case *bool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("can't assign %T to *bool", value)
+		}
+		*d = v
+	case *time.Time:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("can't assign %T to *time.Time", value)
+		}
+		*d = v
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+
+	return nil
+}
+
+// queryCall represents an in-flight query for a single key.
+type queryCall struct {
+	wg sync.WaitGroup
+
+	result *Result
+	err    error
+}
+
+// DB wraps a sql.DB with a cache-aside query result cache. Query results are cached by the query and its arguments,
+// and are invalidated explicitly or by the tags of the executed statements.
+type DB struct {
+	db    *sql.DB
+	cache *sq_cache.LRUCache[string, []byte]
+	ttl   time.Duration
+
+	// QueryTags returns the tags (e.g. the table names) of a query, under which its result is indexed.
+	QueryTags func(query string) (tags []string)
+	// ExecTags returns the tags (e.g. the table names) of a statement, whose cached query results are invalidated once
+	// the statement was executed successfully.
+	ExecTags func(query string) (tags []string)
+
+	tagsMutex sync.Mutex
+	tags      map[string]map[string]struct{}
+	// generation is incremented by every invalidation, a query result is only cached, if no invalidation happened
+	// while the query was running.
+	generation uint64
+
+	callsMutex sync.Mutex
+	calls      map[string]*queryCall
+}
+
+// New creates a DB, which caches the query results of the specified database in the specified cache.
+//
+// Parameters:
+//   - db: The database to query.
+//   - cache: The cache to store the query results in.
+//   - ttl: The TTL (time to live) of the query results, zero falls back to the default TTL of the cache.
+//
+// Returns:
+//   - cachedDB: The created DB object.
+//
+// Example Usage:
+//
+//	cachedDB := sql_cache.New(db, cache, time.Minute)
+//	result, err := cachedDB.Query(ctx, "SELECT name FROM users WHERE id = ?", 42)
+//	if err != nil {
+//	    panic(err)
+//	}
+func New(db *sql.DB, cache *sq_cache.LRUCache[string, []byte], ttl time.Duration) (cachedDB *DB) {
+	return &DB{
+		db:    db,
+		cache: cache,
+		ttl:   ttl,
+		tags:  make(map[string]map[string]struct{}),
+		calls: make(map[string]*queryCall),
+	}
+}
+
+// Key generates the cache key of a query and its arguments. The query and the arguments are length-prefixed, so that
+// different queries and arguments never share a key. The arguments are converted like by database/sql, i.e. pointers
+// are dereferenced and driver.Valuers are replaced by their values.
+func Key(query string, args ...any) (key string) {
+	var b strings.Builder
+
+	writeKeyPart(&b, 'q', query)
+	for _, arg := range args {
+		writeKeyArg(&b, arg)
+	}
+
+	return b.String()
+}
+
+// writeKeyArg writes an argument of a query to the key.
+func writeKeyArg(b *strings.Builder, arg any) {
+	if named, ok := arg.(sql.NamedArg); ok {
+		writeKeyPart(b, 'n', named.Name)
+		arg = named.Value
+	}
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(arg)
+	if err != nil {
+		// The argument is left to the driver, e.g. by a driver.NamedValueChecker.
+		writeKeyPart(b, 'x', fmt.Sprintf("%T:%#v", arg, arg))
+		return
+	}
+
+	switch v := value.(type) {
+	case nil:
+		writeKeyPart(b, '0', "")
+	case int64:
+		writeKeyPart(b, 'i', strconv.FormatInt(v, 10))
+	case uint64:
+		writeKeyPart(b, 'u', strconv.FormatUint(v, 10))
+	case float64:
+		writeKeyPart(b, 'f', strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		writeKeyPart(b, 'b', strconv.FormatBool(v))
+	case []byte:
+		writeKeyPart(b, 'y', string(v))
+	case string:
+		writeKeyPart(b, 's', v)
+	case time.Time:
+		writeKeyPart(b, 't', v.Format(time.RFC3339Nano))
+	default:
+		writeKeyPart(b, 'x', fmt.Sprintf("%T:%#v", v, v))
+	}
+}
+
+// writeKeyPart writes a part of the key, by its kind and its length-prefixed value.
+func writeKeyPart(b *strings.Builder, kind byte, value string) {
+	b.WriteByte(kind)
+	b.WriteString(strconv.Itoa(len(value)))
+	b.WriteByte(':')
+	b.WriteString(value)
+}
+
+// Query returns the cached result of the query, or runs the query on the database and caches its result. Concurrent
+// misses of the same query and arguments are coalesced into a single query (singleflight). The result is indexed under
+// its tags before it is cached, and it isn't cached, if the cached results were invalidated while the query was running,
+// so that a concurrent Exec never leaves a stale result in the cache.
+//
+// Parameters:
+//   - ctx: The context of the query.
+//   - query: The query to run.
+//   - args: The arguments of the query.
+//
+// Returns:
+//   - result: The result of the query.
+//   - err: An error if the query failed, or if any other issue occurs.
+func (cachedDB *DB) Query(ctx context.Context, query string, args ...any) (result *Result, err error) {
+	key := Key(query, args...)
+
+	if value, err := cachedDB.cache.Get(key); err == nil && value != nil {
+		result = &Result{}
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(result); err == nil {
+			return result, nil
+		}
+	}
+
+	cachedDB.callsMutex.Lock()
+	if call, found := cachedDB.calls[key]; found {
+		cachedDB.callsMutex.Unlock()
+		call.wg.Wait()
+		return call.result, call.err
+	}
+	call := &queryCall{}
+	call.wg.Add(1)
+	cachedDB.calls[key] = call
+	cachedDB.callsMutex.Unlock()
+
+	call.result, call.err = cachedDB.load(ctx, key, query, args...)
+
+	cachedDB.callsMutex.Lock()
+	delete(cachedDB.calls, key)
+	cachedDB.callsMutex.Unlock()
+
+	call.wg.Done()
+
+	return call.result, call.err
+}
+
+// load runs the query on the database and caches its result under the specified key, if no invalidation happened
+// while the query was running.
+func (cachedDB *DB) load(ctx context.Context, key string, query string, args ...any) (result *Result, err error) {
+	cachedDB.tagsMutex.Lock()
+	generation := cachedDB.generation
+	cachedDB.tagsMutex.Unlock()
+
+	result, err = cachedDB.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(result); err != nil {
+		return result, nil
+	}
+
+	var tags []string
+	if cachedDB.QueryTags != nil {
+		tags = cachedDB.QueryTags(query)
+	}
+
+	// The tags are registered and the result is cached under the lock, so that an invalidation either happens before
+	// and the result isn't cached, or after and removes the result.
+	cachedDB.tagsMutex.Lock()
+	defer cachedDB.tagsMutex.Unlock()
+
+	if cachedDB.generation != generation {
+		return result, nil
+	}
+	cachedDB.setTags(key, tags)
+	// The cache rejects results exceeding its size limit, which are returned uncached.
+	_, _ = cachedDB.cache.SetWithTTL(key, buf.Bytes(), cachedDB.ttl)
+
+	return result, nil
+}
+
+// query runs the query on the database and reads all rows.
+func (cachedDB *DB) query(ctx context.Context, query string, args ...any) (result *Result, err error) {
+	rows, err := cachedDB.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result = &Result{Columns: columns}
+	for rows.Next() {
+		row := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range row {
+			pointers[i] = &row[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, rows.Err()
+}
+
+// Exec executes the statement on the database and invalidates the cached query results of the tags returned by
+// ExecTags.
+//
+// Parameters:
+//   - ctx: The context of the statement.
+//   - query: The statement to execute.
+//   - args: The arguments of the statement.
+//
+// Returns:
+//   - result: The result of the statement.
+//   - err: An error if the statement failed, or if any other issue occurs.
+func (cachedDB *DB) Exec(ctx context.Context, query string, args ...any) (result sql.Result, err error) {
+	result, err = cachedDB.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedDB.ExecTags != nil {
+		if err = cachedDB.InvalidateTags(cachedDB.ExecTags(query)...); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Invalidate removes the cached result of the query and its arguments.
+func (cachedDB *DB) Invalidate(query string, args ...any) (err error) {
+	cachedDB.tagsMutex.Lock()
+	cachedDB.generation++
+	cachedDB.tagsMutex.Unlock()
+
+	_, err = cachedDB.cache.Remove(Key(query, args...))
+
+	return err
+}
+
+// InvalidateTags removes the cached results of all queries indexed under the specified tags.
+func (cachedDB *DB) InvalidateTags(tags ...string) (err error) {
+	cachedDB.tagsMutex.Lock()
+	cachedDB.generation++
+	var keys []string
+	for _, tag := range tags {
+		for key := range cachedDB.tags[tag] {
+			keys = append(keys, key)
+		}
+		delete(cachedDB.tags, tag)
+	}
+	cachedDB.tagsMutex.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		if _, err := cachedDB.cache.Remove(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setTags adds the specified key to the index of the specified tags. Must be called with the lock of the tags held.
+func (cachedDB *DB) setTags(key string, tags []string) {
+	for _, tag := range tags {
+		keys, found := cachedDB.tags[tag]
+		if !found {
+			keys = make(map[string]struct{})
+			cachedDB.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
@@ -45,8 +45,45 @@ const (
 	Hit
 	Miss
 	Evict
+	LoadSuccess
+	LoadFailure
+	LoadTimeout
+	Oversized
+	Rejected
+	Lock
+	LockContention
+	LockWait
+	RecommendedShards
+	SnapshotTime
+	SnapshotBytes
+	SnapshotItems
+	AuditDropped
+	WALDropped
+	SetFill
+	LoaderFill
+	PeerFill
+	PromotionFill
+	RestoreFill
+	LoadCoalesced
+	VictimHit
+	GhostHit
 )
 
+// counterModes lists all counterModes, e.g. to iterate over all counters.
+var counterModes = []counterMode{
+	Add, Update, Hit, Miss, Evict,
+	LoadSuccess, LoadFailure, LoadTimeout,
+	Oversized, Rejected,
+	Lock, LockContention, LockWait,
+	RecommendedShards,
+	SnapshotTime, SnapshotBytes, SnapshotItems,
+	AuditDropped,
+	WALDropped,
+	SetFill, LoaderFill, PeerFill, PromotionFill, RestoreFill,
+	LoadCoalesced,
+	VictimHit, GhostHit,
+}
+
 // telemetry is a structure that holds atomic counters for different telemetry metrics.
 type telemetry struct {
 	Add    atomic.Int64
@@ -54,6 +91,54 @@ type telemetry struct {
 	Hit    atomic.Int64
 	Miss   atomic.Int64
 	Evict  atomic.Int64
+
+	LoadSuccess atomic.Int64
+	LoadFailure atomic.Int64
+	LoadTimeout atomic.Int64
+
+	Oversized atomic.Int64
+	Rejected  atomic.Int64
+
+	Lock           atomic.Int64
+	LockContention atomic.Int64
+	LockWait       atomic.Int64
+
+	RecommendedShards atomic.Int64
+
+	SnapshotTime  atomic.Int64
+	SnapshotBytes atomic.Int64
+	SnapshotItems atomic.Int64
+
+	AuditDropped atomic.Int64
+
+	// WALDropped counts the records, which weren't written to the write-ahead log, since writing it failed.
+	WALDropped atomic.Int64
+
+	// SetFill, LoaderFill, PeerFill, PromotionFill and RestoreFill count the writes of the cache items by their source,
+	// see EntrySource.
+	SetFill       atomic.Int64
+	LoaderFill    atomic.Int64
+	PeerFill      atomic.Int64
+	PromotionFill atomic.Int64
+	RestoreFill   atomic.Int64
+
+	// LoadCoalesced counts the loads, which waited for an in-flight load of the same key instead of calling the origin.
+	LoadCoalesced atomic.Int64
+
+	// VictimHit counts the misses, which were served by the victim cache, see VictimRatio.
+	VictimHit atomic.Int64
+
+	// GhostHit counts the misses of recently evicted keys, which would have been hits with more capacity, see GhostRatio.
+	GhostHit atomic.Int64
+
+	// valueSizes is the distribution of the value sizes in bytes and shardItems the distribution of the number of cache
+	// items per shard, both set by the Telemetry of the cache.
+	valueSizes Histogram
+	shardItems Histogram
+
+	// missesSaved is the number of misses, which would have been hits with 10%, 20%, ... more capacity, set by the
+	// Telemetry of the cache.
+	missesSaved []int64
 }
 
 // newTelemetry creates and returns a new instance of telemetry with all counters initialized.
@@ -62,40 +147,105 @@ func newTelemetry() *telemetry {
 	return t
 }
 
-// getCounter retrieves the value of the specified counter based on the counterMode.
-func (t *telemetry) getCounter(mode counterMode) (value int64) {
+// counter returns the atomic counter based on the counterMode.
+func (t *telemetry) counter(mode counterMode) (counter *atomic.Int64) {
 	switch mode {
 	case Add:
-		return t.Add.Load()
+		return &t.Add
 	case Update:
-		return t.Update.Load()
+		return &t.Update
 	case Hit:
-		return t.Hit.Load()
+		return &t.Hit
 	case Miss:
-		return t.Miss.Load()
+		return &t.Miss
 	case Evict:
-		return t.Evict.Load()
+		return &t.Evict
+	case LoadSuccess:
+		return &t.LoadSuccess
+	case LoadFailure:
+		return &t.LoadFailure
+	case LoadTimeout:
+		return &t.LoadTimeout
+	case Oversized:
+		return &t.Oversized
+	case Rejected:
+		return &t.Rejected
+	case Lock:
+		return &t.Lock
+	case LockContention:
+		return &t.LockContention
+	case LockWait:
+		return &t.LockWait
+	case RecommendedShards:
+		return &t.RecommendedShards
+	case SnapshotTime:
+		return &t.SnapshotTime
+	case SnapshotBytes:
+		return &t.SnapshotBytes
+	case SnapshotItems:
+		return &t.SnapshotItems
+	case AuditDropped:
+		return &t.AuditDropped
+	case WALDropped:
+		return &t.WALDropped
+	case SetFill:
+		return &t.SetFill
+	case LoaderFill:
+		return &t.LoaderFill
+	case PeerFill:
+		return &t.PeerFill
+	case PromotionFill:
+		return &t.PromotionFill
+	case RestoreFill:
+		return &t.RestoreFill
+	case LoadCoalesced:
+		return &t.LoadCoalesced
+	case VictimHit:
+		return &t.VictimHit
+	case GhostHit:
+		return &t.GhostHit
 	default:
 		panic("counterMode doesn't exists")
 	}
 }
 
+// getCounter retrieves the value of the specified counter based on the counterMode.
+func (t *telemetry) getCounter(mode counterMode) (value int64) {
+	return t.counter(mode).Load()
+}
+
 // setCounter sets the value of the specified counter based on the counterMode.
 func (t *telemetry) setCounter(mode counterMode, value int64) {
-	switch mode {
-	case Add:
-		t.Add.Store(value)
-	case Update:
-		t.Update.Store(value)
-	case Hit:
-		t.Hit.Store(value)
-	case Miss:
-		t.Miss.Store(value)
-	case Evict:
-		t.Evict.Store(value)
-	default:
-		panic("counterMode doesn't exists")
+	t.counter(mode).Store(value)
+}
+
+// incrementCounter increments the value of the specified counter based on the counterMode by one.
+func (t *telemetry) incrementCounter(mode counterMode) {
+	t.counter(mode).Add(1)
+}
+
+// addCounter adds the specified delta to the value of the specified counter based on the counterMode.
+func (t *telemetry) addCounter(mode counterMode, delta int64) {
+	t.counter(mode).Add(delta)
+}
+
+// merge adds the values of all counters of another telemetry (other) to the counters of the telemetry.
+func (t *telemetry) merge(other *telemetry) {
+	for _, mode := range counterModes {
+		t.addCounter(mode, other.getCounter(mode))
+	}
+	t.valueSizes.merge(&other.valueSizes)
+	t.shardItems.merge(&other.shardItems)
+}
+
+// reset resets the values of all counters to zero.
+func (t *telemetry) reset() {
+	for _, mode := range counterModes {
+		t.setCounter(mode, 0)
 	}
+	t.valueSizes = Histogram{}
+	t.shardItems = Histogram{}
+	t.missesSaved = nil
 }
 
 // GetAddCounter retrieves the current value of the "Add" counter.
@@ -147,3 +297,240 @@ func (t *telemetry) GetEvictCounter() (value int64) {
 func (t *telemetry) SetEvictCounter(value int64) {
 	t.setCounter(Evict, value)
 }
+
+// GetLoadSuccessCounter retrieves the current value of the "LoadSuccess" counter.
+func (t *telemetry) GetLoadSuccessCounter() (value int64) {
+	return t.getCounter(LoadSuccess)
+}
+
+// SetLoadSuccessCounter Sets the value of the "LoadSuccess" counter.
+func (t *telemetry) SetLoadSuccessCounter(value int64) {
+	t.setCounter(LoadSuccess, value)
+}
+
+// GetLoadFailureCounter retrieves the current value of the "LoadFailure" counter.
+func (t *telemetry) GetLoadFailureCounter() (value int64) {
+	return t.getCounter(LoadFailure)
+}
+
+// SetLoadFailureCounter Sets the value of the "LoadFailure" counter.
+func (t *telemetry) SetLoadFailureCounter(value int64) {
+	t.setCounter(LoadFailure, value)
+}
+
+// GetLoadTimeoutCounter retrieves the current value of the "LoadTimeout" counter.
+func (t *telemetry) GetLoadTimeoutCounter() (value int64) {
+	return t.getCounter(LoadTimeout)
+}
+
+// SetLoadTimeoutCounter Sets the value of the "LoadTimeout" counter.
+func (t *telemetry) SetLoadTimeoutCounter(value int64) {
+	t.setCounter(LoadTimeout, value)
+}
+
+// GetOversizedCounter retrieves the current value of the "Oversized" counter.
+func (t *telemetry) GetOversizedCounter() (value int64) {
+	return t.getCounter(Oversized)
+}
+
+// SetOversizedCounter Sets the value of the "Oversized" counter.
+func (t *telemetry) SetOversizedCounter(value int64) {
+	t.setCounter(Oversized, value)
+}
+
+// GetRejectedCounter retrieves the current value of the "Rejected" counter.
+func (t *telemetry) GetRejectedCounter() (value int64) {
+	return t.getCounter(Rejected)
+}
+
+// SetRejectedCounter Sets the value of the "Rejected" counter.
+func (t *telemetry) SetRejectedCounter(value int64) {
+	t.setCounter(Rejected, value)
+}
+
+// GetLockCounter retrieves the current value of the "Lock" counter.
+func (t *telemetry) GetLockCounter() (value int64) {
+	return t.getCounter(Lock)
+}
+
+// SetLockCounter Sets the value of the "Lock" counter.
+func (t *telemetry) SetLockCounter(value int64) {
+	t.setCounter(Lock, value)
+}
+
+// GetLockContentionCounter retrieves the current value of the "LockContention" counter.
+func (t *telemetry) GetLockContentionCounter() (value int64) {
+	return t.getCounter(LockContention)
+}
+
+// SetLockContentionCounter Sets the value of the "LockContention" counter.
+func (t *telemetry) SetLockContentionCounter(value int64) {
+	t.setCounter(LockContention, value)
+}
+
+// GetLockWaitCounter retrieves the current value of the "LockWait" counter.
+func (t *telemetry) GetLockWaitCounter() (value int64) {
+	return t.getCounter(LockWait)
+}
+
+// SetLockWaitCounter Sets the value of the "LockWait" counter.
+func (t *telemetry) SetLockWaitCounter(value int64) {
+	t.setCounter(LockWait, value)
+}
+
+// GetRecommendedShardsCounter retrieves the current value of the "RecommendedShards" counter.
+func (t *telemetry) GetRecommendedShardsCounter() (value int64) {
+	return t.getCounter(RecommendedShards)
+}
+
+// SetRecommendedShardsCounter Sets the value of the "RecommendedShards" counter.
+func (t *telemetry) SetRecommendedShardsCounter(value int64) {
+	t.setCounter(RecommendedShards, value)
+}
+
+// GetSnapshotTimeCounter retrieves the current value of the "SnapshotTime" counter.
+func (t *telemetry) GetSnapshotTimeCounter() (value int64) {
+	return t.getCounter(SnapshotTime)
+}
+
+// SetSnapshotTimeCounter Sets the value of the "SnapshotTime" counter.
+func (t *telemetry) SetSnapshotTimeCounter(value int64) {
+	t.setCounter(SnapshotTime, value)
+}
+
+// GetSnapshotBytesCounter retrieves the current value of the "SnapshotBytes" counter.
+func (t *telemetry) GetSnapshotBytesCounter() (value int64) {
+	return t.getCounter(SnapshotBytes)
+}
+
+// SetSnapshotBytesCounter Sets the value of the "SnapshotBytes" counter.
+func (t *telemetry) SetSnapshotBytesCounter(value int64) {
+	t.setCounter(SnapshotBytes, value)
+}
+
+// GetSnapshotItemsCounter retrieves the current value of the "SnapshotItems" counter.
+func (t *telemetry) GetSnapshotItemsCounter() (value int64) {
+	return t.getCounter(SnapshotItems)
+}
+
+// SetSnapshotItemsCounter Sets the value of the "SnapshotItems" counter.
+func (t *telemetry) SetSnapshotItemsCounter(value int64) {
+	t.setCounter(SnapshotItems, value)
+}
+
+// GetAuditDroppedCounter retrieves the current value of the "AuditDropped" counter.
+func (t *telemetry) GetAuditDroppedCounter() (value int64) {
+	return t.getCounter(AuditDropped)
+}
+
+// SetAuditDroppedCounter Sets the value of the "AuditDropped" counter.
+func (t *telemetry) SetAuditDroppedCounter(value int64) {
+	t.setCounter(AuditDropped, value)
+}
+
+// GetWALDroppedCounter retrieves the current value of the "WALDropped" counter.
+func (t *telemetry) GetWALDroppedCounter() (value int64) {
+	return t.getCounter(WALDropped)
+}
+
+// SetWALDroppedCounter Sets the value of the "WALDropped" counter.
+func (t *telemetry) SetWALDroppedCounter(value int64) {
+	t.setCounter(WALDropped, value)
+}
+
+// GetValueSizeHistogram retrieves the distribution of the sizes of the cached values in bytes.
+func (t *telemetry) GetValueSizeHistogram() (histogram Histogram) {
+	return t.valueSizes
+}
+
+// GetShardItemsHistogram retrieves the distribution of the number of cache items per shard.
+func (t *telemetry) GetShardItemsHistogram() (histogram Histogram) {
+	return t.shardItems
+}
+
+// GetMissesSaved retrieves the estimated number of misses, which would have been hits with more capacity: the element
+// i counts the misses saved by (i+1)*10% more capacity, including the ones of the smaller steps. It is empty, unless
+// the ghost list is enabled (see GhostRatio).
+func (t *telemetry) GetMissesSaved() (saved []int64) {
+	return t.missesSaved
+}
+
+// GetSetFillCounter retrieves the current value of the "SetFill" counter.
+func (t *telemetry) GetSetFillCounter() (value int64) {
+	return t.getCounter(SetFill)
+}
+
+// SetSetFillCounter Sets the value of the "SetFill" counter.
+func (t *telemetry) SetSetFillCounter(value int64) {
+	t.setCounter(SetFill, value)
+}
+
+// GetLoaderFillCounter retrieves the current value of the "LoaderFill" counter.
+func (t *telemetry) GetLoaderFillCounter() (value int64) {
+	return t.getCounter(LoaderFill)
+}
+
+// SetLoaderFillCounter Sets the value of the "LoaderFill" counter.
+func (t *telemetry) SetLoaderFillCounter(value int64) {
+	t.setCounter(LoaderFill, value)
+}
+
+// GetPeerFillCounter retrieves the current value of the "PeerFill" counter.
+func (t *telemetry) GetPeerFillCounter() (value int64) {
+	return t.getCounter(PeerFill)
+}
+
+// SetPeerFillCounter Sets the value of the "PeerFill" counter.
+func (t *telemetry) SetPeerFillCounter(value int64) {
+	t.setCounter(PeerFill, value)
+}
+
+// GetPromotionFillCounter retrieves the current value of the "PromotionFill" counter.
+func (t *telemetry) GetPromotionFillCounter() (value int64) {
+	return t.getCounter(PromotionFill)
+}
+
+// SetPromotionFillCounter Sets the value of the "PromotionFill" counter.
+func (t *telemetry) SetPromotionFillCounter(value int64) {
+	t.setCounter(PromotionFill, value)
+}
+
+// GetRestoreFillCounter retrieves the current value of the "RestoreFill" counter.
+func (t *telemetry) GetRestoreFillCounter() (value int64) {
+	return t.getCounter(RestoreFill)
+}
+
+// SetRestoreFillCounter Sets the value of the "RestoreFill" counter.
+func (t *telemetry) SetRestoreFillCounter(value int64) {
+	t.setCounter(RestoreFill, value)
+}
+
+// GetLoadCoalescedCounter retrieves the current value of the "LoadCoalesced" counter.
+func (t *telemetry) GetLoadCoalescedCounter() (value int64) {
+	return t.getCounter(LoadCoalesced)
+}
+
+// SetLoadCoalescedCounter Sets the value of the "LoadCoalesced" counter.
+func (t *telemetry) SetLoadCoalescedCounter(value int64) {
+	t.setCounter(LoadCoalesced, value)
+}
+
+// GetVictimHitCounter retrieves the current value of the "VictimHit" counter.
+func (t *telemetry) GetVictimHitCounter() (value int64) {
+	return t.getCounter(VictimHit)
+}
+
+// SetVictimHitCounter Sets the value of the "VictimHit" counter.
+func (t *telemetry) SetVictimHitCounter(value int64) {
+	t.setCounter(VictimHit, value)
+}
+
+// GetGhostHitCounter retrieves the current value of the "GhostHit" counter.
+func (t *telemetry) GetGhostHitCounter() (value int64) {
+	return t.getCounter(GhostHit)
+}
+
+// SetGhostHitCounter Sets the value of the "GhostHit" counter.
+func (t *telemetry) SetGhostHitCounter(value int64) {
+	t.setCounter(GhostHit, value)
+}
@@ -0,0 +1,346 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+	"time"
+)
+
+// ThreadLocalConfig is a structure that holds the settings of a ThreadLocalCache.
+type ThreadLocalConfig struct {
+	// LocalItems is the capacity of every LocalCache, 256 if zero.
+	LocalItems int
+	// SyncInterval is the interval of the synchronization of a LocalCache with the shared cache, which bounds the
+	// staleness of its cache items and the delay of its Sets, 100 milliseconds if zero.
+	SyncInterval time.Duration
+}
+
+// ThreadLocalCache represents a shared-nothing mode over a LRUCache for extreme QPS workloads: every worker goroutine
+// gets a small private LocalCache by Local, which serves the hot keys of the worker without any lock, and periodically
+// synchronizes with the shared cache. The Sets of a worker are visible to the other workers after the synchronization,
+// and the cache items of a LocalCache are at most one SyncInterval stale.
+type ThreadLocalCache[K IKey, V IValue] struct {
+	cache *LRUCache[K, V]
+
+	localItems   int
+	syncInterval time.Duration
+}
+
+// NewThreadLocalCache creates a ThreadLocalCache over the specified shared cache.
+//
+// Parameters:
+//   - cache: The shared cache.
+//   - config: The settings of the local caches, nil for the defaults.
+//
+// Returns:
+//   - threadLocal: The created ThreadLocalCache object.
+//
+// Example Usage:
+//
+//	threadLocal := sq_cache.NewThreadLocalCache(cache, &sq_cache.ThreadLocalConfig{LocalItems: 1024})
+//	for range workers {
+//	    go func() {
+//	        local := threadLocal.Local()
+//	        defer local.Sync()
+//	        for request := range requests {
+//	            value, err := local.Get(request.Key)
+//	            ...
+//	        }
+//	    }()
+//	}
+func NewThreadLocalCache[K IKey, V IValue](
+	cache *LRUCache[K, V], config *ThreadLocalConfig,
+) (threadLocal *ThreadLocalCache[K, V]) {
+	threadLocal = &ThreadLocalCache[K, V]{
+		cache: cache,
+
+		localItems:   256,
+		syncInterval: time.Millisecond * 100,
+	}
+
+	if config != nil {
+		if config.LocalItems > 0 {
+			threadLocal.localItems = config.LocalItems
+		}
+		if config.SyncInterval > 0 {
+			threadLocal.syncInterval = config.SyncInterval
+		}
+	}
+
+	return threadLocal
+}
+
+// Local creates a LocalCache for a worker goroutine. The LocalCache isn't safe for concurrent use, every worker must
+// create its own.
+//
+// Returns:
+//   - local: The created LocalCache object.
+func (threadLocal *ThreadLocalCache[K, V]) Local() (local *LocalCache[K, V]) {
+	return &LocalCache[K, V]{
+		threadLocal: threadLocal,
+
+		list:  newLRUList[K, V](),
+		nodes: make(map[K]*lruListNode[K, V], threadLocal.localItems),
+		dirty: make(map[K]struct{}),
+
+		syncedAt: time.Now(),
+	}
+}
+
+// LocalCache represents the private, non thread-safe LRU cache of a worker goroutine, created by
+// ThreadLocalCache.Local. The operations synchronize the LocalCache with the shared cache, once the SyncInterval
+// passed since the last synchronization: the buffered Sets are written to the shared cache, the cache items hit since
+// the last synchronization are refreshed from the shared cache, which also keeps them recent in the shared cache, and
+// the other cache items are dropped.
+type LocalCache[K IKey, V IValue] struct {
+	threadLocal *ThreadLocalCache[K, V]
+
+	list  *lruList[K, V]
+	nodes map[K]*lruListNode[K, V]
+	// dirty holds the keys of the Sets, which weren't written to the shared cache yet.
+	dirty map[K]struct{}
+
+	syncedAt time.Time
+}
+
+// Get retrieves a value by the specified key from the local cache, or from the shared cache if the key isn't cached
+// locally.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - found: A boolean indicating whether the key exists and is not expired.
+//   - err: An error if the shared cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	value, found, err := local.Get("my-key")
+func (local *LocalCache[K, V]) Get(key K) (value V, found bool, err error) {
+	if err = local.maybeSync(); err != nil {
+		return value, false, err
+	}
+
+	key = local.threadLocal.cache.normalizeKey(key)
+
+	if item, found := local.nodes[key]; found {
+		if !item.expired(timeNow()) {
+			local.list.MoveToFront(item)
+			item.hits.Add(1)
+
+			return item.Value, true, nil
+		}
+		local.remove(item)
+	}
+
+	value, ttl, found, err := local.threadLocal.cache.GetWithTTL(key)
+	if err != nil || !found {
+		return value, false, err
+	}
+
+	var expiresAt time.Time
+	if ttl != NoExpiry {
+		expiresAt = timeNow().Add(ttl)
+	}
+	if err = local.add(key, value, expiresAt); err != nil {
+		return value, true, err
+	}
+
+	return value, true, nil
+}
+
+// Set adds a key-value pair with a specific TTL (time to live) to the local cache and buffers it for the shared cache,
+// until the next synchronization. The TTL is handled as by SetWithTTL of the shared cache. A value, which is vetoed by
+// the admit hook, isn't cached, and the key is removed from the local and the shared cache immediately.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key to associate with the value.
+//   - value: The value to store in the cache.
+//   - duration: The time-to-live (TTL) for the cache entry.
+//
+// Returns:
+//   - err: An error if the shared cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	err := local.Set("my-key", []byte("my-value"), time.Minute)
+func (local *LocalCache[K, V]) Set(key K, value V, duration time.Duration) (err error) {
+	if err = local.maybeSync(); err != nil {
+		return err
+	}
+
+	key = local.threadLocal.cache.normalizeKey(key)
+	if err = checkTTL(duration); err != nil {
+		return err
+	}
+
+	cacheable, err := local.threadLocal.cache.cacheable(key, value)
+	if err != nil {
+		return err
+	}
+	if !cacheable {
+		_, err = local.Remove(key)
+		return err
+	}
+
+	if err = local.add(key, value, local.threadLocal.cache.expiresAt(key, value, duration)); err != nil {
+		return err
+	}
+	local.dirty[key] = struct{}{}
+
+	return nil
+}
+
+// Remove removes a key from the local cache and from the shared cache immediately.
+//
+// Parameters:
+//   - key: The key to remove.
+//
+// Returns:
+//   - removed: A boolean indicating whether the key was removed from the shared cache.
+//   - err: An error if the shared cache is stopped or closed, or if any other issue occurs.
+func (local *LocalCache[K, V]) Remove(key K) (removed bool, err error) {
+	key = local.threadLocal.cache.normalizeKey(key)
+	if item, found := local.nodes[key]; found {
+		local.remove(item)
+	}
+
+	return local.threadLocal.cache.Remove(key)
+}
+
+// Len returns the number of cache items in the local cache.
+//
+// Returns:
+//   - len: The number of cache items in the local cache.
+func (local *LocalCache[K, V]) Len() (len int) {
+	return local.list.Len()
+}
+
+// Sync synchronizes the local cache with the shared cache immediately. It should be called, before the worker
+// goroutine exits, so that its buffered Sets aren't lost.
+//
+// Returns:
+//   - err: An error if the shared cache is stopped or closed, or if any other issue occurs.
+func (local *LocalCache[K, V]) Sync() (err error) {
+	local.threadLocal.cache.inFlight.Add(1)
+	defer local.threadLocal.cache.inFlight.Add(-1)
+
+	switch local.threadLocal.cache.Status() {
+	case Closed:
+		return ErrClosed
+	case Stopped:
+		return errors.New("cache is stopped, must be started before calling method Sync()")
+	}
+
+	local.syncedAt = time.Now()
+
+	for key := range local.dirty {
+		item := local.nodes[key]
+		if _, _, err = local.threadLocal.cache.set(key, item.Value, item.TTL, nil); err != nil {
+			return err
+		}
+		delete(local.dirty, key)
+	}
+
+	for item := local.list.Back(); item != nil; {
+		prev := item.Prev()
+		if item.hits.Swap(0) == 0 {
+			local.remove(item)
+			item = prev
+			continue
+		}
+
+		value, ttl, found, err := local.threadLocal.cache.GetWithTTL(item.Key)
+		switch {
+		case err != nil:
+			return err
+		case !found:
+			local.remove(item)
+		default:
+			item.Value, item.TTL = value, time.Time{}
+			if ttl != NoExpiry {
+				item.TTL = local.syncedAt.Add(ttl)
+			}
+		}
+		item = prev
+	}
+
+	return nil
+}
+
+// maybeSync synchronizes the local cache with the shared cache, once the SyncInterval passed.
+func (local *LocalCache[K, V]) maybeSync() (err error) {
+	if time.Since(local.syncedAt) < local.threadLocal.syncInterval {
+		return nil
+	}
+
+	return local.Sync()
+}
+
+// add adds or updates a cache item of the local cache. If the local cache is over its capacity, the least recently
+// used cache item is dropped, after its buffered Set was written to the shared cache.
+func (local *LocalCache[K, V]) add(key K, value V, ttl time.Time) (err error) {
+	if item, found := local.nodes[key]; found {
+		item.Value, item.TTL = value, ttl
+		local.list.MoveToFront(item)
+		item.hits.Add(1)
+
+		return nil
+	}
+
+	if local.list.Len() >= local.threadLocal.localItems {
+		oldest := local.list.Back()
+		if _, dirty := local.dirty[oldest.Key]; dirty {
+			if _, _, err = local.threadLocal.cache.set(oldest.Key, oldest.Value, oldest.TTL, nil); err != nil {
+				return err
+			}
+		}
+		local.remove(oldest)
+	}
+
+	item := local.list.PushFront(&lruListNode[K, V]{Key: key, Value: value, TTL: ttl})
+	item.hits.Store(1)
+	local.nodes[key] = item
+
+	return nil
+}
+
+// remove drops a cache item from the local cache, without writing its buffered Set to the shared cache.
+func (local *LocalCache[K, V]) remove(item *lruListNode[K, V]) {
+	local.list.Remove(item)
+	delete(local.nodes, item.Key)
+	delete(local.dirty, item.Key)
+}
@@ -0,0 +1,241 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"time"
+)
+
+const (
+	// wheelBits is the number of bits of the tick, which select the slot of a level.
+	wheelBits = 6
+	// wheelSlots is the number of slots per level.
+	wheelSlots = 1 << wheelBits
+	// wheelLevels is the number of levels, the wheel spans wheelSlots^wheelLevels ticks.
+	wheelLevels = 4
+	// wheelSpan is the number of ticks spanned by the wheel, later expiries are cascaded from the top level.
+	wheelSpan = int64(1) << (wheelBits * wheelLevels)
+)
+
+// wheelSlot represents an intrusive list of the cache items, which expire in the same slot of the wheel.
+type wheelSlot[K IKey, V IValue] struct {
+	head  *lruListNode[K, V]
+	level int
+}
+
+// push inserts the cache item at the head of the slot.
+func (slot *wheelSlot[K, V]) push(item *lruListNode[K, V]) {
+	item.wheelNext = slot.head
+	item.wheelPrev = nil
+	if slot.head != nil {
+		slot.head.wheelPrev = item
+	}
+	slot.head = item
+	item.wheelSlot = slot
+}
+
+// remove unlinks the cache item from the slot.
+func (slot *wheelSlot[K, V]) remove(item *lruListNode[K, V]) {
+	if item.wheelPrev != nil {
+		item.wheelPrev.wheelNext = item.wheelNext
+	} else {
+		slot.head = item.wheelNext
+	}
+	if item.wheelNext != nil {
+		item.wheelNext.wheelPrev = item.wheelPrev
+	}
+
+	item.wheelNext = nil
+	item.wheelPrev = nil
+	item.wheelSlot = nil
+}
+
+// take unlinks and returns all cache items of the slot.
+func (slot *wheelSlot[K, V]) take() (items []*lruListNode[K, V]) {
+	for item := slot.head; item != nil; {
+		next := item.wheelNext
+		item.wheelNext = nil
+		item.wheelPrev = nil
+		item.wheelSlot = nil
+		items = append(items, item)
+		item = next
+	}
+	slot.head = nil
+
+	return items
+}
+
+// timingWheel represents a non thread-safe hierarchical timing wheel, which schedules the expiry of the cache items of a
+// shard. A slot of level l spans wheelSlots^l ticks of the resolution; the slots of the upper levels are cascaded into
+// the lower levels, once the current tick reaches them. A cache item is due, once the tick of its expiry has passed, so
+// it is expired at most one resolution after its TTL (time to live).
+type timingWheel[K IKey, V IValue] struct {
+	resolution time.Duration
+	current    int64
+	len        int64
+
+	levels    [wheelLevels][wheelSlots]wheelSlot[K, V]
+	levelLens [wheelLevels]int64
+}
+
+// newTimingWheel initializes and returns a new timingWheel instance with the specified resolution, starting at the
+// specified time.
+func newTimingWheel[K IKey, V IValue](resolution time.Duration, now time.Time) (wheel *timingWheel[K, V]) {
+	wheel = &timingWheel[K, V]{
+		resolution: resolution,
+	}
+	wheel.current = wheel.tick(now)
+
+	for level := range wheel.levels {
+		for slot := range wheel.levels[level] {
+			wheel.levels[level][slot].level = level
+		}
+	}
+
+	return wheel
+}
+
+// tick returns the tick of the specified time.
+func (wheel *timingWheel[K, V]) tick(t time.Time) (tick int64) {
+	return t.UnixNano() / int64(wheel.resolution)
+}
+
+// Schedule schedules the expiry of the cache item, replacing a previous schedule. Cache items without TTL are only
+// unscheduled.
+func (wheel *timingWheel[K, V]) Schedule(item *lruListNode[K, V]) {
+	wheel.Unschedule(item)
+
+	if item.TTL.IsZero() {
+		return
+	}
+
+	wheel.place(item)
+	wheel.len++
+}
+
+// Unschedule removes the cache item from the wheel, if it is scheduled.
+func (wheel *timingWheel[K, V]) Unschedule(item *lruListNode[K, V]) {
+	if item.wheelSlot == nil {
+		return
+	}
+
+	wheel.levelLens[item.wheelSlot.level]--
+	item.wheelSlot.remove(item)
+	wheel.len--
+}
+
+// place inserts the cache item into the slot of its expiry tick. Expiries beyond the span of the wheel are placed into
+// the last slot of the top level and placed again, once it is cascaded.
+func (wheel *timingWheel[K, V]) place(item *lruListNode[K, V]) {
+	expiry := wheel.tick(item.TTL)
+	delta := expiry - wheel.current
+
+	switch {
+	case delta < 0:
+		expiry = wheel.current
+	case delta >= wheelSpan:
+		expiry = wheel.current + wheelSpan - 1
+		delta = wheelSpan - 1
+	}
+
+	level := 0
+	for delta >= int64(1)<<(wheelBits*(level+1)) {
+		level++
+	}
+
+	wheel.levels[level][(expiry>>(wheelBits*level))&(wheelSlots-1)].push(item)
+	wheel.levelLens[level]++
+}
+
+// takeSlot unlinks and returns all cache items of the specified slot.
+func (wheel *timingWheel[K, V]) takeSlot(level int, index int64) (items []*lruListNode[K, V]) {
+	items = wheel.levels[level][index&(wheelSlots-1)].take()
+	wheel.levelLens[level] -= int64(len(items))
+
+	return items
+}
+
+// skip returns the tick following the current tick, which must be processed. While the lower levels are empty, the
+// ticks up to the next cascade of the lowest non-empty level are skipped.
+func (wheel *timingWheel[K, V]) skip() (next int64) {
+	level := 0
+	for level < wheelLevels-1 && wheel.levelLens[level] == 0 {
+		level++
+	}
+	if level == 0 {
+		return wheel.current + 1
+	}
+
+	step := int64(1) << (wheelBits * level)
+
+	return (wheel.current | (step - 1)) + 1
+}
+
+// Advance advances the wheel to the specified time and returns the cache items, which expired meanwhile. The returned
+// cache items are unscheduled, but still in the shard.
+func (wheel *timingWheel[K, V]) Advance(now time.Time) (expired []*lruListNode[K, V]) {
+	target := wheel.tick(now)
+
+	if wheel.len == 0 {
+		wheel.current = max(wheel.current, target)
+		return nil
+	}
+
+	for wheel.current < target {
+		for _, item := range wheel.takeSlot(0, wheel.current) {
+			wheel.len--
+			if wheel.tick(item.TTL) <= wheel.current {
+				expired = append(expired, item)
+			} else {
+				wheel.place(item)
+				wheel.len++
+			}
+		}
+
+		wheel.current = min(wheel.skip(), target)
+
+		for level := 1; level < wheelLevels; level++ {
+			if wheel.current&(int64(1)<<(wheelBits*level)-1) != 0 {
+				break
+			}
+			for _, item := range wheel.takeSlot(level, wheel.current>>(wheelBits*level)) {
+				wheel.place(item)
+			}
+		}
+
+		if wheel.len == 0 {
+			wheel.current = target
+		}
+	}
+
+	return expired
+}
@@ -0,0 +1,150 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"errors"
+	"time"
+)
+
+// tombstone records the removal of a key, see TombstoneTTL.
+type tombstone[K IKey] struct {
+	key       K
+	removedAt time.Time
+}
+
+// recordTombstone records the removal of the key at the specified time, if the tombstones are enabled. Must be called
+// with the lock held.
+func (shard *lruCacheShard[K, V]) recordTombstone(key K, now time.Time) {
+	if shard.tombstoneTTL <= 0 {
+		return
+	}
+
+	shard.expireTombstones(now)
+	shard.tombstones[key] = now
+	shard.tombstoneQueue = append(shard.tombstoneQueue, tombstone[K]{key: key, removedAt: now})
+}
+
+// clearTombstone drops the tombstone of the key, once the key was written again. Must be called with the lock held.
+func (shard *lruCacheShard[K, V]) clearTombstone(key K) {
+	if len(shard.tombstones) > 0 {
+		delete(shard.tombstones, key)
+	}
+}
+
+// tombstone returns the time the key was removed, if its tombstone didn't expire at the specified time yet. Safe to call
+// under the read lock of the shard.
+func (shard *lruCacheShard[K, V]) tombstone(key K, now time.Time) (removedAt time.Time, found bool) {
+	removedAt, found = shard.tombstones[key]
+	if !found || !removedAt.Add(shard.tombstoneTTL).After(now) {
+		return time.Time{}, false
+	}
+
+	return removedAt, true
+}
+
+// expireTombstones drops the tombstones expired at the specified time. As all tombstones live for the same duration,
+// the queue is ordered by their expiry, and a tombstone recorded again is only dropped by its latest queue entry. Must
+// be called with the lock held.
+func (shard *lruCacheShard[K, V]) expireTombstones(now time.Time) {
+	for len(shard.tombstoneQueue) > 0 {
+		oldest := shard.tombstoneQueue[0]
+		if oldest.removedAt.Add(shard.tombstoneTTL).After(now) {
+			return
+		}
+
+		if removedAt, found := shard.tombstones[oldest.key]; found && removedAt.Equal(oldest.removedAt) {
+			delete(shard.tombstones, oldest.key)
+		}
+		shard.tombstoneQueue[0] = tombstone[K]{}
+		shard.tombstoneQueue = shard.tombstoneQueue[1:]
+	}
+}
+
+// Tombstone reports whether the specified key was removed by Remove within the TombstoneTTL and wasn't written since,
+// e.g. for a replica to distinguish a deleted key from a key that never existed, instead of resurrecting its stale
+// value. The tombstone of a key is dropped by the next write of the key.
+//
+// Parameters:
+//   - key: The key to check.
+//
+// Returns:
+//   - removedAt: The time the key was removed, if found.
+//   - found: A boolean indicating whether the key has a tombstone.
+//   - err: An error if the tombstones are disabled, if the cache is stopped or closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	removedAt, deleted, err := cache.Tombstone("my-key")
+//	if err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Tombstone(key K) (removedAt time.Time, found bool, err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return removedAt, false, ErrClosed
+	case Stopped:
+		return removedAt, false, errors.New("cache is stopped, must be started before calling method Tombstone()")
+	}
+
+	if cache.tombstoneTTL <= 0 {
+		return removedAt, false, errors.New("cache tombstones are disabled")
+	}
+
+	key = cache.normalizeKey(key)
+
+	shard := cache.shards[cache.generateShardId(key, cache.maxShards)]
+	shard.RLockMeasured()
+	defer shard.RUnlock()
+
+	removedAt, found = shard.tombstone(key, timeNow())
+
+	return removedAt, found, nil
+}
+
+// buried reports whether the entry must not be imported, as its key was removed after the entry was created, or the
+// entry has no creation time.
+func (cache *LRUCache[K, V]) buried(key K, entry Entry[K, V], now time.Time) bool {
+	if cache.tombstoneTTL <= 0 {
+		return false
+	}
+
+	shard := cache.shards[cache.generateShardId(key, cache.maxShards)]
+	shard.RLockMeasured()
+	removedAt, found := shard.tombstone(key, now)
+	shard.RUnlock()
+
+	return found && !entry.Meta.CreatedAt.After(removedAt)
+}
@@ -0,0 +1,184 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TypedCache represents a typed view of values of type T on a LRUCache, which marshals the values by a Codec to the
+// bytes stored in the cache. Multiple typed views of different types can share a single cache, every view prefixes its
+// keys by its namespace, so the views don't see each others cache items.
+type TypedCache[K IKey, T any] struct {
+	cache     *LRUCache[K, []byte]
+	codec     Codec[T]
+	namespace K
+}
+
+// NewTypedCache creates a typed view of values of type T on the specified cache, e.g. to share one cache between the
+// types of a service instead of creating a cache per type. The keys of the view are prefixed by the namespace, which
+// must be unique for every view on the cache.
+//
+// Parameters:
+//   - cache: The cache backing the view.
+//   - codec: The codec to marshal the values with.
+//   - namespace: The prefix of the keys of the view, empty to use the keys as they are.
+//
+// Returns:
+//   - view: The created TypedCache object.
+//
+// Example Usage:
+//
+//	users := sq_cache.NewTypedCache(cache, sq_cache.JSONCodec[User]{}, "user:")
+//	orders := sq_cache.NewTypedCache(cache, sq_cache.GobCodec[Order]{}, "order:")
+//
+//	err := users.Set("42", User{Name: "Gopher"})
+//	user, found, err := users.Get("42")
+func NewTypedCache[K IKey, T any](cache *LRUCache[K, []byte], codec Codec[T], namespace K) (view *TypedCache[K, T]) {
+	return &TypedCache[K, T]{
+		cache:     cache,
+		codec:     codec,
+		namespace: namespace,
+	}
+}
+
+// CacheOf initializes and returns a new LRUCache with user-configured settings, which stores values of type T marshaled
+// by the specified codec. Further typed views on the cache are created by NewTypedCache with the cache returned by
+// Cache.
+//
+// Parameters:
+//   - ctx: The context to manage the lifecycle of the cache.
+//   - userConfig: A user-defined configuration for customizing the cache's behavior.
+//   - codec: The codec to marshal the values with.
+//
+// Returns:
+//   - cache: The created TypedCache object.
+//   - err: An error, if any occurs during initialization.
+//
+// Example Usage:
+//
+//	users, err := sq_cache.CacheOf(ctx, &sq_cache.Config[string, []byte]{
+//	    MaxItems: 10000,
+//	}, sq_cache.JSONCodec[User]{})
+//
+//	err = users.Set("user:42", User{Name: "Gopher"})
+//	user, found, err := users.Get("user:42")
+func CacheOf[T any](
+	ctx context.Context, userConfig *Config[string, []byte], codec Codec[T],
+) (cache *TypedCache[string, T], err error) {
+	lruCache, err := NewLRUCache(ctx, userConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTypedCache(lruCache, codec, ""), nil
+}
+
+// Cache returns the underlying LRUCache, e.g. to read its telemetry, to create further views on it or to close it.
+func (view *TypedCache[K, T]) Cache() (cache *LRUCache[K, []byte]) {
+	return view.cache
+}
+
+// Namespace returns the prefix of the keys of the view.
+func (view *TypedCache[K, T]) Namespace() (namespace K) {
+	return view.namespace
+}
+
+// Get retrieves and unmarshals a value by the specified key from the cache.
+// This operation does updates the recent-ness of the cache item.
+//
+// Parameters:
+//   - key: The key associated with the value to retrieve, without the namespace.
+//
+// Returns:
+//   - value: The value associated with the key if found.
+//   - found: A boolean indicating whether the key was found.
+//   - err: An error if the cache is stopped or closed, if the value can't be unmarshaled, or if any other issue occurs.
+func (view *TypedCache[K, T]) Get(key K) (value T, found bool, err error) {
+	data, err := view.cache.Get(view.namespace + key)
+	if err != nil || data == nil {
+		return value, false, err
+	}
+
+	value, err = view.codec.Unmarshal(data)
+	if err != nil {
+		return value, false, fmt.Errorf("%s: unmarshal %q: %w", LibraryName, view.namespace+key, err)
+	}
+
+	return value, true, nil
+}
+
+// Set marshals and adds a value to the cache with the default TTL (time to live), if any.
+//
+// Parameters:
+//   - key: The key under which the value will be stored, without the namespace.
+//   - value: The value to store in the cache.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, if the value can't be marshaled, or if any other issue occurs.
+func (view *TypedCache[K, T]) Set(key K, value T) (err error) {
+	return view.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL marshals and adds a value to the cache with the specified TTL (time to live).
+//
+// Parameters:
+//   - key: The key under which the value will be stored, without the namespace.
+//   - value: The value to store in the cache.
+//   - duration: The TTL (time to live) of the cache item, zero falls back to the default TTL of the cache.
+//
+// Returns:
+//   - err: An error if the cache is stopped or closed, if the value can't be marshaled, or if any other issue occurs.
+func (view *TypedCache[K, T]) SetWithTTL(key K, value T, duration time.Duration) (err error) {
+	data, err := view.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%s: marshal %q: %w", LibraryName, view.namespace+key, err)
+	}
+
+	_, err = view.cache.SetWithTTL(view.namespace+key, data, duration)
+
+	return err
+}
+
+// Remove removes a cache item by the specified key from the cache.
+//
+// Parameters:
+//   - key: The key of the cache item to remove, without the namespace.
+//
+// Returns:
+//   - removed: A boolean indicating whether the cache item was removed.
+//   - err: An error if the cache is stopped or closed, or if any other issue occurs.
+func (view *TypedCache[K, T]) Remove(key K) (removed bool, err error) {
+	return view.cache.Remove(view.namespace + key)
+}
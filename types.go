@@ -37,6 +37,239 @@ type IKey interface {
 }
 
 // IValue is an interface that defines the value type.
+// Named byte slice types are allowed, so that values can implement the Closer interface.
 type IValue interface {
-	[]byte
+	~[]byte
+}
+
+// Closer is an interface that can be implemented by value types that hold resources (e.g. prepared statements, decoded
+// images). The Close method is called automatically when the cache item is replaced, evicted, expired, deleted or
+// purged.
+type Closer interface {
+	Close() error
+}
+
+// RemovalReason defines the reason why a cache item was removed from the cache.
+type RemovalReason int
+
+// RemovalReason constants
+const (
+	Replaced RemovalReason = iota
+	Evicted
+	Expired
+	Deleted
+	Purged
+	Corrupted
+)
+
+// String returns the name of the removal reason.
+func (reason RemovalReason) String() string {
+	switch reason {
+	case Replaced:
+		return "replaced"
+	case Evicted:
+		return "evicted"
+	case Expired:
+		return "expired"
+	case Deleted:
+		return "deleted"
+	case Purged:
+		return "purged"
+	case Corrupted:
+		return "corrupted"
+	default:
+		return "unknown"
+	}
+}
+
+// AuditOp defines the mutating operation of an AuditEvent.
+type AuditOp int
+
+// AuditOp constants
+const (
+	AuditSet AuditOp = iota
+	AuditRemove
+	AuditPurge
+)
+
+// String returns the name of the audited operation.
+func (op AuditOp) String() string {
+	switch op {
+	case AuditSet:
+		return "set"
+	case AuditRemove:
+		return "remove"
+	case AuditPurge:
+		return "purge"
+	default:
+		return "unknown"
+	}
+}
+
+// AuditOrigin defines where a mutating operation of an AuditEvent came from.
+type AuditOrigin int
+
+// AuditOrigin constants
+const (
+	LocalOrigin AuditOrigin = iota
+	AdminOrigin
+	PeerOrigin
+	RestoreOrigin
+)
+
+// String returns the name of the origin.
+func (origin AuditOrigin) String() string {
+	switch origin {
+	case LocalOrigin:
+		return "local"
+	case AdminOrigin:
+		return "admin"
+	case PeerOrigin:
+		return "peer"
+	case RestoreOrigin:
+		return "restore"
+	default:
+		return "unknown"
+	}
+}
+
+// EntrySource defines which path populated a cache item.
+type EntrySource int
+
+// EntrySource constants
+const (
+	SetSource EntrySource = iota
+	LoaderSource
+	PeerSource
+	PromotionSource
+	RestoreSource
+)
+
+// String returns the name of the source.
+func (source EntrySource) String() string {
+	switch source {
+	case SetSource:
+		return "set"
+	case LoaderSource:
+		return "loader"
+	case PeerSource:
+		return "peer"
+	case PromotionSource:
+		return "promotion"
+	case RestoreSource:
+		return "restore"
+	default:
+		return "unknown"
+	}
+}
+
+// ShardStrategy defines how the keys are mapped to the shards.
+type ShardStrategy int
+
+// ShardStrategy constants
+const (
+	ModuloSharding ShardStrategy = iota
+	ConsistentHashSharding
+)
+
+// String returns the name of the shard strategy.
+func (strategy ShardStrategy) String() string {
+	switch strategy {
+	case ModuloSharding:
+		return "modulo"
+	case ConsistentHashSharding:
+		return "consistent-hash"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictionPolicy defines how the shards select the cache items to evict.
+type EvictionPolicy int
+
+// EvictionPolicy constants
+const (
+	LRUEviction EvictionPolicy = iota
+	ClockEviction
+	SampledEviction
+	FIFOEviction
+	NoEviction
+	TTLEviction
+	CostEviction
+	FairEviction
+)
+
+// String returns the name of the eviction policy.
+func (policy EvictionPolicy) String() string {
+	switch policy {
+	case LRUEviction:
+		return "lru"
+	case ClockEviction:
+		return "clock"
+	case SampledEviction:
+		return "sampled"
+	case FIFOEviction:
+		return "fifo"
+	case NoEviction:
+		return "none"
+	case TTLEviction:
+		return "ttl"
+	case CostEviction:
+		return "cost"
+	case FairEviction:
+		return "fair"
+	default:
+		return "unknown"
+	}
+}
+
+// ShardIndexType defines the implementation of the key index of the shards.
+type ShardIndexType int
+
+// ShardIndexType constants
+const (
+	MapIndex ShardIndexType = iota
+	SyncMapIndex
+	OpenAddressingIndex
+)
+
+// String returns the name of the shard index type.
+func (indexType ShardIndexType) String() string {
+	switch indexType {
+	case MapIndex:
+		return "map"
+	case SyncMapIndex:
+		return "sync-map"
+	case OpenAddressingIndex:
+		return "open-addressing"
+	default:
+		return "unknown"
+	}
+}
+
+// ResultStatus defines the outcome of the lookup of a key.
+type ResultStatus int
+
+// ResultStatus constants
+const (
+	ResultMiss ResultStatus = iota
+	ResultHit
+	ResultExpired
+	ResultCorrupted
+)
+
+// String returns the name of the result status.
+func (status ResultStatus) String() string {
+	switch status {
+	case ResultMiss:
+		return "miss"
+	case ResultHit:
+		return "hit"
+	case ResultExpired:
+		return "expired"
+	case ResultCorrupted:
+		return "corrupted"
+	default:
+		return "unknown"
+	}
 }
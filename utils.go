@@ -34,39 +34,77 @@ package sq_cache
 import (
 	"crypto/sha1"
 	"encoding/binary"
+	"encoding/hex"
 	"log"
+	"slices"
 )
 
 // generateKey generates a hash key from a specified value.
 func generateKey[K IKey, V IValue](value V) (key K) {
 	h := sha1.New()
 
-	switch v := any(value).(type) {
-	case []byte:
-		h.Write(v)
-		k := h.Sum(nil)
-		h.Reset()
-		return any(k).(K)
-	default:
-		panic("IValue type is not supported")
+	h.Write([]byte(value))
+	k := h.Sum(nil)
+	h.Reset()
+
+	return K(hex.EncodeToString(k))
+}
+
+// closeValue calls the Close method of a value, if the value implements the Closer interface.
+func closeValue[V IValue](loggingOn bool, value V) {
+	closer, ok := any(value).(Closer)
+	if !ok {
+		return
+	}
+
+	if err := closer.Close(); err != nil && loggingOn {
+		log.Printf(
+			"%s: closeValue - %v", LibraryName, err,
+		)
 	}
 }
 
 // generateShardId generates a shardId based on the specified key and the maximum number of shards.
-func generateShardId[K IKey](key K, maxItems int64) (shardId int64) {
+func generateShardId[K IKey](key K, maxShards int64) (shardId int64) {
 	h := sha1.New()
 
-	switch k := any(key).(type) {
-	case string:
-		h.Write([]byte(k))
-		v := h.Sum(nil)
-		h.Reset()
-		return int64(binary.BigEndian.Uint64(v) % uint64(maxItems))
-	default:
-		panic("IKey type is not supported")
+	h.Write([]byte(key))
+	v := h.Sum(nil)
+	h.Reset()
+
+	return int64(binary.BigEndian.Uint64(v) % uint64(maxShards))
+}
+
+// normalizedGenerateKey wraps a key generation function, so that the generated keys are normalized as the specified keys.
+func normalizedGenerateKey[K IKey, V IValue](generateKey func(value V) K, normalizeKey func(key K) K) func(value V) K {
+	return func(value V) (key K) {
+		return normalizeKey(generateKey(value))
+	}
+}
+
+// boundShardId wraps a custom shardId generation function, so that shardIds out of range are mapped into the range
+// instead of indexing the shards out of range.
+func boundShardId[K IKey](generateShardId func(key K, maxShards int64) int64) func(key K, maxShards int64) int64 {
+	return func(key K, maxShards int64) (shardId int64) {
+		shardId = generateShardId(key, maxShards)
+		if shardId >= 0 && shardId < maxShards {
+			return shardId
+		}
+
+		shardId %= maxShards
+		if shardId < 0 {
+			shardId += maxShards
+		}
+
+		return shardId
 	}
 }
 
+// cloneValue copies the bytes of the specified value.
+func cloneValue[V IValue](value V) V {
+	return slices.Clone(value)
+}
+
 // onAdd is a callback function that gets triggered when a cache item is added.
 func onAdd[K IKey, V IValue](loggingOn bool, node *lruListNode[K, V]) {
 	if loggingOn {
@@ -112,3 +150,21 @@ func onEvict[K IKey, V IValue](loggingOn bool, node *lruListNode[K, V]) {
 		)
 	}
 }
+
+// onShardPurge is a callback function that gets triggered when a shard was purged or trimmed.
+func onShardPurge(loggingOn bool, shardId int64, removed int64) {
+	if loggingOn {
+		log.Printf(
+			"%s: onShardPurge callback - shard - %d - removed - %d", LibraryName, shardId, removed,
+		)
+	}
+}
+
+// onRemove is a callback function that gets triggered when a cache item gets removed, for whatever reason.
+func onRemove[K IKey, V IValue](loggingOn bool, key K, value V, reason RemovalReason) {
+	if loggingOn {
+		log.Printf(
+			"%s: onRemove callback - key - %+v - reason - %s", LibraryName, key, reason,
+		)
+	}
+}
@@ -0,0 +1,94 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func FuzzGenerateKey(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("my-value"))
+	f.Add([]byte{0x00, 0xff, 0xfe})
+
+	f.Fuzz(func(t *testing.T, value []byte) {
+		key := generateKey[string, []byte](value)
+
+		if _, err := hex.DecodeString(key); err != nil || len(key) != 40 {
+			t.Fatalf("generateKey(%q) = %q, want 40 hex digits", value, key)
+		}
+		if again := generateKey[string, []byte](value); again != key {
+			t.Fatalf("generateKey(%q) = %q and %q, want the same key", value, key, again)
+		}
+	})
+}
+
+func FuzzGenerateShardId(f *testing.F) {
+	f.Add("", int64(1))
+	f.Add("my-key", int64(256))
+	f.Add("\x00\xff", int64(7))
+
+	f.Fuzz(func(t *testing.T, key string, maxShards int64) {
+		if maxShards < 1 {
+			t.Skip()
+		}
+
+		shardId := generateShardId(key, maxShards)
+		if shardId < 0 || shardId >= maxShards {
+			t.Fatalf("generateShardId(%q, %d) = %d, want a shardId in range", key, maxShards, shardId)
+		}
+		if again := generateShardId(key, maxShards); again != shardId {
+			t.Fatalf("generateShardId(%q, %d) = %d and %d, want the same shardId", key, maxShards, shardId, again)
+		}
+	})
+}
+
+func FuzzBoundShardId(f *testing.F) {
+	f.Add(int64(0), int64(1))
+	f.Add(int64(-1), int64(256))
+	f.Add(int64(-9223372036854775808), int64(3))
+	f.Add(int64(9223372036854775807), int64(16))
+
+	f.Fuzz(func(t *testing.T, custom int64, maxShards int64) {
+		if maxShards < 1 {
+			t.Skip()
+		}
+
+		bound := boundShardId(func(key string, maxShards int64) int64 {
+			return custom
+		})
+		if shardId := bound("key", maxShards); shardId < 0 || shardId >= maxShards {
+			t.Fatalf("boundShardId() of %d with %d shards = %d, want a shardId in range", custom, maxShards, shardId)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// victimEntry represents a cache item in the victimCache, with the shard it was evicted from.
+type victimEntry[K IKey, V IValue] struct {
+	node  *lruListNode[K, V]
+	shard *lruCacheShard[K, V]
+}
+
+// victimCache represents a thread-safe LRU of the cache items recently evicted from the shards by capacity pressure,
+// shared by all shards of the cache. A miss consults it before the loader, and moves a found cache item back into its
+// shard. The entries of a key are only added, taken and discarded with the lock of the shard of the key held, so they
+// are serialized with the writes of the key.
+type victimCache[K IKey, V IValue] struct {
+	sync.Mutex
+
+	// ratio is the capacity of the victim cache as fraction of the item capacity of the cache (maxItems).
+	ratio    float64
+	maxItems *atomic.Int64
+
+	list    *lruList[K, V]
+	entries map[K]victimEntry[K, V]
+}
+
+// newVictimCache initializes and returns a new victimCache, whose capacity is the specified fraction of the item
+// capacity of the cache.
+func newVictimCache[K IKey, V IValue](ratio float64, maxItems *atomic.Int64) (victims *victimCache[K, V]) {
+	return &victimCache[K, V]{
+		ratio:    ratio,
+		maxItems: maxItems,
+
+		list:    newLRUList[K, V](),
+		entries: make(map[K]victimEntry[K, V]),
+	}
+}
+
+// capacity returns the maximum number of cache items of the victim cache, at least one.
+func (victims *victimCache[K, V]) capacity() (capacity int) {
+	return max(int(victims.ratio*float64(victims.maxItems.Load())), 1)
+}
+
+// add adds a copy of the cache item evicted from the shard, and drops the least recently evicted cache items beyond
+// the capacity. Values implementing the Closer interface are not added, as they are closed on the eviction.
+func (victims *victimCache[K, V]) add(shard *lruCacheShard[K, V], item *lruListNode[K, V]) {
+	if _, ok := any(item.Value).(Closer); ok {
+		return
+	}
+
+	node := newLRUListNode[K, V]()
+	node.Key, node.Value, node.TTL, node.Meta, node.source = item.Key, item.Value, item.TTL, item.Meta, item.source
+
+	victims.Lock()
+	defer victims.Unlock()
+
+	if entry, found := victims.entries[item.Key]; found {
+		victims.list.Remove(entry.node)
+	}
+	victims.entries[item.Key] = victimEntry[K, V]{node: node, shard: shard}
+	victims.list.PushFront(node)
+
+	for capacity := victims.capacity(); victims.list.Len() > capacity; {
+		oldest := victims.list.Back()
+		victims.list.Remove(oldest)
+		delete(victims.entries, oldest.Key)
+	}
+}
+
+// take removes the cache item of the key from the victim cache and returns it, unless it is expired at the specified
+// time.
+func (victims *victimCache[K, V]) take(key K, now time.Time) (node *lruListNode[K, V], found bool) {
+	victims.Lock()
+	defer victims.Unlock()
+
+	entry, found := victims.entries[key]
+	if !found {
+		return nil, false
+	}
+	victims.list.Remove(entry.node)
+	delete(victims.entries, key)
+
+	if entry.node.expired(now) {
+		return nil, false
+	}
+
+	return entry.node, true
+}
+
+// discard drops the cache item of the key, e.g. when the key is written or removed.
+func (victims *victimCache[K, V]) discard(key K) {
+	victims.Lock()
+	defer victims.Unlock()
+
+	if entry, found := victims.entries[key]; found {
+		victims.list.Remove(entry.node)
+		delete(victims.entries, key)
+	}
+}
+
+// discardShard drops the cache items evicted from the shard, which match the specified function, or all of them if
+// it is nil, e.g. when the shard is purged.
+func (victims *victimCache[K, V]) discardShard(shard *lruCacheShard[K, V], match func(key K) bool) {
+	victims.Lock()
+	defer victims.Unlock()
+
+	for key, entry := range victims.entries {
+		if entry.shard == shard && (match == nil || match(key)) {
+			victims.list.Remove(entry.node)
+			delete(victims.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cache items in the victim cache.
+func (victims *victimCache[K, V]) Len() (len int) {
+	victims.Lock()
+	defer victims.Unlock()
+
+	return victims.list.Len()
+}
+
+// rescue moves the cache item of the key from the victim cache back into its shard, and reports whether it was found.
+// The rescued cache item keeps its TTL (time to live), metadata and source, and is counted by the VictimHit counter.
+func (cache *LRUCache[K, V]) rescue(shardId int64, key K) (value V, ttl time.Time, found bool) {
+	shard := cache.shards[shardId]
+
+	shard.LockMeasured()
+	if cache.writeBuffers != nil {
+		cache.applyWrites(shardId)
+	}
+	node, found := cache.victims.take(key, timeNow())
+	if !found {
+		shard.Unlock()
+		return value, ttl, false
+	}
+
+	shard.opSource = node.source
+	setEvictCount, added := shard.Set(cache.len.Load(), key, node.Value, node.TTL, node.Meta)
+	shard.opSource = SetSource
+	cache.walSet(key, node.Value, node.TTL, node.Meta)
+	if added {
+		cache.len.Add(1)
+	}
+	cache.len.Add(-setEvictCount)
+	shard.Unlock()
+
+	if cache.telemetryOn {
+		cache.telemetry.incrementCounter(VictimHit)
+	}
+
+	return node.Value, node.TTL, true
+}
@@ -0,0 +1,633 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// walMagic identifies the write-ahead log segments, walVersion is the version of their format, see format.go. The
+// segments of version 1 don't have a codec id.
+const (
+	walMagic   = "SQW"
+	walVersion = 2
+)
+
+// walSuffix is the suffix of the write-ahead log segment files.
+const walSuffix = ".wal"
+
+// walOp defines the operations recorded by the write-ahead log.
+type walOp byte
+
+// walOp constants
+const (
+	walSet walOp = iota + 1
+	walRemove
+	walPurge
+)
+
+// WALPolicy configures the write-ahead log of a cache, which records the Sets, Removes and Purges, so that the cache
+// items can be recovered after a crash.
+type WALPolicy struct {
+	// Dir is the directory of the log segments.
+	Dir string
+	// SyncInterval is the interval, in which the log is flushed and synced to the disk. The writes of the last interval
+	// may be lost on a crash. Defaults to a second.
+	SyncInterval time.Duration
+	// CompactInterval is the interval of the compaction, which rewrites the log from the cache items. Zero disables the
+	// periodic compaction.
+	CompactInterval time.Duration
+}
+
+// syncInterval returns the SyncInterval of the policy, or its default.
+func (policy WALPolicy) syncInterval() (interval time.Duration) {
+	if policy.SyncInterval <= 0 {
+		return time.Second
+	}
+
+	return policy.SyncInterval
+}
+
+// walRecord represents a decoded record of the write-ahead log.
+type walRecord[K IKey, V IValue] struct {
+	op    walOp
+	key   K
+	value V
+	ttl   time.Time
+	meta  *EntryMeta
+}
+
+// writeAheadLog represents the write-ahead log of a cache. The records are appended to the current segment, which is
+// rotated by the compaction.
+type writeAheadLog struct {
+	sync.Mutex
+
+	// compactMutex serializes the compactions.
+	compactMutex sync.Mutex
+
+	dir     string
+	segment uint64
+	file    *os.File
+	w       *bufio.Writer
+	buf     []byte
+
+	// err is the first error writing the log, further records are dropped and counted by dropped.
+	err     error
+	dropped atomic.Int64
+}
+
+// walSegmentName returns the file name of the specified segment.
+func walSegmentName(segment uint64) (name string) {
+	return fmt.Sprintf("%020d%s", segment, walSuffix)
+}
+
+// walSegments returns the segments in the directory, sorted in the order they must be replayed.
+func walSegments(dir string) (segments []uint64, err error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), walSuffix)
+		if !found || entry.IsDir() {
+			continue
+		}
+		segment, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	slices.Sort(segments)
+
+	return segments, nil
+}
+
+// createWALSegment creates the file of a segment and writes the segment header.
+func createWALSegment(path string) (file *os.File, w *bufio.Writer, err error) {
+	file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w = bufio.NewWriter(file)
+	if _, err = w.Write(appendFormatHeader(nil, walMagic, walVersion)); err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	return file, w, nil
+}
+
+// openWAL opens a new segment after the specified segment in the directory and returns the log.
+func openWAL(dir string, segment uint64) (wal *writeAheadLog, err error) {
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, w, err := createWALSegment(filepath.Join(dir, walSegmentName(segment)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeAheadLog{
+		dir:     dir,
+		segment: segment,
+		file:    file,
+		w:       w,
+	}, nil
+}
+
+// appendRecord encodes a record to the specified buffer, framed by its length and its CRC-32 checksum.
+func appendRecord[K IKey, V IValue](buf []byte, op walOp, key K, value V, ttl time.Time, meta *EntryMeta) []byte {
+	payload := []byte{byte(op)}
+	payload = binary.AppendUvarint(payload, uint64(len(key)))
+	payload = append(payload, key...)
+
+	if op == walSet {
+		payload = binary.AppendUvarint(payload, uint64(len(value)))
+		payload = append(payload, value...)
+		payload = binary.AppendVarint(payload, unixNano(ttl))
+
+		if meta == nil {
+			payload = append(payload, 0)
+		} else {
+			payload = append(payload, 1)
+			payload = binary.AppendVarint(payload, unixNano(meta.CreatedAt))
+			payload = binary.AppendUvarint(payload, uint64(len(meta.Source)))
+			payload = append(payload, meta.Source...)
+			payload = binary.AppendUvarint(payload, uint64(len(meta.Tags)))
+			for tag, tagValue := range meta.Tags {
+				payload = binary.AppendUvarint(payload, uint64(len(tag)))
+				payload = append(payload, tag...)
+				payload = binary.AppendUvarint(payload, uint64(len(tagValue)))
+				payload = append(payload, tagValue...)
+			}
+			payload = binary.AppendVarint(payload, meta.Cost)
+		}
+	}
+
+	return appendFrame(buf, payload)
+}
+
+// unixNano returns the Unix time of the specified time in nanoseconds, zero for the zero time.
+func unixNano(t time.Time) (nanos int64) {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
+
+// fromUnixNano returns the time of the specified Unix time in nanoseconds, the zero time for zero.
+func fromUnixNano(nanos int64) (t time.Time) {
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
+// walDecoder decodes the payload of a record.
+type walDecoder struct {
+	payload []byte
+	err     error
+}
+
+// readUvarint decodes an unsigned integer.
+func (decoder *walDecoder) readUvarint() (value uint64) {
+	value, n := binary.Uvarint(decoder.payload)
+	if n <= 0 {
+		decoder.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	decoder.payload = decoder.payload[n:]
+
+	return value
+}
+
+// readVarint decodes a signed integer.
+func (decoder *walDecoder) readVarint() (value int64) {
+	value, n := binary.Varint(decoder.payload)
+	if n <= 0 {
+		decoder.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	decoder.payload = decoder.payload[n:]
+
+	return value
+}
+
+// readBytes decodes a length-prefixed byte slice.
+func (decoder *walDecoder) readBytes() (value []byte) {
+	length := decoder.readUvarint()
+	if decoder.err != nil || length > uint64(len(decoder.payload)) {
+		decoder.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	value, decoder.payload = decoder.payload[:length:length], decoder.payload[length:]
+
+	return value
+}
+
+// readByte decodes a single byte.
+func (decoder *walDecoder) readByte() (value byte) {
+	if len(decoder.payload) == 0 {
+		decoder.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	value, decoder.payload = decoder.payload[0], decoder.payload[1:]
+
+	return value
+}
+
+// readRecord reads the next record of a segment. It returns io.EOF at the end of the segment, and an error if the
+// record is truncated or corrupted, e.g. by a crash while it was written.
+func readRecord[K IKey, V IValue](r *bufio.Reader) (record walRecord[K, V], err error) {
+	payload, err := readFrame(r)
+	if err != nil {
+		return record, err
+	}
+
+	return decodeRecord[K, V](payload)
+}
+
+// decodeRecord decodes the payload of a record frame.
+func decodeRecord[K IKey, V IValue](payload []byte) (record walRecord[K, V], err error) {
+	decoder := &walDecoder{payload: payload}
+	record.op = walOp(decoder.readByte())
+	record.key = K(decoder.readBytes())
+
+	if record.op == walSet {
+		record.value = V(decoder.readBytes())
+		record.ttl = fromUnixNano(decoder.readVarint())
+
+		if decoder.readByte() == 1 {
+			record.meta = &EntryMeta{
+				CreatedAt: fromUnixNano(decoder.readVarint()),
+				Source:    string(decoder.readBytes()),
+			}
+			if tags := decoder.readUvarint(); tags > 0 && decoder.err == nil {
+				record.meta.Tags = make(map[string]string, min(tags, uint64(len(decoder.payload))))
+				for range tags {
+					if decoder.err != nil {
+						break
+					}
+					tag := string(decoder.readBytes())
+					record.meta.Tags[tag] = string(decoder.readBytes())
+				}
+			}
+			// The cost was appended to the metadata later, the records of older segments end before it.
+			if len(decoder.payload) > 0 {
+				record.meta.Cost = decoder.readVarint()
+			}
+		}
+	}
+
+	return record, decoder.err
+}
+
+// append appends a record to the current segment. Once writing the log failed, the records are dropped. The error is
+// only returned for the record, which failed first, so that the failure is reported once.
+func (wal *writeAheadLog) append(encode func(buf []byte) []byte) (err error) {
+	wal.Lock()
+	defer wal.Unlock()
+
+	if wal.err != nil {
+		wal.dropped.Add(1)
+		return nil
+	}
+
+	wal.buf = encode(wal.buf[:0])
+	if _, wal.err = wal.w.Write(wal.buf); wal.err != nil {
+		wal.dropped.Add(1)
+	}
+
+	return wal.err
+}
+
+// sync flushes the current segment and syncs it to the disk.
+func (wal *writeAheadLog) sync() (err error) {
+	wal.Lock()
+	defer wal.Unlock()
+
+	return wal.syncLocked()
+}
+
+// syncLocked flushes the current segment and syncs it to the disk. Must be called with the lock held.
+func (wal *writeAheadLog) syncLocked() (err error) {
+	if wal.err != nil {
+		return wal.err
+	}
+	if err = wal.w.Flush(); err != nil {
+		wal.err = err
+		return err
+	}
+
+	return wal.file.Sync()
+}
+
+// rotate closes the current segment and opens the segment after the next one, the next segment is left for the
+// compaction. It returns the closed segment.
+func (wal *writeAheadLog) rotate() (closed uint64, err error) {
+	wal.Lock()
+	defer wal.Unlock()
+
+	if err = wal.syncLocked(); err != nil {
+		return 0, err
+	}
+	if err = wal.file.Close(); err != nil {
+		return 0, err
+	}
+
+	closed = wal.segment
+	wal.segment += 2
+	wal.file, wal.w, wal.err = createWALSegment(filepath.Join(wal.dir, walSegmentName(wal.segment)))
+
+	return closed, wal.err
+}
+
+// close flushes, syncs and closes the current segment.
+func (wal *writeAheadLog) close() (err error) {
+	wal.Lock()
+	defer wal.Unlock()
+
+	err = wal.syncLocked()
+	if closeErr := wal.file.Close(); err == nil {
+		err = closeErr
+	}
+	wal.err = errors.New("write-ahead log is closed")
+
+	return err
+}
+
+// walSet records a Set of the cache. Must be called with the lock of the shard held, so that the records of a key are
+// appended in the order of the operations.
+func (cache *LRUCache[K, V]) walSet(key K, value V, ttl time.Time, meta *EntryMeta) {
+	if cache.wal == nil {
+		return
+	}
+
+	cache.appendWAL(func(buf []byte) []byte {
+		return appendRecord(buf, walSet, key, value, ttl, meta)
+	})
+}
+
+// walRemove records a Remove of the cache. Must be called with the lock of the shard held.
+func (cache *LRUCache[K, V]) walRemove(key K) {
+	if cache.wal == nil {
+		return
+	}
+
+	cache.appendWAL(func(buf []byte) []byte {
+		return appendRecord(buf, walRemove, key, *new(V), time.Time{}, nil)
+	})
+}
+
+// walPurge records a Purge of the cache.
+func (cache *LRUCache[K, V]) walPurge() {
+	if cache.wal == nil {
+		return
+	}
+
+	cache.appendWAL(func(buf []byte) []byte {
+		return appendRecord(buf, walPurge, *new(K), *new(V), time.Time{}, nil)
+	})
+}
+
+// appendWAL appends a record to the write-ahead log and logs the first failure, after which the records are dropped
+// and counted by the WALDropped counter of the telemetry.
+func (cache *LRUCache[K, V]) appendWAL(encode func(buf []byte) []byte) {
+	if err := cache.wal.append(encode); err != nil && cache.loggingOn {
+		log.Printf("%s: appendWAL - %v, further records are dropped", LibraryName, err)
+	}
+}
+
+// replayWAL replays the segments of the write-ahead log in the directory and returns the last segment. A truncated or
+// corrupted record ends the replay of its segment, as it was written last before a crash.
+func (cache *LRUCache[K, V]) replayWAL(dir string) (count int64, last uint64, err error) {
+	segments, err := walSegments(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := timeNow()
+	for _, segment := range segments {
+		last = segment
+
+		n, err := cache.replayWALSegment(filepath.Join(dir, walSegmentName(segment)), now)
+		count += n
+		if err != nil && cache.loggingOn {
+			log.Printf("%s: replayWAL - segment %d - %v", LibraryName, segment, err)
+		}
+	}
+
+	return count, last, nil
+}
+
+// replayWALSegment replays the records of a segment of the write-ahead log.
+func (cache *LRUCache[K, V]) replayWALSegment(path string, now time.Time) (count int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	if _, err = readFormatHeader(r, walMagic, walVersion, 2); err != nil {
+		return 0, err
+	}
+
+	ctx := WithOrigin(context.Background(), RestoreOrigin)
+	for {
+		record, err := readRecord[K, V](r)
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		switch record.op {
+		case walSet:
+			if !record.ttl.IsZero() && record.ttl.Before(now) {
+				continue
+			}
+			if _, _, err = cache.setContext(ctx, record.key, record.value, record.ttl, record.meta); err != nil {
+				continue
+			}
+		case walRemove:
+			_, _ = cache.RemoveContext(ctx, record.key)
+		case walPurge:
+			_, _, _ = cache.purgeShards(ctx)
+		}
+		count++
+	}
+}
+
+// CompactWAL rewrites the write-ahead log from the cache items, so that the superseded records are dropped. The log is
+// rotated first, so the writes during the compaction are appended to the new segment and replayed after the compacted
+// segment.
+//
+// Returns:
+//   - err: An error if no write-ahead log is configured, if the cache is closed, if the log can't be written, or if any
+//     other issue occurs.
+//
+// Example Usage:
+//
+//	if err := cache.CompactWAL(); err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) CompactWAL() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	}
+
+	if cache.wal == nil {
+		return errors.New("cache write-ahead log is not configured")
+	}
+
+	wal := cache.wal
+	wal.compactMutex.Lock()
+	defer wal.compactMutex.Unlock()
+
+	_ = cache.Flush()
+
+	closed, err := wal.rotate()
+	if err != nil {
+		return err
+	}
+
+	compacted := filepath.Join(wal.dir, walSegmentName(closed+1))
+	file, w, err := createWALSegment(compacted + ".tmp")
+	if err != nil {
+		return err
+	}
+
+	buf := appendRecord(nil, walPurge, *new(K), *new(V), time.Time{}, nil)
+	for _, shard := range cache.shards {
+		for _, entry := range cache.exportShard(shard) {
+			var meta *EntryMeta
+			if !entry.Meta.isZero() {
+				meta = &entry.Meta
+			}
+			buf = appendRecord(buf, walSet, entry.Key, entry.Value, entry.TTL, meta)
+		}
+		if _, err = w.Write(buf); err != nil {
+			break
+		}
+		buf = buf[:0]
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(compacted+".tmp", compacted)
+	}
+	if err != nil {
+		_ = os.Remove(compacted + ".tmp")
+		return err
+	}
+
+	segments, err := walSegments(wal.dir)
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if segment <= closed {
+			if err = os.Remove(filepath.Join(wal.dir, walSegmentName(segment))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walTicker syncs the write-ahead log every sync interval and compacts it every compaction interval, until the context
+// is done.
+func (cache *LRUCache[K, V]) walTicker(ctx context.Context, done chan struct{}, syncInterval, compactInterval time.Duration) {
+	defer close(done)
+
+	syncTicker := time.NewTicker(syncInterval)
+	defer syncTicker.Stop()
+
+	var compactTick <-chan time.Time
+	if compactInterval > 0 {
+		compactTicker := time.NewTicker(compactInterval)
+		defer compactTicker.Stop()
+		compactTick = compactTicker.C
+	}
+
+	for {
+		select {
+		case <-syncTicker.C:
+			if err := cache.wal.sync(); err != nil && cache.loggingOn {
+				log.Printf("%s: syncWAL - %v", LibraryName, err)
+			}
+		case <-compactTick:
+			if err := cache.CompactWAL(); err != nil && cache.loggingOn {
+				log.Printf("%s: compactWAL - %v", LibraryName, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
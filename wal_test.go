@@ -0,0 +1,238 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newWALTestCache creates a small cache with a write-ahead log in the specified directory, which isn't synced by the
+// ticker during the tests.
+func newWALTestCache(t *testing.T, dir string) (cache *LRUCache[string, []byte]) {
+	t.Helper()
+
+	return newTestCache(t, &Config[string, []byte]{WAL: &WALPolicy{Dir: dir, SyncInterval: time.Hour}})
+}
+
+// walSegmentSizes returns the sizes of the segments of the write-ahead log in the directory.
+func walSegmentSizes(t *testing.T, dir string) (sizes map[uint64]int64) {
+	t.Helper()
+
+	segments, err := walSegments(dir)
+	if err != nil {
+		t.Fatalf("walSegments() error = %v", err)
+	}
+	sizes = make(map[uint64]int64, len(segments))
+	for _, segment := range segments {
+		info, err := os.Stat(filepath.Join(dir, walSegmentName(segment)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[segment] = info.Size()
+	}
+
+	return sizes
+}
+
+// checkKeys checks that the cache holds exactly the specified keys, each with its key as value.
+func checkKeys(t *testing.T, cache *LRUCache[string, []byte], keys ...string) {
+	t.Helper()
+
+	if length := cache.Len(); length != int64(len(keys)) {
+		t.Errorf("Len() = %d, want %d", length, len(keys))
+	}
+	for _, key := range keys {
+		if value, err := cache.Get(key); err != nil || string(value) != key {
+			t.Errorf("Get(%q) = %q, %v, want %q, nil", key, value, err, key)
+		}
+	}
+}
+
+func TestWALRecoversTruncatedSegment(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := newWALTestCache(t, dir)
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := cache.Set(key, []byte(key)); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+	cache.Close()
+
+	// A crash while "c" was written leaves a segment, which ends in the middle of its record.
+	sizes := walSegmentSizes(t, dir)
+	if len(sizes) != 1 {
+		t.Fatalf("walSegments() = %v, want a single segment", sizes)
+	}
+	for segment, size := range sizes {
+		if err := os.Truncate(filepath.Join(dir, walSegmentName(segment)), size-3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache = newWALTestCache(t, dir)
+	checkKeys(t, cache, "a", "b")
+
+	// The writes after the recovery must not be lost behind the truncated record.
+	if _, err := cache.Set("d", []byte("d")); err != nil {
+		t.Fatalf("Set(%q) error = %v", "d", err)
+	}
+	cache.Close()
+
+	cache = newWALTestCache(t, dir)
+	checkKeys(t, cache, "a", "b", "d")
+}
+
+func TestCompactWAL(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := newWALTestCache(t, dir)
+	for range 10 {
+		for _, key := range []string{"a", "b", "c"} {
+			if _, err := cache.Set(key, []byte(key)); err != nil {
+				t.Fatalf("Set(%q) error = %v", key, err)
+			}
+		}
+	}
+	if _, err := cache.Remove("b"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := cache.wal.sync(); err != nil {
+		t.Fatalf("sync() error = %v", err)
+	}
+
+	var before int64
+	for _, size := range walSegmentSizes(t, dir) {
+		before += size
+	}
+
+	if err := cache.CompactWAL(); err != nil {
+		t.Fatalf("CompactWAL() error = %v", err)
+	}
+
+	// The superseded segment is replaced by the compacted segment and the segment of the further writes.
+	sizes := walSegmentSizes(t, dir)
+	if len(sizes) != 2 {
+		t.Fatalf("walSegments() = %v, want the compacted and the current segment", sizes)
+	}
+	var after int64
+	for _, size := range sizes {
+		after += size
+	}
+	if after >= before {
+		t.Errorf("CompactWAL() grew the log from %d to %d bytes", before, after)
+	}
+
+	if _, err := cache.Set("e", []byte("e")); err != nil {
+		t.Fatalf("Set(%q) error = %v", "e", err)
+	}
+	cache.Close()
+
+	cache = newWALTestCache(t, dir)
+	checkKeys(t, cache, "a", "c", "e")
+}
+
+// failingWriter is a writer, which fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (n int, err error) {
+	return 0, errors.New("disk is full")
+}
+
+func TestWALCountsDroppedRecords(t *testing.T) {
+	cache := newWALTestCache(t, t.TempDir())
+
+	cache.wal.Lock()
+	cache.wal.w = bufio.NewWriterSize(failingWriter{}, 16)
+	cache.wal.Unlock()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := cache.Set(key, []byte(strings.Repeat(key, 32))); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+
+	if err := cache.wal.sync(); err == nil {
+		t.Errorf("sync() error = nil, want the error of the failed write")
+	}
+
+	telemetry, err := cache.Telemetry()
+	if err != nil {
+		t.Fatalf("Telemetry() error = %v", err)
+	}
+	if dropped := telemetry.GetWALDroppedCounter(); dropped != 3 {
+		t.Errorf("GetWALDroppedCounter() = %d, want 3", dropped)
+	}
+}
+
+func FuzzDecodeRecord(f *testing.F) {
+	f.Add("key", []byte("value"), int64(0), "", "", "", int64(0), false)
+	f.Add("", []byte{}, time.Now().UnixNano(), "loader", "tag", "value", int64(42), true)
+	f.Add("\x00", []byte{0xff}, int64(-1), "\xff", "", "\x00", int64(-1<<63), true)
+
+	f.Fuzz(func(t *testing.T, key string, value []byte, ttl int64, source, tag, tagValue string, cost int64, withMeta bool) {
+		var meta *EntryMeta
+		if withMeta {
+			meta = &EntryMeta{CreatedAt: fromUnixNano(ttl), Source: source, Tags: map[string]string{tag: tagValue}, Cost: cost}
+		}
+
+		frame := appendRecord(nil, walSet, key, value, fromUnixNano(ttl), meta)
+		record, err := readRecord[string, []byte](bufio.NewReader(bytes.NewReader(frame)))
+		if err != nil {
+			t.Fatalf("readRecord() error = %v", err)
+		}
+		if record.op != walSet || record.key != key || !bytes.Equal(record.value, value) || unixNano(record.ttl) != ttl {
+			t.Fatalf("readRecord() = %+v, want the appended record", record)
+		}
+		if (record.meta != nil) != withMeta || withMeta && (record.meta.Source != source || record.meta.Cost != cost ||
+			!record.meta.CreatedAt.Equal(meta.CreatedAt) || !maps.Equal(record.meta.Tags, meta.Tags)) {
+			t.Fatalf("readRecord() meta = %+v, want %+v", record.meta, meta)
+		}
+
+		// Every prefix of the payload is a truncated record, which must be rejected or decoded without a panic.
+		payload, err := readFrame(bufio.NewReader(bytes.NewReader(frame)))
+		if err != nil {
+			t.Fatalf("readFrame() error = %v", err)
+		}
+		for n := range payload {
+			_, _ = decodeRecord[string, []byte](payload[:n])
+		}
+	})
+}
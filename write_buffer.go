@@ -0,0 +1,236 @@
+// BSD 3-Clause License
+//
+// Copyright © 2025 - Marius Romeiser. All rights reserved.
+//
+//               \   /                                         \   /
+//          .____-/.\-____.      `\\             //'      .____-/.\-____.
+//               ~`-'~             \\           //             ~`-'~
+//                                  \\. __-__ .//
+//                        ___/-_.-.__`/~     ~\'__.-._-\___
+// .|.       ___________.'__/__ ~-[ \.\'-----'/./ ]-~ __\__`.___________       .|.
+// ~o~~~~~~~--------______-~~~~~-_/_/ |   .   | \_\_-~~~~~-______--------~~~~~~~o~
+// ' `               + + +  (X)(X)  ~--\__ __/--~  (X)(X)  + + +               ' `
+//                              (X) `/.\' ~ `/.\' (X)
+//                                  "\_/"   "\_/"
+//                                __
+//    _________ ___  ______ _____/ /________  ____
+//   / ___/ __ `/ / / / __ `/ __  / ___/ __ \/ __ \
+//  (__  ) /_/ / /_/ / /_/ / /_/ / /  / /_/ / / / /
+// /____/\__, /\__,_/\__,_/\__,_/_/   \____/_/ /_/
+// squadron/_/
+//
+// The "sq_cache" package is a highly efficient, flexible caching library designed to enhance data retrieval and memory
+// management in applications that require fast data access. It provides a robust solution to caching with a focus on
+// optimizing both speed and memory usage. Using the Least Recently Used (LRU) eviction strategy, "sq_cache" ensures
+// that the least recently accessed cache entries are evicted when the cache reaches its capacity, making it an ideal
+// choice for high-performance caching scenarios.
+//
+// The powerful library is suitable for applications requiring high-performance caching with fine-tuned memory
+// management. Its flexibility, including support for TTL, periodic cleanup, and detailed telemetry, makes it a perfect
+// choice for building efficient caching systems in a wide range of use cases.
+
+package sq_cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// writeBufferSlot represents a slot of a writeBuffer, which holds a buffered Set. The sequence tells the producers and
+// the consumers whether the slot is free or filled in the current lap of the ring.
+type writeBufferSlot[K IKey, V IValue] struct {
+	sequence atomic.Uint64
+
+	key   K
+	value V
+	ttl   time.Time
+	meta  *EntryMeta
+	// source is the source of the buffered Set.
+	source EntrySource
+}
+
+// writeBuffer represents a bounded, lock-free ring buffer of the Sets of a shard (multiple producers, multiple
+// consumers), which are applied to the shard asynchronously. The Sets are popped in the order they were pushed.
+type writeBuffer[K IKey, V IValue] struct {
+	slots []writeBufferSlot[K, V]
+	mask  uint64
+
+	enqueue atomic.Uint64
+	dequeue atomic.Uint64
+}
+
+// newWriteBuffer creates a writeBuffer, whose capacity is the specified size rounded up to a power of two.
+func newWriteBuffer[K IKey, V IValue](size int64) (buffer *writeBuffer[K, V]) {
+	capacity := uint64(1)
+	for capacity < uint64(max(size, 1)) {
+		capacity <<= 1
+	}
+
+	buffer = &writeBuffer[K, V]{
+		slots: make([]writeBufferSlot[K, V], capacity),
+		mask:  capacity - 1,
+	}
+	for i := range buffer.slots {
+		buffer.slots[i].sequence.Store(uint64(i))
+	}
+
+	return buffer
+}
+
+// push appends a Set to the buffer and reports whether it succeeded, i.e. whether the buffer wasn't full.
+func (buffer *writeBuffer[K, V]) push(
+	key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource,
+) (pushed bool) {
+	var slot *writeBufferSlot[K, V]
+
+	position := buffer.enqueue.Load()
+	for {
+		slot = &buffer.slots[position&buffer.mask]
+		switch diff := int64(slot.sequence.Load() - position); {
+		case diff == 0:
+			if buffer.enqueue.CompareAndSwap(position, position+1) {
+				slot.key, slot.value, slot.ttl, slot.meta, slot.source = key, value, ttl, meta, source
+				slot.sequence.Store(position + 1)
+
+				return true
+			}
+		case diff < 0:
+			return false
+		}
+		position = buffer.enqueue.Load()
+	}
+}
+
+// pop removes the oldest Set from the buffer and reports whether there was any.
+func (buffer *writeBuffer[K, V]) pop() (
+	key K, value V, ttl time.Time, meta *EntryMeta, source EntrySource, popped bool,
+) {
+	var slot *writeBufferSlot[K, V]
+
+	position := buffer.dequeue.Load()
+	for {
+		slot = &buffer.slots[position&buffer.mask]
+		switch diff := int64(slot.sequence.Load() - (position + 1)); {
+		case diff == 0:
+			if buffer.dequeue.CompareAndSwap(position, position+1) {
+				key, value, ttl, meta, source = slot.key, slot.value, slot.ttl, slot.meta, slot.source
+				slot.key, slot.value, slot.ttl, slot.meta, slot.source = *new(K), *new(V), time.Time{}, nil, SetSource
+				slot.sequence.Store(position + buffer.mask + 1)
+
+				return key, value, ttl, meta, source, true
+			}
+		case diff < 0:
+			return key, value, ttl, meta, source, false
+		}
+		position = buffer.dequeue.Load()
+	}
+}
+
+// empty reports whether the buffer holds no Sets, without synchronizing with concurrent pushes.
+func (buffer *writeBuffer[K, V]) empty() bool {
+	return buffer.enqueue.Load() == buffer.dequeue.Load()
+}
+
+// applyWrites applies the buffered Sets of the shard to the shard. Must be called with the lock of the shard held, so
+// that the Sets are applied in order.
+func (cache *LRUCache[K, V]) applyWrites(shardId int64) (evictCount int64) {
+	shard := cache.shards[shardId]
+
+	for {
+		key, value, ttl, meta, source, popped := cache.writeBuffers[shardId].pop()
+		if !popped {
+			return evictCount
+		}
+
+		shard.opSource = source
+		evicted, added := shard.Set(cache.len.Load(), key, value, ttl, meta)
+		shard.opSource = SetSource
+		cache.walSet(key, value, ttl, meta)
+		if added {
+			cache.len.Add(1)
+		}
+		cache.len.Add(-evicted)
+		evictCount += evicted
+
+		cache.trace(TraceSet, key, len(value))
+	}
+}
+
+// flushWrites applies the buffered Sets of the shard, if there are any.
+func (cache *LRUCache[K, V]) flushWrites(shardId int64) {
+	if cache.writeBuffers == nil || cache.writeBuffers[shardId].empty() {
+		return
+	}
+
+	cache.shards[shardId].LockMeasured()
+	cache.applyWrites(shardId)
+	cache.shards[shardId].Unlock()
+
+	cache.evictOverflow()
+}
+
+// readYourWrites applies the buffered and the coalesced Sets of the shard before a read, if the cache is configured to
+// read its own writes.
+func (cache *LRUCache[K, V]) readYourWrites(shardId int64) {
+	if cache.writeBufferReadYourWrites {
+		cache.flushWrites(shardId)
+		if cache.setCoalescers != nil {
+			cache.setCoalescers[shardId].flushDirty()
+		}
+	}
+}
+
+// Flush applies the buffered Sets of all shards to the cache, so that they are visible to the following reads.
+//
+// Returns:
+//   - err: An error if the cache is closed, or if any other issue occurs.
+//
+// Example Usage:
+//
+//	for key, value := range batch {
+//	    _, _ = cache.Set(key, value)
+//	}
+//	if err := cache.Flush(); err != nil {
+//	    panic(err)
+//	}
+func (cache *LRUCache[K, V]) Flush() (err error) {
+	cache.inFlight.Add(1)
+	defer cache.inFlight.Add(-1)
+
+	switch cache.Status() {
+	case Closed:
+		return ErrClosed
+	}
+
+	for shardId := range cache.writeBuffers {
+		cache.flushWrites(int64(shardId))
+	}
+
+	return nil
+}
+
+// writeApplier applies the buffered Sets of all shards in the background, whenever Sets were buffered, until the
+// context is done.
+func (cache *LRUCache[K, V]) writeApplier(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-cache.writeSignal:
+			for shardId := range cache.writeBuffers {
+				cache.flushWrites(int64(shardId))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// signalWrites wakes up the write applier, without blocking if it was already woken up.
+func (cache *LRUCache[K, V]) signalWrites() {
+	select {
+	case cache.writeSignal <- struct{}{}:
+	default:
+	}
+}